@@ -0,0 +1,100 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This example is a local HTTP Event Collector (HEC) receiver forwarding the events sent by HEC agents to the ingest
+// service. The HEC tokens accepted are given as a comma-separated list, the SDK is configured with the BEARER_TOKEN,
+// SPLUNK_CLOUD_HOST and TENANT environment variables:
+//
+//	```$ go run ./examples/hecReceiver/hecReceiver.go -addr=:8088 -tokens=<token>[,<token>...]```
+//
+// Agents can then send events to http://localhost:8088/services/collector/event with the `Authorization: Splunk <token>`
+// header. The receiver stops on SIGINT or SIGTERM, once the events received were sent.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/ingest"
+)
+
+func main() {
+	addr := flag.String("addr", ":8088", "Address to listen on")
+	tokens := flag.String("tokens", os.Getenv("HEC_TOKENS"), "Comma-separated HEC tokens accepted, HEC_TOKENS by default")
+	batchSize := flag.Int("batch-size", 100, "Maximum number of events per batch")
+	interval := flag.Int64("interval", 1000, "Milliseconds to wait before sending a batch which is not full")
+	flag.Parse()
+	if *tokens == "" {
+		log.Fatal("at least one HEC token is required, see -tokens")
+	}
+
+	client, err := services.NewClient(&services.Config{
+		Token:  os.Getenv("BEARER_TOKEN"),
+		Host:   os.Getenv("SPLUNK_CLOUD_HOST"),
+		Tenant: os.Getenv("TENANT"),
+	})
+	exitOnError(err)
+	sender, err := ingest.NewService(client).NewBatchEventsSenderWithMaxAllowedError(*batchSize, *interval, 0, 10)
+	exitOnError(err)
+	sender.SetDeliveryHandler(func(result ingest.DeliveryResult) {
+		if result.Err != nil {
+			log.Printf("failed to send %d events: %v", len(result.Events), result.Err)
+		}
+	})
+	sender.Run()
+
+	server := &http.Server{
+		Addr:    *addr,
+		Handler: ingest.NewHECHandler(sender, ingest.HECHandlerConfig{Tokens: strings.Split(*tokens, ",")}),
+	}
+	// ListenAndServe returns as soon as Shutdown is called, shutdown is closed once the requests in progress are done
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			log.Printf("shutting down: %v", err)
+		}
+	}()
+
+	log.Printf("listening on %s", *addr)
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		exitOnError(err)
+	}
+	<-shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	exitOnError(sender.Close(ctx))
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/ingest"
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/search"
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/search/spl2"
 	testutils "github.com/khulnasoft/khulnasoft-cloud-sdk-go/test/utils"
 )
 
@@ -50,13 +51,17 @@ func main() {
 
 	//Do search and verify results
 	fmt.Println("Search event data")
-	query := fmt.Sprintf("|from  index:%v where host=\"%v\" and source=\"%v\"", index, host, source)
+	query := spl2.FromIndex(index).Where(spl2.And(spl2.Eq("host", host), spl2.Eq("source", source))).String()
 	fmt.Println(query)
 	demoSearchServiceSearchResults(client, query, 3, false)
 
 	//Search metrics data and verify
 	fmt.Println("Search metric data")
-	query = fmt.Sprintf("| from metrics group by host SELECT sum(CPU) as cpu,host |search host=\"%v\" AND cpu > 0", metricHost)
+	query = spl2.From("metrics").
+		GroupBy("host").
+		Select(spl2.As(spl2.Call("sum", spl2.Field("CPU")), "cpu"), spl2.Field("host")).
+		Search(spl2.And(spl2.Eq("host", metricHost), spl2.Gt("cpu", 0))).
+		String()
 	fmt.Println(query)
 	demoSearchServiceSearchResults(client, query, 1, false)
 }
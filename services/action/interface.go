@@ -18,6 +18,10 @@
 
 package action
 
+import (
+	"context"
+)
+
 // Servicer represents the interface for implementing all endpoints for this service
 type Servicer interface {
 	//interfaces that cannot be auto-generated from codegen
@@ -29,7 +33,18 @@ type Servicer interface {
 			triggerEvent: The action payload, which must include values for any templated fields.
 	*/
 	TriggerActionWithStatus(actionName string, triggerEvent TriggerEvent) (*TriggerResponse, error)
+	/*
+		TriggerActionWithStatusWithContext - Trigger an action and return a TriggerResponse with StatusID
+
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action, as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			triggerEvent: The action payload, which must include values for any templated fields.
+	*/
+	TriggerActionWithStatusWithContext(ctx context.Context, actionName string, triggerEvent TriggerEvent) (*TriggerResponse, error)
 
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
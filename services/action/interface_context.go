@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package action
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateActionWithContext - Creates an action template.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			action: The action template to create.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateActionWithContext(ctx context.Context, action Action, resp ...*http.Response) (*Action, error)
+	/*
+		DeleteActionWithContext - Removes an action template.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteActionWithContext(ctx context.Context, actionName string, resp ...*http.Response) error
+	/*
+		GetActionWithContext - Returns a specific action template.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetActionWithContext(ctx context.Context, actionName string, resp ...*http.Response) (*Action, error)
+	/*
+		GetActionStatusWithContext - Returns the status of an action that was invoked. The status is available for 4 days after the last status change.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			statusId: The ID of the action status.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetActionStatusWithContext(ctx context.Context, actionName string, statusId string, resp ...*http.Response) (*ActionResult, error)
+	/*
+		GetActionStatusDetailsWithContext - Returns the status details of the invoked email action. The status is available for 4 days after the last status change.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			statusId: The ID of the action status.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetActionStatusDetailsWithContext(ctx context.Context, actionName string, statusId string, resp ...*http.Response) ([]ActionResultEmailDetail, error)
+	/*
+		GetPublicWebhookKeysWithContext - Returns an array of one or two webhook keys. The first key is active. The second key, if present, is expired.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPublicWebhookKeysWithContext(ctx context.Context, resp ...*http.Response) ([]PublicWebhookKey, error)
+	/*
+		ListActionsWithContext - Returns the list of action templates.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListActionsWithContext(ctx context.Context, resp ...*http.Response) ([]Action, error)
+	/*
+		TriggerActionWithContext - Invokes an action.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			triggerEvent: The action payload, which should include values for any templated fields.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	TriggerActionWithContext(ctx context.Context, actionName string, triggerEvent TriggerEvent, resp ...*http.Response) error
+	/*
+		UpdateActionWithContext - Modifies an action template.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+			actionMutable: Updates to the action template.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateActionWithContext(ctx context.Context, actionName string, actionMutable ActionMutable, resp ...*http.Response) (*Action, error)
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package action
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateActionWithContext - Creates an action template.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	action: The action template to create.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateActionWithContext(ctx context.Context, action Action, resp ...*http.Response) (*Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "CreateAction")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: action})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Action
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteActionWithContext - Removes an action template.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteActionWithContext(ctx context.Context, actionName string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "action", "DeleteAction")
+	pp := struct {
+		ActionName string
+	}{
+		ActionName: actionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions/{{.ActionName}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetActionWithContext - Returns a specific action template.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetActionWithContext(ctx context.Context, actionName string, resp ...*http.Response) (*Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "GetAction")
+	pp := struct {
+		ActionName string
+	}{
+		ActionName: actionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions/{{.ActionName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Action
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetActionStatusWithContext - Returns the status of an action that was invoked. The status is available for 4 days after the last status change.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	statusId: The ID of the action status.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetActionStatusWithContext(ctx context.Context, actionName string, statusId string, resp ...*http.Response) (*ActionResult, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "GetActionStatus")
+	pp := struct {
+		ActionName string
+		StatusId   string
+	}{
+		ActionName: actionName,
+		StatusId:   statusId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions/{{.ActionName}}/status/{{.StatusId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ActionResult
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetActionStatusDetailsWithContext - Returns the status details of the invoked email action. The status is available for 4 days after the last status change.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	statusId: The ID of the action status.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetActionStatusDetailsWithContext(ctx context.Context, actionName string, statusId string, resp ...*http.Response) ([]ActionResultEmailDetail, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "GetActionStatusDetails")
+	pp := struct {
+		ActionName string
+		StatusId   string
+	}{
+		ActionName: actionName,
+		StatusId:   statusId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions/{{.ActionName}}/status/{{.StatusId}}/details`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []ActionResultEmailDetail
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+GetPublicWebhookKeysWithContext - Returns an array of one or two webhook keys. The first key is active. The second key, if present, is expired.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPublicWebhookKeysWithContext(ctx context.Context, resp ...*http.Response) ([]PublicWebhookKey, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "GetPublicWebhookKeys")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/action/v1beta2/webhook/keys`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []PublicWebhookKey
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListActionsWithContext - Returns the list of action templates.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListActionsWithContext(ctx context.Context, resp ...*http.Response) ([]Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "ListActions")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Action
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+TriggerActionWithContext - Invokes an action.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	triggerEvent: The action payload, which should include values for any templated fields.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) TriggerActionWithContext(ctx context.Context, actionName string, triggerEvent TriggerEvent, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "action", "TriggerAction")
+	pp := struct {
+		ActionName string
+	}{
+		ActionName: actionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions/{{.ActionName}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: triggerEvent})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+UpdateActionWithContext - Modifies an action template.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	actionMutable: Updates to the action template.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateActionWithContext(ctx context.Context, actionName string, actionMutable ActionMutable, resp ...*http.Response) (*Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "action", "UpdateAction")
+	pp := struct {
+		ActionName string
+	}{
+		ActionName: actionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/action/v1beta2/actions/{{.ActionName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: actionMutable})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Action
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
@@ -18,6 +18,7 @@
 package action
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -33,8 +34,21 @@ Parameters:
 	triggerEvent: The action payload, which must include values for any templated fields.
 */
 func (s *Service) TriggerActionWithStatus(actionName string, triggerEvent TriggerEvent) (*TriggerResponse, error) {
+	return s.TriggerActionWithStatusWithContext(context.Background(), actionName, triggerEvent)
+}
+
+/*
+TriggerActionWithStatusWithContext - Trigger an action and return a TriggerResponse with StatusID
+
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	actionName: The name of the action, as one or more identifier strings separated by periods. Each identifier string consists of lowercase letters, digits, and underscores, and cannot start with a digit.
+	triggerEvent: The action payload, which must include values for any templated fields.
+*/
+func (s *Service) TriggerActionWithStatusWithContext(ctx context.Context, actionName string, triggerEvent TriggerEvent) (*TriggerResponse, error) {
 	var resp http.Response
-	err := s.TriggerAction(actionName, triggerEvent, &resp)
+	err := s.TriggerActionWithContext(ctx, actionName, triggerEvent, &resp)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,133 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package appregistry
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateAppWithContext - appregistry service endpoint
+		Creates an app.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			createAppRequest: Creates a new app.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateAppWithContext(ctx context.Context, createAppRequest CreateAppRequest, resp ...*http.Response) (*AppResponseCreateUpdate, error)
+	/*
+		CreateSubscriptionWithContext - appregistry service endpoint
+		Creates a subscription.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: Creates a subscription between a tenant and an app.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateSubscriptionWithContext(ctx context.Context, appName AppName, resp ...*http.Response) error
+	/*
+		DeleteAppWithContext - appregistry service endpoint
+		Removes an app.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteAppWithContext(ctx context.Context, appName string, resp ...*http.Response) error
+	/*
+		DeleteSubscriptionWithContext - appregistry service endpoint
+		Removes a subscription.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteSubscriptionWithContext(ctx context.Context, appName string, resp ...*http.Response) error
+	/*
+		GetAppWithContext - appregistry service endpoint
+		Returns the metadata of an app.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetAppWithContext(ctx context.Context, appName string, resp ...*http.Response) (*AppResponseGetList, error)
+	/*
+		GetKeysWithContext - appregistry service endpoint
+		Returns a list of the public keys used for verifying signed webhook requests.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetKeysWithContext(ctx context.Context, resp ...*http.Response) ([]Key, error)
+	/*
+		GetSubscriptionWithContext - appregistry service endpoint
+		Returns or validates a subscription.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetSubscriptionWithContext(ctx context.Context, appName string, resp ...*http.Response) (*Subscription, error)
+	/*
+		ListAppSubscriptionsWithContext - appregistry service endpoint
+		Returns the collection of subscriptions to an app.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListAppSubscriptionsWithContext(ctx context.Context, appName string, resp ...*http.Response) ([]Subscription, error)
+	/*
+		ListAppsWithContext - appregistry service endpoint
+		Returns a list of apps.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListAppsWithContext(ctx context.Context, resp ...*http.Response) ([]AppResponseGetList, error)
+	/*
+		ListSubscriptionsWithContext - appregistry service endpoint
+		Returns the tenant subscriptions.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListSubscriptionsWithContext(ctx context.Context, query *ListSubscriptionsQueryParams, resp ...*http.Response) ([]Subscription, error)
+	/*
+		RotateSecretWithContext - appregistry service endpoint
+		Rotates the client secret for an app.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	RotateSecretWithContext(ctx context.Context, appName string, resp ...*http.Response) (*AppResponseCreateUpdate, error)
+	/*
+		UpdateAppWithContext - appregistry service endpoint
+		Updates an app.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			appName: App name.
+			updateAppRequest: Updates app contents.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateAppWithContext(ctx context.Context, appName string, updateAppRequest UpdateAppRequest, resp ...*http.Response) (*AppResponseCreateUpdate, error)
+}
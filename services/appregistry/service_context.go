@@ -0,0 +1,433 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package appregistry
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateAppWithContext - appregistry service endpoint
+Creates an app.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	createAppRequest: Creates a new app.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateAppWithContext(ctx context.Context, createAppRequest CreateAppRequest, resp ...*http.Response) (*AppResponseCreateUpdate, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "CreateApp")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: createAppRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb AppResponseCreateUpdate
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateSubscriptionWithContext - appregistry service endpoint
+Creates a subscription.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: Creates a subscription between a tenant and an app.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateSubscriptionWithContext(ctx context.Context, appName AppName, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "CreateSubscription")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/subscriptions`, nil)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: appName})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteAppWithContext - appregistry service endpoint
+Removes an app.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteAppWithContext(ctx context.Context, appName string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "DeleteApp")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps/{{.AppName}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteSubscriptionWithContext - appregistry service endpoint
+Removes a subscription.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteSubscriptionWithContext(ctx context.Context, appName string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "DeleteSubscription")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/subscriptions/{{.AppName}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetAppWithContext - appregistry service endpoint
+Returns the metadata of an app.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetAppWithContext(ctx context.Context, appName string, resp ...*http.Response) (*AppResponseGetList, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "GetApp")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps/{{.AppName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb AppResponseGetList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetKeysWithContext - appregistry service endpoint
+Returns a list of the public keys used for verifying signed webhook requests.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetKeysWithContext(ctx context.Context, resp ...*http.Response) ([]Key, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "GetKeys")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/app-registry/v1beta2/keys`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Key
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+GetSubscriptionWithContext - appregistry service endpoint
+Returns or validates a subscription.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetSubscriptionWithContext(ctx context.Context, appName string, resp ...*http.Response) (*Subscription, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "GetSubscription")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/subscriptions/{{.AppName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Subscription
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListAppSubscriptionsWithContext - appregistry service endpoint
+Returns the collection of subscriptions to an app.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListAppSubscriptionsWithContext(ctx context.Context, appName string, resp ...*http.Response) ([]Subscription, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "ListAppSubscriptions")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps/{{.AppName}}/subscriptions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Subscription
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListAppsWithContext - appregistry service endpoint
+Returns a list of apps.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListAppsWithContext(ctx context.Context, resp ...*http.Response) ([]AppResponseGetList, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "ListApps")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []AppResponseGetList
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListSubscriptionsWithContext - appregistry service endpoint
+Returns the tenant subscriptions.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListSubscriptionsWithContext(ctx context.Context, query *ListSubscriptionsQueryParams, resp ...*http.Response) ([]Subscription, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "ListSubscriptions")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/app-registry/v1beta2/subscriptions`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Subscription
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+RotateSecretWithContext - appregistry service endpoint
+Rotates the client secret for an app.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RotateSecretWithContext(ctx context.Context, appName string, resp ...*http.Response) (*AppResponseCreateUpdate, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "RotateSecret")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps/{{.AppName}}/rotate-secret`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb AppResponseCreateUpdate
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateAppWithContext - appregistry service endpoint
+Updates an app.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	appName: App name.
+	updateAppRequest: Updates app contents.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateAppWithContext(ctx context.Context, appName string, updateAppRequest UpdateAppRequest, resp ...*http.Response) (*AppResponseCreateUpdate, error) {
+	ctx = sdkservices.WithOperation(ctx, "appregistry", "UpdateApp")
+	pp := struct {
+		AppName string
+	}{
+		AppName: appName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/app-registry/v1beta2/apps/{{.AppName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updateAppRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb AppResponseCreateUpdate
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
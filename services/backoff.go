@@ -0,0 +1,133 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JitterStrategy defines how the exponential backoff between retries is randomized so that clients
+// throttled at the same time don't all retry at the same time
+type JitterStrategy int
+
+const (
+	// NoJitter waits exactly interval * 2^(retry num), capped by MaxBackoff
+	NoJitter JitterStrategy = iota
+	// FullJitter waits a random duration between 0 and the capped exponential backoff
+	FullJitter
+	// EqualJitter waits half of the capped exponential backoff plus a random duration up to the other half
+	EqualJitter
+	// DecorrelatedJitter waits a random duration between interval and three times the previous wait, capped by MaxBackoff
+	DecorrelatedJitter
+)
+
+// clock abstracts the passing of time so that backoffs can be tested without sleeping
+type clock interface {
+	Now() time.Time
+	// NewTimer returns a channel which receives once d has elapsed and a func to stop the timer
+	NewTimer(d time.Duration) (<-chan time.Time, func() bool)
+}
+
+// realClock implements clock using the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	t := time.NewTimer(d)
+	return t.C, t.Stop
+}
+
+// randInt63n returns a random int64 in [0,n), it is a variable so that tests can make jitter deterministic
+var randInt63n = rand.Int63n
+
+// backoff returns how long to wait before the next attempt given the number of attempts made so far
+// and the previous wait (used by DecorrelatedJitter)
+func (c ConfigurableRetryConfig) backoff(numAttempts uint, previous time.Duration) time.Duration {
+	base := time.Duration(c.Interval) * time.Millisecond
+	if base <= 0 {
+		return 0
+	}
+	maxBackoff := c.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = math.MaxInt64
+	}
+	if c.Jitter == DecorrelatedJitter {
+		if previous < base {
+			previous = base
+		}
+		upper := previous * 3
+		if upper/3 != previous || upper > maxBackoff { // guard against overflow
+			upper = maxBackoff
+		}
+		if upper <= base {
+			return upper
+		}
+		return base + time.Duration(randInt63n(int64(upper-base)+1))
+	}
+
+	exp := maxBackoff
+	if numAttempts < 63 && base <= maxBackoff>>numAttempts {
+		exp = base << numAttempts
+	}
+	switch c.Jitter {
+	case FullJitter:
+		return time.Duration(randInt63n(int64(exp) + 1))
+	case EqualJitter:
+		half := exp / 2
+		return half + time.Duration(randInt63n(int64(exp-half)+1))
+	default:
+		return exp
+	}
+}
+
+// parseRetryAfter returns the wait requested by the Retry-After header of a 429 or 503 response,
+// expressed either in seconds or as an HTTP-date relative to now
+func parseRetryAfter(response *http.Response, now time.Time) (time.Duration, bool) {
+	if response == nil || (response.StatusCode != http.StatusTooManyRequests && response.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	value := strings.TrimSpace(response.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return time.Duration(math.MaxInt64), true
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := date.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock advances instantly whenever a timer is created and records every wait
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) (<-chan time.Time, func() bool) {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch, func() bool { return false }
+}
+
+// retryAfterRT returns the given responses in order, then 200 OK
+type retryAfterRT struct {
+	N         int
+	responses []*http.Response
+}
+
+func (rt *retryAfterRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.N++
+	if rt.N <= len(rt.responses) {
+		resp := rt.responses[rt.N-1]
+		resp.Body = ioutil.NopCloser(bytes.NewReader([]byte("")))
+		return resp, nil
+	}
+	b := ioutil.NopCloser(bytes.NewReader([]byte("")))
+	return &http.Response{Status: "200 OK", StatusCode: 200, Body: b}, nil
+}
+
+func throttled(code int, retryAfter string) *http.Response {
+	resp := &http.Response{StatusCode: code, Status: http.StatusText(code), Header: http.Header{}}
+	if retryAfter != "" {
+		resp.Header.Set("Retry-After", retryAfter)
+	}
+	return resp
+}
+
+func newFakeClockClient(t *testing.T, rt http.RoundTripper, config ConfigurableRetryConfig, clk *fakeClock) *BaseClient {
+	config.clock = clk
+	client, err := NewClient(&Config{
+		Token:         "testtoken",
+		RetryRequests: true,
+		RetryConfig:   RetryStrategyConfig{ConfigurableRetryConfig: &config},
+		RoundTripper:  rt,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestBackoffNoJitter(t *testing.T) {
+	config := ConfigurableRetryConfig{Interval: 100}
+	assert.Equal(t, 200*time.Millisecond, config.backoff(1, 0))
+	assert.Equal(t, 400*time.Millisecond, config.backoff(2, 0))
+	assert.Equal(t, 800*time.Millisecond, config.backoff(3, 0))
+	config.MaxBackoff = 500 * time.Millisecond
+	assert.Equal(t, 500*time.Millisecond, config.backoff(3, 0))
+	// Very large attempt numbers must not overflow
+	assert.Equal(t, 500*time.Millisecond, config.backoff(200, 0))
+}
+
+func TestBackoffJitterBounds(t *testing.T) {
+	defer func(orig func(int64) int64) { randInt63n = orig }(randInt63n)
+	var upper int64
+	randInt63n = func(n int64) int64 {
+		upper = n
+		return n - 1
+	}
+	config := ConfigurableRetryConfig{Interval: 100, MaxBackoff: time.Second}
+
+	config.Jitter = FullJitter
+	assert.Equal(t, 800*time.Millisecond, config.backoff(3, 0))
+	assert.Equal(t, int64(800*time.Millisecond)+1, upper)
+
+	config.Jitter = EqualJitter
+	assert.Equal(t, 800*time.Millisecond, config.backoff(3, 0))
+	randInt63n = func(n int64) int64 { return 0 }
+	assert.Equal(t, 400*time.Millisecond, config.backoff(3, 0))
+
+	config.Jitter = DecorrelatedJitter
+	assert.Equal(t, 100*time.Millisecond, config.backoff(3, 200*time.Millisecond))
+	randInt63n = func(n int64) int64 { return n - 1 }
+	assert.Equal(t, 600*time.Millisecond, config.backoff(3, 200*time.Millisecond))
+	// capped by MaxBackoff
+	assert.Equal(t, time.Second, config.backoff(3, 900*time.Millisecond))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	wait, ok := parseRetryAfter(throttled(429, "7"), now)
+	require.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	wait, ok = parseRetryAfter(throttled(503, now.Add(90*time.Second).Format(http.TimeFormat)), now)
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Second, wait)
+
+	// dates in the past mean retry now
+	wait, ok = parseRetryAfter(throttled(503, now.Add(-time.Minute).Format(http.TimeFormat)), now)
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), wait)
+
+	_, ok = parseRetryAfter(throttled(429, "soon"), now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter(throttled(429, ""), now)
+	assert.False(t, ok)
+	_, ok = parseRetryAfter(throttled(500, "7"), now)
+	assert.False(t, ok, "Retry-After is only honored for 429 and 503 responses")
+	_, ok = parseRetryAfter(nil, now)
+	assert.False(t, ok)
+}
+
+func TestClientRetryAfterSeconds(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, "3"), throttled(429, "")}}
+	client := newFakeClockClient(t, rt, ConfigurableRetryConfig{RetryNum: 5, Interval: 10}, clk)
+	resp, err := client.Get(services.RequestParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 3, rt.N)
+	// First wait is dictated by the server, second one is the exponential backoff for the 2nd attempt
+	assert.Equal(t, []time.Duration{3 * time.Second, 40 * time.Millisecond}, clk.waits)
+}
+
+func TestClientRetryAfterHTTPDate(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	rt := &retryAfterRT{responses: []*http.Response{throttled(503, clk.now.Add(5*time.Second).Format(http.TimeFormat))}}
+	retryFn := func(request *Request, reqErr error, response *http.Response, maxRetries uint) bool {
+		return request.NumAttempts <= maxRetries && response != nil && response.StatusCode == 503
+	}
+	client := newFakeClockClient(t, rt, ConfigurableRetryConfig{RetryNum: 5, Interval: 10, ShouldRetryFn: retryFn}, clk)
+	resp, err := client.Get(services.RequestParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []time.Duration{5 * time.Second}, clk.waits)
+}
+
+func TestClientRetryAfterIgnored(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, "3")}}
+	client := newFakeClockClient(t, rt, ConfigurableRetryConfig{RetryNum: 5, Interval: 10, IgnoreRetryAfter: true}, clk)
+	_, err := client.Get(services.RequestParams{})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{20 * time.Millisecond}, clk.waits)
+}
+
+func TestClientRetryAfterBeyondMaxBackoff(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, "120")}}
+	client := newFakeClockClient(t, rt, ConfigurableRetryConfig{RetryNum: 5, Interval: 10, MaxBackoff: time.Minute}, clk)
+	_, err := client.Get(services.RequestParams{})
+	require.Error(t, err)
+	assert.Equal(t, 1, rt.N, "request should not be retried when the server asks to wait longer than MaxBackoff")
+	assert.Empty(t, clk.waits)
+}
+
+func TestClientRetryMaxBackoff(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, ""), throttled(429, ""), throttled(429, ""), throttled(429, "")}}
+	client := newFakeClockClient(t, rt, ConfigurableRetryConfig{RetryNum: 5, Interval: 100, MaxBackoff: 500 * time.Millisecond}, clk)
+	_, err := client.Get(services.RequestParams{})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}, clk.waits)
+}
+
+func TestClientRetryMaxRetryDuration(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, ""), throttled(429, ""), throttled(429, ""), throttled(429, "")}}
+	client := newFakeClockClient(t, rt, ConfigurableRetryConfig{RetryNum: 5, Interval: 100, MaxRetryDuration: time.Second}, clk)
+	_, err := client.Get(services.RequestParams{})
+	require.Error(t, err)
+	// 200ms + 400ms fit within the budget, the next 800ms wait does not
+	assert.Equal(t, []time.Duration{200 * time.Millisecond, 400 * time.Millisecond}, clk.waits)
+	assert.Equal(t, 3, rt.N)
+}
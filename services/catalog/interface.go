@@ -18,8 +18,37 @@
 
 package catalog
 
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
 // Servicer represents the interface for implementing all endpoints for this service
 type Servicer interface {
+	//interfaces that cannot be auto-generated from codegen
+	// AllDatasets returns an iterator over every dataset matching query, requesting pages as they are needed
+	AllDatasets(ctx context.Context, query *ListDatasetsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[DatasetGet, error]
+	// AllRules returns an iterator over every rule matching query, requesting pages as they are needed
+	AllRules(ctx context.Context, query *ListRulesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Rule, error]
+	// AllActionsForRule returns an iterator over every action of the rule ruleresource matching query, requesting pages as they are needed
+	AllActionsForRule(ctx context.Context, ruleresource string, query *ListActionsForRuleQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Action, error]
+	// AllAnnotations returns an iterator over every annotation matching query, requesting pages as they are needed
+	AllAnnotations(ctx context.Context, query *ListAnnotationsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Annotation, error]
+	// AllAnnotationsForDataset returns an iterator over every annotation of the dataset datasetresource matching query, requesting pages as they are needed
+	AllAnnotationsForDataset(ctx context.Context, datasetresource string, query *ListAnnotationsForDatasetQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Annotation, error]
+	// AllDashboards returns an iterator over every dashboard matching query, requesting pages as they are needed
+	AllDashboards(ctx context.Context, query *ListDashboardsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Dashboard, error]
+	// AllFields returns an iterator over every field matching query, requesting pages as they are needed
+	AllFields(ctx context.Context, query *ListFieldsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Field, error]
+	// AllFieldsForDataset returns an iterator over every field of the dataset datasetresource matching query, requesting pages as they are needed
+	AllFieldsForDataset(ctx context.Context, datasetresource string, query *ListFieldsForDatasetQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Field, error]
+	// AllRelationships returns an iterator over every relationship matching query, requesting pages as they are needed
+	AllRelationships(ctx context.Context, query *ListRelationshipsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Relationship, error]
+
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
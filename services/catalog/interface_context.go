@@ -0,0 +1,429 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package catalog
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateActionForRuleWithContext - catalog service endpoint
+		Creates a new action for the specified rule by rule id or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			actionPost: The JSON representation of the action to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateActionForRuleWithContext(ctx context.Context, ruleresource string, actionPost ActionPost, resp ...*http.Response) (*Action, error)
+	/*
+		CreateAnnotationForDashboardWithContext - catalog service endpoint
+		Creates a new annotation for the specified dashboard.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+			requestBody: The JSON representation of the annotation to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateAnnotationForDashboardWithContext(ctx context.Context, dashboardresource string, requestBody map[string]string, resp ...*http.Response) (*Annotation, error)
+	/*
+		CreateAnnotationForDatasetWithContext - catalog service endpoint
+		Creates a new annotation for the specified dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			requestBody: The JSON representation of the annotation to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateAnnotationForDatasetWithContext(ctx context.Context, datasetresource string, requestBody map[string]string, resp ...*http.Response) (*Annotation, error)
+	/*
+		CreateDashboardWithContext - catalog service endpoint
+		Creates a new dashboard.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardPost: The JSON representation of the Dashboard to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateDashboardWithContext(ctx context.Context, dashboardPost DashboardPost, resp ...*http.Response) (*Dashboard, error)
+	/*
+		CreateDatasetWithContext - catalog service endpoint
+		Creates a new dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetPost: JSON representation of the DatasetInfo to be persisted
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateDatasetWithContext(ctx context.Context, datasetPost DatasetPost, resp ...*http.Response) (*Dataset, error)
+	/*
+		CreateDatasetImportWithContext - catalog service endpoint
+		Creates a new dataset import using the ID or resource name of the imported dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			datasetImportedBy
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateDatasetImportWithContext(ctx context.Context, datasetresource string, datasetImportedBy DatasetImportedBy, resp ...*http.Response) (*DatasetImportedBy, error)
+	/*
+		CreateFieldForDatasetWithContext - catalog service endpoint
+		Adds a new field to the dataset with the specified ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			fieldPost: The JSON representation of the field to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateFieldForDatasetWithContext(ctx context.Context, datasetresource string, fieldPost FieldPost, resp ...*http.Response) (*Field, error)
+	/*
+		CreateRelationshipWithContext - catalog service endpoint
+		Creates a new relationship.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			relationshipPost: The JSON representation of the relationship to persist.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateRelationshipWithContext(ctx context.Context, relationshipPost RelationshipPost, resp ...*http.Response) (*Relationship, error)
+	/*
+		CreateRuleWithContext - catalog service endpoint
+		Creates a new rule.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			rulePost: The JSON representation of the rule to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateRuleWithContext(ctx context.Context, rulePost RulePost, resp ...*http.Response) (*Rule, error)
+	/*
+		DeleteActionByIdForRuleWithContext - catalog service endpoint
+		Deletes the action with the specified ID that is associated with the specified rule.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			actionid: ID of an Action.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteActionByIdForRuleWithContext(ctx context.Context, ruleresource string, actionid string, resp ...*http.Response) error
+	/*
+		DeleteAnnotationOfDashboardWithContext - catalog service endpoint
+		Deletes the annotation with the speciifed ID that is associted with the specified dashboard.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+			annotationid: ID of a annotation.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteAnnotationOfDashboardWithContext(ctx context.Context, dashboardresource string, annotationid string, resp ...*http.Response) error
+	/*
+		DeleteAnnotationOfDatasetWithContext - catalog service endpoint
+		Deletes the annotation with the specified ID that is associated with the specified dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			annotationid: ID of a annotation.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteAnnotationOfDatasetWithContext(ctx context.Context, datasetresource string, annotationid string, resp ...*http.Response) error
+	/*
+		DeleteDashboardWithContext - catalog service endpoint
+		Deletes the dashboard with the specified ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteDashboardWithContext(ctx context.Context, dashboardresource string, resp ...*http.Response) error
+	/*
+		DeleteDatasetWithContext - catalog service endpoint
+		Deletes the dataset with the specified ID or resource name. Deleting a dataset also deletes its dependent objects, such as fields.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteDatasetWithContext(ctx context.Context, datasetresource string, resp ...*http.Response) error
+	/*
+		DeleteFieldByIdForDatasetWithContext - catalog service endpoint
+		Deletes the field with the specified ID that is part of the specified dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			fieldid: ID of a Field.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteFieldByIdForDatasetWithContext(ctx context.Context, datasetresource string, fieldid string, resp ...*http.Response) error
+	/*
+		DeleteRelationshipByIdWithContext - catalog service endpoint
+		Deletes the relationship with the specified relationship ID. Deleting a relationship also deletes any objects that are dependents of that relationship, such as relationship fields.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			relationshipid: ID of a relationship.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteRelationshipByIdWithContext(ctx context.Context, relationshipid string, resp ...*http.Response) error
+	/*
+		DeleteRuleWithContext - catalog service endpoint
+		Deletes the rule with the specfied ID or resource name. Deleting a rule also deleletes any objects that are dependents of that rule, such as rule actions.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteRuleWithContext(ctx context.Context, ruleresource string, resp ...*http.Response) error
+	/*
+		GetActionByIdForRuleWithContext - catalog service endpoint
+		Returns information about the action with the specified ID that is associated with the specified rule.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			actionid: ID of an Action.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetActionByIdForRuleWithContext(ctx context.Context, ruleresource string, actionid string, resp ...*http.Response) (*Action, error)
+	/*
+		GetDashboardWithContext - catalog service endpoint
+		Returns information about the dashboard with the specified ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetDashboardWithContext(ctx context.Context, dashboardresource string, resp ...*http.Response) (*Dashboard, error)
+	/*
+		GetDatasetWithContext - catalog service endpoint
+		Returns information about the dataset with the specified ID or resource name. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetDatasetWithContext(ctx context.Context, datasetresource string, query *GetDatasetQueryParams, resp ...*http.Response) (*DatasetGet, error)
+	/*
+		GetFieldByIdWithContext - catalog service endpoint
+		Returns the field with the specified ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			fieldid: ID of a Field.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetFieldByIdWithContext(ctx context.Context, fieldid string, resp ...*http.Response) (*Field, error)
+	/*
+		GetFieldByIdForDatasetWithContext - catalog service endpoint
+		Returns the field with the specified ID that is part of the specified dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			fieldid: ID of a Field.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetFieldByIdForDatasetWithContext(ctx context.Context, datasetresource string, fieldid string, resp ...*http.Response) (*Field, error)
+	/*
+		GetRelationshipByIdWithContext - catalog service endpoint
+		Returns the relationship with the specified relationship ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			relationshipid: ID of a relationship.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetRelationshipByIdWithContext(ctx context.Context, relationshipid string, resp ...*http.Response) (*Relationship, error)
+	/*
+		GetRuleWithContext - catalog service endpoint
+		Returns information about rule with the specified rule ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetRuleWithContext(ctx context.Context, ruleresource string, resp ...*http.Response) (*Rule, error)
+	/*
+		ImportDatasetWithContext - catalog service endpoint
+		Creates a new dataset import using the ID or resource name of the imported dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			datasetImportedBy
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ImportDatasetWithContext(ctx context.Context, datasetresource string, datasetImportedBy DatasetImportedBy, resp ...*http.Response) (*DatasetImportedBy, error)
+	/*
+		ListActionsForRuleWithContext - catalog service endpoint
+		Returns the set of actions that are part of the specified rule.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListActionsForRuleWithContext(ctx context.Context, ruleresource string, query *ListActionsForRuleQueryParams, resp ...*http.Response) ([]Action, error)
+	/*
+		ListAnnotationsWithContext - catalog service endpoint
+		Returns the set of annotations across all objects.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListAnnotationsWithContext(ctx context.Context, query *ListAnnotationsQueryParams, resp ...*http.Response) ([]Annotation, error)
+	/*
+		ListAnnotationsForDashboardWithContext - catalog service endpoint
+		Returns the set of annotations that are associated with the specified dashboard.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListAnnotationsForDashboardWithContext(ctx context.Context, dashboardresource string, query *ListAnnotationsForDashboardQueryParams, resp ...*http.Response) ([]Annotation, error)
+	/*
+		ListAnnotationsForDatasetWithContext - catalog service endpoint
+		Returns the set of annotations that are associated with the specified dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListAnnotationsForDatasetWithContext(ctx context.Context, datasetresource string, query *ListAnnotationsForDatasetQueryParams, resp ...*http.Response) ([]Annotation, error)
+	/*
+		ListDashboardsWithContext - catalog service endpoint
+		Returns a list of dashboards.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListDashboardsWithContext(ctx context.Context, query *ListDashboardsQueryParams, resp ...*http.Response) ([]Dashboard, error)
+	/*
+		ListDatasetsWithContext - catalog service endpoint
+		Returns a list of all datasets. Use a filter to return a specific list of datasets.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListDatasetsWithContext(ctx context.Context, query *ListDatasetsQueryParams, resp ...*http.Response) ([]DatasetGet, error)
+	/*
+		ListFieldsWithContext - catalog service endpoint
+		Returns a list of all of the fields in the Metadata Catalog.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListFieldsWithContext(ctx context.Context, query *ListFieldsQueryParams, resp ...*http.Response) ([]Field, error)
+	/*
+		ListFieldsForDatasetWithContext - catalog service endpoint
+		Returns the set of fields for the dataset with the specified ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListFieldsForDatasetWithContext(ctx context.Context, datasetresource string, query *ListFieldsForDatasetQueryParams, resp ...*http.Response) ([]Field, error)
+	/*
+		ListModulesWithContext - catalog service endpoint
+		Returns a list of all modules. Use a filter to return a specific list of modules.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListModulesWithContext(ctx context.Context, query *ListModulesQueryParams, resp ...*http.Response) ([]Module, error)
+	/*
+		ListRelationshipsWithContext - catalog service endpoint
+		Returns a list of all relationships. Use a filter to return a specific list of relationships.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListRelationshipsWithContext(ctx context.Context, query *ListRelationshipsQueryParams, resp ...*http.Response) ([]Relationship, error)
+	/*
+		ListRulesWithContext - catalog service endpoint
+		Returns a list of rules that match a filter, if specified, otherwise returns all rules.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListRulesWithContext(ctx context.Context, query *ListRulesQueryParams, resp ...*http.Response) ([]Rule, error)
+	/*
+		UpdateActionByIdForRuleWithContext - catalog service endpoint
+		Modifies the action with the specified ID that is associated with the specified rule.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			actionid: ID of an Action.
+			actionPatch: The properties to update in the specified action.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateActionByIdForRuleWithContext(ctx context.Context, ruleresource string, actionid string, actionPatch ActionPatch, resp ...*http.Response) (*Action, error)
+	/*
+		UpdateDashboardWithContext - catalog service endpoint
+		Modifies the dashboard with the specified ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+			dashboardPatch: An updated representation of the dashboard to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateDashboardWithContext(ctx context.Context, dashboardresource string, dashboardPatch DashboardPatch, resp ...*http.Response) (*Dashboard, error)
+	/*
+		UpdateDatasetWithContext - catalog service endpoint
+		Modifies the dataset with the specified Dataset ID or Resource Name. For the default module, the resource name format is datasetName, otherwise, the resource name format is module.datasetName.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			datasetPatch: An updated representation of the dataset to be persisted.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateDatasetWithContext(ctx context.Context, datasetresource string, datasetPatch DatasetPatch, resp ...*http.Response) (*Dataset, error)
+	/*
+		UpdateFieldByIdForDatasetWithContext - catalog service endpoint
+		Modifies the field with the specified ID that is part of the specified dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+			fieldid: ID of a Field.
+			fieldPatch: The properties to update in the specified field, or the requesting user lacks catalog.datasets.read permission for them.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateFieldByIdForDatasetWithContext(ctx context.Context, datasetresource string, fieldid string, fieldPatch FieldPatch, resp ...*http.Response) (*Field, error)
+	/*
+		UpdateRelationshipByIdWithContext - catalog service endpoint
+		Modifies the relationship with the specified relationship ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			relationshipid: ID of a relationship.
+			relationshipPatch: The properties to update in the specified relationship.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateRelationshipByIdWithContext(ctx context.Context, relationshipid string, relationshipPatch RelationshipPatch, resp ...*http.Response) (*Relationship, error)
+	/*
+		UpdateRuleWithContext - catalog service endpoint
+		Modifies the rule with the specified rule ID or resource name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+			rulePatch: The properties to update in the specified rule.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateRuleWithContext(ctx context.Context, ruleresource string, rulePatch RulePatch, resp ...*http.Response) (*Rule, error)
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package catalog
+
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+// AllDatasets returns an iterator over every dataset matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first dataset, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllDatasets(ctx context.Context, query *ListDatasetsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[DatasetGet, error] {
+	var q ListDatasetsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]DatasetGet, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		datasets, err := s.ListDatasetsWithContext(ctx, &page)
+		return datasets, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllRules returns an iterator over every rule matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first rule, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllRules(ctx context.Context, query *ListRulesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Rule, error] {
+	var q ListRulesQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Rule, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		rules, err := s.ListRulesWithContext(ctx, &page)
+		return rules, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllActionsForRule returns an iterator over every action of the rule ruleresource matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first action, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllActionsForRule(ctx context.Context, ruleresource string, query *ListActionsForRuleQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Action, error] {
+	var q ListActionsForRuleQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Action, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		actions, err := s.ListActionsForRuleWithContext(ctx, ruleresource, &page)
+		return actions, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllAnnotations returns an iterator over every annotation matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first annotation, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllAnnotations(ctx context.Context, query *ListAnnotationsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Annotation, error] {
+	var q ListAnnotationsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Annotation, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		annotations, err := s.ListAnnotationsWithContext(ctx, &page)
+		return annotations, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllAnnotationsForDataset returns an iterator over every annotation of the dataset datasetresource matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first annotation, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllAnnotationsForDataset(ctx context.Context, datasetresource string, query *ListAnnotationsForDatasetQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Annotation, error] {
+	var q ListAnnotationsForDatasetQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Annotation, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		annotations, err := s.ListAnnotationsForDatasetWithContext(ctx, datasetresource, &page)
+		return annotations, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllDashboards returns an iterator over every dashboard matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first dashboard, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllDashboards(ctx context.Context, query *ListDashboardsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Dashboard, error] {
+	var q ListDashboardsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Dashboard, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		dashboards, err := s.ListDashboardsWithContext(ctx, &page)
+		return dashboards, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllFields returns an iterator over every field matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first field, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllFields(ctx context.Context, query *ListFieldsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Field, error] {
+	var q ListFieldsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Field, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		fields, err := s.ListFieldsWithContext(ctx, &page)
+		return fields, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllFieldsForDataset returns an iterator over every field of the dataset datasetresource matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first field, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllFieldsForDataset(ctx context.Context, datasetresource string, query *ListFieldsForDatasetQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Field, error] {
+	var q ListFieldsForDatasetQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Field, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		fields, err := s.ListFieldsForDatasetWithContext(ctx, datasetresource, &page)
+		return fields, -1, err
+	}, opts...).All(ctx)
+}
+
+// AllRelationships returns an iterator over every relationship matching query, requesting pages as they are needed.
+// query.Count is used as the page size and query.Offset as the offset of the first relationship, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllRelationships(ctx context.Context, query *ListRelationshipsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Relationship, error] {
+	var q ListRelationshipsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]Relationship, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		relationships, err := s.ListRelationshipsWithContext(ctx, &page)
+		return relationships, -1, err
+	}, opts...).All(ctx)
+}
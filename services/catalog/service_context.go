@@ -0,0 +1,1541 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package catalog
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateActionForRuleWithContext - catalog service endpoint
+Creates a new action for the specified rule by rule id or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	actionPost: The JSON representation of the action to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateActionForRuleWithContext(ctx context.Context, ruleresource string, actionPost ActionPost, resp ...*http.Response) (*Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateActionForRule")
+	pp := struct {
+		Ruleresource string
+	}{
+		Ruleresource: ruleresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}/actions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: actionPost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Action
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateAnnotationForDashboardWithContext - catalog service endpoint
+Creates a new annotation for the specified dashboard.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+	requestBody: The JSON representation of the annotation to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateAnnotationForDashboardWithContext(ctx context.Context, dashboardresource string, requestBody map[string]string, resp ...*http.Response) (*Annotation, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateAnnotationForDashboard")
+	pp := struct {
+		Dashboardresource string
+	}{
+		Dashboardresource: dashboardresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/dashboards/{{.Dashboardresource}}/annotations`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: requestBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Annotation
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateAnnotationForDatasetWithContext - catalog service endpoint
+Creates a new annotation for the specified dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	requestBody: The JSON representation of the annotation to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateAnnotationForDatasetWithContext(ctx context.Context, datasetresource string, requestBody map[string]string, resp ...*http.Response) (*Annotation, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateAnnotationForDataset")
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/annotations`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: requestBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Annotation
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateDashboardWithContext - catalog service endpoint
+Creates a new dashboard.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardPost: The JSON representation of the Dashboard to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateDashboardWithContext(ctx context.Context, dashboardPost DashboardPost, resp ...*http.Response) (*Dashboard, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateDashboard")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/dashboards`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: dashboardPost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dashboard
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateDatasetWithContext - catalog service endpoint
+Creates a new dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetPost: JSON representation of the DatasetInfo to be persisted
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateDatasetWithContext(ctx context.Context, datasetPost DatasetPost, resp ...*http.Response) (*Dataset, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateDataset")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: datasetPost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dataset
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateDatasetImportWithContext - catalog service endpoint
+Creates a new dataset import using the ID or resource name of the imported dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	datasetImportedBy
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateDatasetImportWithContext(ctx context.Context, datasetresource string, datasetImportedBy DatasetImportedBy, resp ...*http.Response) (*DatasetImportedBy, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateDatasetImport")
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/imported-by`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: datasetImportedBy})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb DatasetImportedBy
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateFieldForDatasetWithContext - catalog service endpoint
+Adds a new field to the dataset with the specified ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	fieldPost: The JSON representation of the field to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateFieldForDatasetWithContext(ctx context.Context, datasetresource string, fieldPost FieldPost, resp ...*http.Response) (*Field, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateFieldForDataset")
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/fields`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: fieldPost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Field
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateRelationshipWithContext - catalog service endpoint
+Creates a new relationship.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	relationshipPost: The JSON representation of the relationship to persist.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateRelationshipWithContext(ctx context.Context, relationshipPost RelationshipPost, resp ...*http.Response) (*Relationship, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateRelationship")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/relationships`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: relationshipPost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Relationship
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateRuleWithContext - catalog service endpoint
+Creates a new rule.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	rulePost: The JSON representation of the rule to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateRuleWithContext(ctx context.Context, rulePost RulePost, resp ...*http.Response) (*Rule, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "CreateRule")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: rulePost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Rule
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteActionByIdForRuleWithContext - catalog service endpoint
+Deletes the action with the specified ID that is associated with the specified rule.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	actionid: ID of an Action.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteActionByIdForRuleWithContext(ctx context.Context, ruleresource string, actionid string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteActionByIdForRule")
+	pp := struct {
+		Ruleresource string
+		Actionid     string
+	}{
+		Ruleresource: ruleresource,
+		Actionid:     actionid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}/actions/{{.Actionid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteAnnotationOfDashboardWithContext - catalog service endpoint
+Deletes the annotation with the speciifed ID that is associted with the specified dashboard.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+	annotationid: ID of a annotation.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteAnnotationOfDashboardWithContext(ctx context.Context, dashboardresource string, annotationid string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteAnnotationOfDashboard")
+	pp := struct {
+		Dashboardresource string
+		Annotationid      string
+	}{
+		Dashboardresource: dashboardresource,
+		Annotationid:      annotationid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/dashboards/{{.Dashboardresource}}/annotations/{{.Annotationid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteAnnotationOfDatasetWithContext - catalog service endpoint
+Deletes the annotation with the specified ID that is associated with the specified dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	annotationid: ID of a annotation.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteAnnotationOfDatasetWithContext(ctx context.Context, datasetresource string, annotationid string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteAnnotationOfDataset")
+	pp := struct {
+		Datasetresource string
+		Annotationid    string
+	}{
+		Datasetresource: datasetresource,
+		Annotationid:    annotationid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/annotations/{{.Annotationid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteDashboardWithContext - catalog service endpoint
+Deletes the dashboard with the specified ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteDashboardWithContext(ctx context.Context, dashboardresource string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteDashboard")
+	pp := struct {
+		Dashboardresource string
+	}{
+		Dashboardresource: dashboardresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/dashboards/{{.Dashboardresource}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteDatasetWithContext - catalog service endpoint
+Deletes the dataset with the specified ID or resource name. Deleting a dataset also deletes its dependent objects, such as fields.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteDatasetWithContext(ctx context.Context, datasetresource string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteDataset")
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteFieldByIdForDatasetWithContext - catalog service endpoint
+Deletes the field with the specified ID that is part of the specified dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	fieldid: ID of a Field.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteFieldByIdForDatasetWithContext(ctx context.Context, datasetresource string, fieldid string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteFieldByIdForDataset")
+	pp := struct {
+		Datasetresource string
+		Fieldid         string
+	}{
+		Datasetresource: datasetresource,
+		Fieldid:         fieldid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/fields/{{.Fieldid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteRelationshipByIdWithContext - catalog service endpoint
+Deletes the relationship with the specified relationship ID. Deleting a relationship also deletes any objects that are dependents of that relationship, such as relationship fields.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	relationshipid: ID of a relationship.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteRelationshipByIdWithContext(ctx context.Context, relationshipid string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteRelationshipById")
+	pp := struct {
+		Relationshipid string
+	}{
+		Relationshipid: relationshipid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/relationships/{{.Relationshipid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteRuleWithContext - catalog service endpoint
+Deletes the rule with the specfied ID or resource name. Deleting a rule also deleletes any objects that are dependents of that rule, such as rule actions.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteRuleWithContext(ctx context.Context, ruleresource string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "DeleteRule")
+	pp := struct {
+		Ruleresource string
+	}{
+		Ruleresource: ruleresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetActionByIdForRuleWithContext - catalog service endpoint
+Returns information about the action with the specified ID that is associated with the specified rule.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	actionid: ID of an Action.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetActionByIdForRuleWithContext(ctx context.Context, ruleresource string, actionid string, resp ...*http.Response) (*Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetActionByIdForRule")
+	pp := struct {
+		Ruleresource string
+		Actionid     string
+	}{
+		Ruleresource: ruleresource,
+		Actionid:     actionid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}/actions/{{.Actionid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Action
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetDashboardWithContext - catalog service endpoint
+Returns information about the dashboard with the specified ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetDashboardWithContext(ctx context.Context, dashboardresource string, resp ...*http.Response) (*Dashboard, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetDashboard")
+	pp := struct {
+		Dashboardresource string
+	}{
+		Dashboardresource: dashboardresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/dashboards/{{.Dashboardresource}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dashboard
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetDatasetWithContext - catalog service endpoint
+Returns information about the dataset with the specified ID or resource name. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetDatasetWithContext(ctx context.Context, datasetresource string, query *GetDatasetQueryParams, resp ...*http.Response) (*DatasetGet, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetDataset")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb DatasetGet
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetFieldByIdWithContext - catalog service endpoint
+Returns the field with the specified ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	fieldid: ID of a Field.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetFieldByIdWithContext(ctx context.Context, fieldid string, resp ...*http.Response) (*Field, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetFieldById")
+	pp := struct {
+		Fieldid string
+	}{
+		Fieldid: fieldid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/fields/{{.Fieldid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Field
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetFieldByIdForDatasetWithContext - catalog service endpoint
+Returns the field with the specified ID that is part of the specified dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	fieldid: ID of a Field.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetFieldByIdForDatasetWithContext(ctx context.Context, datasetresource string, fieldid string, resp ...*http.Response) (*Field, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetFieldByIdForDataset")
+	pp := struct {
+		Datasetresource string
+		Fieldid         string
+	}{
+		Datasetresource: datasetresource,
+		Fieldid:         fieldid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/fields/{{.Fieldid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Field
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetRelationshipByIdWithContext - catalog service endpoint
+Returns the relationship with the specified relationship ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	relationshipid: ID of a relationship.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetRelationshipByIdWithContext(ctx context.Context, relationshipid string, resp ...*http.Response) (*Relationship, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetRelationshipById")
+	pp := struct {
+		Relationshipid string
+	}{
+		Relationshipid: relationshipid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/relationships/{{.Relationshipid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Relationship
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetRuleWithContext - catalog service endpoint
+Returns information about rule with the specified rule ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetRuleWithContext(ctx context.Context, ruleresource string, resp ...*http.Response) (*Rule, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "GetRule")
+	pp := struct {
+		Ruleresource string
+	}{
+		Ruleresource: ruleresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Rule
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ImportDatasetWithContext - catalog service endpoint
+Creates a new dataset import using the ID or resource name of the imported dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	datasetImportedBy
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ImportDatasetWithContext(ctx context.Context, datasetresource string, datasetImportedBy DatasetImportedBy, resp ...*http.Response) (*DatasetImportedBy, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ImportDataset")
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/importedby`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: datasetImportedBy})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb DatasetImportedBy
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListActionsForRuleWithContext - catalog service endpoint
+Returns the set of actions that are part of the specified rule.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListActionsForRuleWithContext(ctx context.Context, ruleresource string, query *ListActionsForRuleQueryParams, resp ...*http.Response) ([]Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListActionsForRule")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Ruleresource string
+	}{
+		Ruleresource: ruleresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}/actions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Action
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListAnnotationsWithContext - catalog service endpoint
+Returns the set of annotations across all objects.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListAnnotationsWithContext(ctx context.Context, query *ListAnnotationsQueryParams, resp ...*http.Response) ([]Annotation, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListAnnotations")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/annotations`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Annotation
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListAnnotationsForDashboardWithContext - catalog service endpoint
+Returns the set of annotations that are associated with the specified dashboard.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListAnnotationsForDashboardWithContext(ctx context.Context, dashboardresource string, query *ListAnnotationsForDashboardQueryParams, resp ...*http.Response) ([]Annotation, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListAnnotationsForDashboard")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Dashboardresource string
+	}{
+		Dashboardresource: dashboardresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/dashboards/{{.Dashboardresource}}/annotations`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Annotation
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListAnnotationsForDatasetWithContext - catalog service endpoint
+Returns the set of annotations that are associated with the specified dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListAnnotationsForDatasetWithContext(ctx context.Context, datasetresource string, query *ListAnnotationsForDatasetQueryParams, resp ...*http.Response) ([]Annotation, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListAnnotationsForDataset")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/annotations`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Annotation
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListDashboardsWithContext - catalog service endpoint
+Returns a list of dashboards.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListDashboardsWithContext(ctx context.Context, query *ListDashboardsQueryParams, resp ...*http.Response) ([]Dashboard, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListDashboards")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/dashboards`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Dashboard
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListDatasetsWithContext - catalog service endpoint
+Returns a list of all datasets. Use a filter to return a specific list of datasets.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListDatasetsWithContext(ctx context.Context, query *ListDatasetsQueryParams, resp ...*http.Response) ([]DatasetGet, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListDatasets")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/datasets`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []DatasetGet
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListFieldsWithContext - catalog service endpoint
+Returns a list of all of the fields in the Metadata Catalog.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListFieldsWithContext(ctx context.Context, query *ListFieldsQueryParams, resp ...*http.Response) ([]Field, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListFields")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/fields`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Field
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListFieldsForDatasetWithContext - catalog service endpoint
+Returns the set of fields for the dataset with the specified ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListFieldsForDatasetWithContext(ctx context.Context, datasetresource string, query *ListFieldsForDatasetQueryParams, resp ...*http.Response) ([]Field, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListFieldsForDataset")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/fields`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Field
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListModulesWithContext - catalog service endpoint
+Returns a list of all modules. Use a filter to return a specific list of modules.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListModulesWithContext(ctx context.Context, query *ListModulesQueryParams, resp ...*http.Response) ([]Module, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListModules")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/modules`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Module
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListRelationshipsWithContext - catalog service endpoint
+Returns a list of all relationships. Use a filter to return a specific list of relationships.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListRelationshipsWithContext(ctx context.Context, query *ListRelationshipsQueryParams, resp ...*http.Response) ([]Relationship, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListRelationships")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/relationships`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Relationship
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListRulesWithContext - catalog service endpoint
+Returns a list of rules that match a filter, if specified, otherwise returns all rules.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListRulesWithContext(ctx context.Context, query *ListRulesQueryParams, resp ...*http.Response) ([]Rule, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "ListRules")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/catalog/v2beta1/rules`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []Rule
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+UpdateActionByIdForRuleWithContext - catalog service endpoint
+Modifies the action with the specified ID that is associated with the specified rule.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	actionid: ID of an Action.
+	actionPatch: The properties to update in the specified action.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateActionByIdForRuleWithContext(ctx context.Context, ruleresource string, actionid string, actionPatch ActionPatch, resp ...*http.Response) (*Action, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "UpdateActionByIdForRule")
+	pp := struct {
+		Ruleresource string
+		Actionid     string
+	}{
+		Ruleresource: ruleresource,
+		Actionid:     actionid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}/actions/{{.Actionid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: actionPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Action
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateDashboardWithContext - catalog service endpoint
+Modifies the dashboard with the specified ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	dashboardresource: ID or the resource name of a dashvboard. The resource name format is module.dashboardname.
+	dashboardPatch: An updated representation of the dashboard to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateDashboardWithContext(ctx context.Context, dashboardresource string, dashboardPatch DashboardPatch, resp ...*http.Response) (*Dashboard, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "UpdateDashboard")
+	pp := struct {
+		Dashboardresource string
+	}{
+		Dashboardresource: dashboardresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/dashboards/{{.Dashboardresource}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: dashboardPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dashboard
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateDatasetWithContext - catalog service endpoint
+Modifies the dataset with the specified Dataset ID or Resource Name. For the default module, the resource name format is datasetName, otherwise, the resource name format is module.datasetName.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	datasetPatch: An updated representation of the dataset to be persisted.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateDatasetWithContext(ctx context.Context, datasetresource string, datasetPatch DatasetPatch, resp ...*http.Response) (*Dataset, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "UpdateDataset")
+	pp := struct {
+		Datasetresource string
+	}{
+		Datasetresource: datasetresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: datasetPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dataset
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateFieldByIdForDatasetWithContext - catalog service endpoint
+Modifies the field with the specified ID that is part of the specified dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetresource: ID of a Dataset or the resource name of a dataset. For the default module, the resource name format is datasetName. Otherwise, the resource name format is module.datasetName.
+	fieldid: ID of a Field.
+	fieldPatch: The properties to update in the specified field, or the requesting user lacks catalog.datasets.read permission for them.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateFieldByIdForDatasetWithContext(ctx context.Context, datasetresource string, fieldid string, fieldPatch FieldPatch, resp ...*http.Response) (*Field, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "UpdateFieldByIdForDataset")
+	pp := struct {
+		Datasetresource string
+		Fieldid         string
+	}{
+		Datasetresource: datasetresource,
+		Fieldid:         fieldid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/datasets/{{.Datasetresource}}/fields/{{.Fieldid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: fieldPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Field
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateRelationshipByIdWithContext - catalog service endpoint
+Modifies the relationship with the specified relationship ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	relationshipid: ID of a relationship.
+	relationshipPatch: The properties to update in the specified relationship.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateRelationshipByIdWithContext(ctx context.Context, relationshipid string, relationshipPatch RelationshipPatch, resp ...*http.Response) (*Relationship, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "UpdateRelationshipById")
+	pp := struct {
+		Relationshipid string
+	}{
+		Relationshipid: relationshipid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/relationships/{{.Relationshipid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: relationshipPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Relationship
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateRuleWithContext - catalog service endpoint
+Modifies the rule with the specified rule ID or resource name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	ruleresource: The ID or resource name of a rule. For the default module, the resource name format is ruleName. Otherwise, the resource name format is module.ruleName.
+	rulePatch: The properties to update in the specified rule.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateRuleWithContext(ctx context.Context, ruleresource string, rulePatch RulePatch, resp ...*http.Response) (*Rule, error) {
+	ctx = sdkservices.WithOperation(ctx, "catalog", "UpdateRule")
+	pp := struct {
+		Ruleresource string
+	}{
+		Ruleresource: ruleresource,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/catalog/v2beta1/rules/{{.Ruleresource}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: rulePatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Rule
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
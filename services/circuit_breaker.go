@@ -0,0 +1,263 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// These are the values used by default for CircuitBreakerConfig
+const (
+	defaultCircuitFailureThreshold    = 5
+	defaultCircuitOpenTimeout         = 30 * time.Second
+	defaultCircuitHalfOpenMaxRequests = 1
+	defaultCircuitSuccessThreshold    = 1
+)
+
+// ErrCircuitOpen is matched by errors.Is for every CircuitOpenError
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of the circuit breaker for a given host
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through while counting consecutive failures
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every request fast until the open timeout has elapsed
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to decide whether to close or re-open
+	CircuitHalfOpen
+)
+
+// String returns the name of the circuit state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitOpenError is returned without sending the request while the circuit for Host is open
+type CircuitOpenError struct {
+	// Host is the host (as formed by BuildHost) the request was made to
+	Host string
+	// State is the state of the circuit when the request was rejected, open or half-open with no probe available
+	State CircuitState
+	// RetryAt is the earliest time at which a probe request will be let through
+	RetryAt time.Time
+}
+
+// Error implements the error interface
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is %s for host %s, retry at %s", e.State, e.Host, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is allows errors.Is(err, ErrCircuitOpen) to match any CircuitOpenError
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreakerConfig is used to set the circuit breaker specific attributes
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the (optional) number of consecutive failures after which the circuit opens, 5 by default
+	FailureThreshold uint
+	// OpenTimeout is the (optional) duration the circuit stays open before letting probes through, 30 seconds by default
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests is the (optional) number of concurrent probe requests let through while half-open, 1 by default
+	HalfOpenMaxRequests uint
+	// SuccessThreshold is the (optional) number of consecutive successful probes needed to close the circuit, 1 by default
+	SuccessThreshold uint
+	// IsFailure is an (optional) function deciding whether an attempt counts as a failure, by default request
+	// errors other than context cancellation and 5xx responses are failures
+	IsFailure func(response *http.Response, err error) bool
+	// OnStateChange is an (optional) callback invoked whenever the circuit for host changes state, it is called
+	// synchronously and must not block
+	OnStateChange func(host string, from CircuitState, to CircuitState)
+}
+
+// CircuitBreakerHandler is a ResponseOrErrorHandler which tracks failures per host (and therefore per
+// service cluster) and fails requests fast while a host is deemed unavailable. It must be added to
+// Config.ResponseHandlers, it is consulted before and after every attempt of a request, including retries.
+type CircuitBreakerHandler struct {
+	config   CircuitBreakerConfig
+	mux      sync.Mutex
+	circuits map[string]*circuit
+	// clock is used to time the open state, time package is used if nil
+	clock clock
+}
+
+// circuit is the state of the breaker for a single host
+type circuit struct {
+	state     CircuitState
+	failures  uint
+	successes uint
+	probes    uint
+	openedAt  time.Time
+	// generation is incremented on every state change, attempts let through in an earlier generation don't
+	// affect the current state
+	generation uint64
+}
+
+// NewCircuitBreakerHandler creates a CircuitBreakerHandler with config values passed in
+func NewCircuitBreakerHandler(config CircuitBreakerConfig) *CircuitBreakerHandler {
+	if config.FailureThreshold == 0 {
+		config.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if config.OpenTimeout == 0 {
+		config.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if config.HalfOpenMaxRequests == 0 {
+		config.HalfOpenMaxRequests = defaultCircuitHalfOpenMaxRequests
+	}
+	if config.SuccessThreshold == 0 {
+		config.SuccessThreshold = defaultCircuitSuccessThreshold
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = defaultIsFailure
+	}
+	return &CircuitBreakerHandler{config: config, circuits: make(map[string]*circuit)}
+}
+
+func defaultIsFailure(response *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, ErrCircuitOpen)
+	}
+	return response != nil && response.StatusCode >= 500
+}
+
+// State returns the current state of the circuit for host
+func (cb *CircuitBreakerHandler) State(host string) CircuitState {
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	if c, ok := cb.circuits[host]; ok {
+		return c.state
+	}
+	return CircuitClosed
+}
+
+func (cb *CircuitBreakerHandler) now() time.Time {
+	if cb.clock != nil {
+		return cb.clock.Now()
+	}
+	return time.Now()
+}
+
+// setState must be called with cb.mux held
+func (cb *CircuitBreakerHandler) setState(host string, c *circuit, to CircuitState) {
+	from := c.state
+	c.state = to
+	c.failures = 0
+	c.successes = 0
+	c.probes = 0
+	c.generation++
+	if to == CircuitOpen {
+		c.openedAt = cb.now()
+	}
+	if from != to && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(host, from, to)
+	}
+}
+
+// BeforeAttempt rejects the attempt with a *CircuitOpenError if the circuit for the request's host is open,
+// or half-open with all probes in flight
+func (cb *CircuitBreakerHandler) BeforeAttempt(client *BaseClient, request *Request) error {
+	host := request.URL.Host
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	c, ok := cb.circuits[host]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[host] = c
+	}
+	if c.state == CircuitOpen && !cb.now().Before(c.openedAt.Add(cb.config.OpenTimeout)) {
+		cb.setState(host, c, CircuitHalfOpen)
+	}
+	switch c.state {
+	case CircuitOpen:
+		return &CircuitOpenError{Host: host, State: CircuitOpen, RetryAt: c.openedAt.Add(cb.config.OpenTimeout)}
+	case CircuitHalfOpen:
+		if c.probes >= cb.config.HalfOpenMaxRequests {
+			return &CircuitOpenError{Host: host, State: CircuitHalfOpen, RetryAt: cb.now()}
+		}
+		c.probes++
+	}
+	request.circuitProbe = c.state == CircuitHalfOpen
+	request.circuitGeneration = c.generation
+	return nil
+}
+
+// AfterAttempt records the outcome of the attempt for the request's host. Only the attempts let through in the
+// current state of the circuit are recorded, e.g. an attempt sent while closed which completes once the circuit
+// is half-open doesn't count as a probe.
+func (cb *CircuitBreakerHandler) AfterAttempt(client *BaseClient, request *Request, response *http.Response, err error) {
+	host := request.URL.Host
+	failed := cb.config.IsFailure(response, err)
+	probe, generation := request.circuitProbe, request.circuitGeneration
+	request.circuitProbe, request.circuitGeneration = false, 0
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	c, ok := cb.circuits[host]
+	if !ok || generation != c.generation {
+		return
+	}
+	if probe && c.probes > 0 {
+		c.probes--
+	}
+	// errors which are not failures (e.g. a canceled context) say nothing about the health of the host
+	if err != nil && !failed {
+		return
+	}
+	switch c.state {
+	case CircuitClosed:
+		if !failed {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= cb.config.FailureThreshold {
+			cb.setState(host, c, CircuitOpen)
+		}
+	case CircuitHalfOpen:
+		if failed {
+			cb.setState(host, c, CircuitOpen)
+			return
+		}
+		c.successes++
+		if c.successes >= cb.config.SuccessThreshold {
+			cb.setState(host, c, CircuitClosed)
+		}
+	}
+}
+
+// HandleResponse returns the response as is, outcomes are recorded by AfterAttempt for every attempt
+func (cb *CircuitBreakerHandler) HandleResponse(client *BaseClient, request *Request, response *http.Response) (*http.Response, error) {
+	return response, nil
+}
+
+// HandleRequestError returns the error as is, outcomes are recorded by AfterAttempt for every attempt
+func (cb *CircuitBreakerHandler) HandleRequestError(client *BaseClient, request *Request, err error) (*http.Response, error) {
+	return nil, err
+}
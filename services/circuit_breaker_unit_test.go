@@ -0,0 +1,224 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statusRT returns the status code currently set for the host of the request, 200 by default
+type statusRT struct {
+	mux    sync.Mutex
+	status map[string]int
+	calls  map[string]int
+}
+
+func (rt *statusRT) set(host string, status int) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	rt.status[host] = status
+}
+
+func (rt *statusRT) count(host string) int {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	return rt.calls[host]
+}
+
+func (rt *statusRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	rt.calls[req.URL.Host]++
+	status, ok := rt.status[req.URL.Host]
+	if !ok {
+		status = 200
+	}
+	b := ioutil.NopCloser(bytes.NewReader([]byte("")))
+	return &http.Response{Status: http.StatusText(status), StatusCode: status, Body: b}, nil
+}
+
+type stateChange struct {
+	host     string
+	from, to CircuitState
+}
+
+func newCircuitBreakerClient(t *testing.T, config CircuitBreakerConfig, clk *fakeClock) (*BaseClient, *CircuitBreakerHandler, *statusRT) {
+	breaker := NewCircuitBreakerHandler(config)
+	breaker.clock = clk
+	rt := &statusRT{status: map[string]int{}, calls: map[string]int{}}
+	client, err := NewClient(&Config{
+		Token:            "testtoken",
+		RoundTripper:     rt,
+		ResponseHandlers: []ResponseHandler{breaker},
+	})
+	require.NoError(t, err)
+	return client, breaker, rt
+}
+
+func getFromHost(client *BaseClient, host string) (*http.Response, error) {
+	return client.Get(services.RequestParams{URL: url.URL{Scheme: "https", Host: host, Path: "/tenant/widgets"}})
+}
+
+func TestCircuitBreakerStateTransitions(t *testing.T) {
+	const host = "api.scp.splunk.com"
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	var changes []stateChange
+	client, breaker, rt := newCircuitBreakerClient(t, CircuitBreakerConfig{
+		FailureThreshold: 3,
+		OpenTimeout:      10 * time.Second,
+		OnStateChange: func(host string, from, to CircuitState) {
+			changes = append(changes, stateChange{host, from, to})
+		},
+	}, clk)
+
+	rt.set(host, 503)
+	for i := 0; i < 3; i++ {
+		_, err := getFromHost(client, host)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrCircuitOpen))
+	}
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+
+	// While open, requests fail fast without being sent
+	_, err := getFromHost(client, host)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, host, openErr.Host)
+	assert.Equal(t, CircuitOpen, openErr.State)
+	assert.Equal(t, time.Unix(1010, 0), openErr.RetryAt)
+	assert.Equal(t, 3, rt.count(host))
+
+	// After the open timeout a failed probe re-opens the circuit
+	clk.now = clk.now.Add(10 * time.Second)
+	_, err = getFromHost(client, host)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCircuitOpen))
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+	assert.Equal(t, 4, rt.count(host))
+
+	// A successful probe closes it
+	clk.now = clk.now.Add(10 * time.Second)
+	rt.set(host, 200)
+	resp, err := getFromHost(client, host)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+
+	assert.Equal(t, []stateChange{
+		{host, CircuitClosed, CircuitOpen},
+		{host, CircuitOpen, CircuitHalfOpen},
+		{host, CircuitHalfOpen, CircuitOpen},
+		{host, CircuitOpen, CircuitHalfOpen},
+		{host, CircuitHalfOpen, CircuitClosed},
+	}, changes)
+}
+
+func TestCircuitBreakerPerHost(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	client, breaker, rt := newCircuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 1}, clk)
+
+	rt.set("api.scp.splunk.com", 500)
+	_, err := getFromHost(client, "api.scp.splunk.com")
+	require.Error(t, err)
+	assert.Equal(t, CircuitOpen, breaker.State("api.scp.splunk.com"))
+
+	// Other service clusters are unaffected
+	resp, err := getFromHost(client, "tenant.api.scp.splunk.com")
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, CircuitClosed, breaker.State("tenant.api.scp.splunk.com"))
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	const host = "api.scp.splunk.com"
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	client, breaker, rt := newCircuitBreakerClient(t, CircuitBreakerConfig{FailureThreshold: 2}, clk)
+
+	for _, status := range []int{404, 429, 500, 400, 500} {
+		rt.set(host, status)
+		_, _ = getFromHost(client, host)
+	}
+	// Only consecutive 5xx responses count, the 400 in between resets the count
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+	_, _ = getFromHost(client, host)
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+}
+
+func TestCircuitBreakerHalfOpenProbeLimit(t *testing.T) {
+	const host = "api.scp.splunk.com"
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	breaker := NewCircuitBreakerHandler(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second, SuccessThreshold: 2})
+	breaker.clock = clk
+	request := &Request{Request: &http.Request{URL: &url.URL{Host: host}}}
+
+	require.NoError(t, breaker.BeforeAttempt(nil, request))
+	breaker.AfterAttempt(nil, request, &http.Response{StatusCode: 502}, nil)
+	clk.now = clk.now.Add(time.Second)
+
+	// Only a single probe is let through at a time
+	require.NoError(t, breaker.BeforeAttempt(nil, request))
+	err := breaker.BeforeAttempt(nil, request)
+	var openErr *CircuitOpenError
+	require.True(t, errors.As(err, &openErr))
+	assert.Equal(t, CircuitHalfOpen, openErr.State)
+
+	breaker.AfterAttempt(nil, request, &http.Response{StatusCode: 200}, nil)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host), "two successful probes are required to close")
+	require.NoError(t, breaker.BeforeAttempt(nil, request))
+	breaker.AfterAttempt(nil, request, &http.Response{StatusCode: 200}, nil)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+}
+
+func TestCircuitBreakerStaleAttempts(t *testing.T) {
+	const host = "api.scp.splunk.com"
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	breaker := NewCircuitBreakerHandler(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	breaker.clock = clk
+	newRequest := func() *Request {
+		return &Request{Request: &http.Request{URL: &url.URL{Host: host}}}
+	}
+
+	// slow is let through while closed and completes once the circuit is half-open
+	slow, failing, probe := newRequest(), newRequest(), newRequest()
+	require.NoError(t, breaker.BeforeAttempt(nil, slow))
+	require.NoError(t, breaker.BeforeAttempt(nil, failing))
+	breaker.AfterAttempt(nil, failing, &http.Response{StatusCode: 502}, nil)
+	require.Equal(t, CircuitOpen, breaker.State(host))
+	clk.now = clk.now.Add(time.Second)
+	require.NoError(t, breaker.BeforeAttempt(nil, probe))
+	require.Equal(t, CircuitHalfOpen, breaker.State(host))
+
+	breaker.AfterAttempt(nil, slow, &http.Response{StatusCode: 200}, nil)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host), "an attempt let through while closed isn't a probe")
+	assert.Error(t, breaker.BeforeAttempt(nil, newRequest()), "the probe is still in flight")
+
+	breaker.AfterAttempt(nil, probe, &http.Response{StatusCode: 200}, nil)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+}
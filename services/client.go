@@ -22,10 +22,13 @@ package services
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -38,6 +41,8 @@ import (
 	gdepservices "github.com/khulnasoft-lab/go-dependencies/services"
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/idp"
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/util"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:generate go run ../util/gen_interface.go -svc=action -p=action -sf=service_generated.go -sf=service_sdk.go
@@ -69,18 +74,20 @@ type BaseClient struct {
 	overrideHost string
 	// scheme is the HTTP scheme used to form requests, `"https"` by default
 	scheme string
-	// tokenContext is the access token to include in `"Authorization: Bearer"` headers and related context information
-	tokenContext *idp.Context
+	// tokenSource provides the access token to include in `"Authorization: Bearer"` headers and related context information
+	tokenSource *TokenSource
 	// HTTP Client used to interact with endpoints
 	httpClient *http.Client
 	// responseHandlers is a slice of handlers to call after a response has been received in the client
 	responseHandlers []ResponseHandler
-	// tokenRetriever to gather access tokens to be sent in the Authorization: Bearer header on client initialization and upon encountering an expired token
-	tokenRetriever idp.TokenRetriever
-	// tokenExpireWindow is the (optional) window within which a new token gets retrieved before the existing token expires. Default to 1 minute
-	tokenExpireWindow time.Duration
-	// tokenMux is used to lock resource when a new token is being fetched
-	tokenMux sync.Mutex
+	// attemptHandlers is a slice of handlers to call before and after each attempt of a request
+	attemptHandlers []AttemptHandler
+	// telemetry records OpenTelemetry spans and metrics, nil if neither a TracerProvider nor a MeterProvider was configured
+	telemetry *telemetry
+	// logger logs requests, responses, retries and token refreshes, nil if no Logger was configured
+	logger *requestLogger
+	// typedErrors is whether an *Error is returned for responses with an error status code
+	typedErrors bool
 	// clientVersion contains the client name and its current version in string format
 	clientVersion string
 	//tenantScoped is bool True if the hostnames are scoped to a specific tenant/region
@@ -95,6 +102,15 @@ type Request struct {
 	*http.Request
 	NumAttempts     uint
 	NumErrorsByType map[string]uint
+
+	// retryStart is when the first retry of the request was scheduled
+	retryStart time.Time
+	// lastBackoff is the wait before the latest retry of the request
+	lastBackoff time.Duration
+	// circuitProbe is whether the attempt in flight was let through by a circuit breaker as a half-open probe
+	circuitProbe bool
+	// circuitGeneration is the generation of the circuit state in which the attempt in flight was let through
+	circuitGeneration uint64
 }
 
 // GetNumErrorsByResponseCode returns number of attempts for a given response code >= 400
@@ -121,12 +137,34 @@ func (r *Request) UpdateToken(accessToken string) {
 	r.Header.Set("Authorization", fmt.Sprintf("%s %s", AuthorizationType, accessToken))
 }
 
+// streamed returns true if the body of the request was streamed, e.g. a multipart form, and can't be sent again
+func (r *Request) streamed() bool {
+	return r.Body != nil && r.Body != http.NoBody && r.GetBody == nil
+}
+
+// rewindBody re-initializes the body of a request which is not streamed before it's sent again, otherwise it
+// would be empty
+func (r *Request) rewindBody() error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return err
+	}
+	r.Body = body
+	return nil
+}
+
 // Config is used to set the client specific attributes
 type Config struct {
 	// TokenRetriever to gather access tokens to be sent in the Authorization: Bearer header on client initialization and upon encountering a 401 response
 	TokenRetriever idp.TokenRetriever
 	// Token to be sent in the Authorization: Bearer header (not required if TokenRetriever is specified)
 	Token string
+	// TokenSource is an (optional) source of access tokens which may be shared by several clients, such that
+	// a single token retrieval is in flight at any time for all of them (not required if TokenRetriever or Token is specified)
+	TokenSource *TokenSource
 	// Tenant is the default Tenant used to form requests
 	Tenant string
 	// Host is the (optional) default host or host:port used to form requests, `"scp.splunk.com"` by default.
@@ -143,9 +181,31 @@ type Config struct {
 	// received in the client - handlers can optionally implement the ResponseOrErrorHandler
 	// interface as well for handling request errors as well as responses
 	ResponseHandlers []ResponseHandler
-	// RetryRequests Knob that will turn on and off retrying incoming service requests when they result in the service returning a 429 TooManyRequests Error or a connection reset error
+	// RetryRequests Knob that will turn on and off retrying incoming service requests when they fail with a retryable error,
+	// as classified by the default RetryPolicy: 429 TooManyRequests, refused connections and temporary DNS failures for
+	// every request, 502/503/504 errors, timeouts and connection resets for idempotent requests only
 	RetryRequests bool
-	// RetryStrategyConfig
+	// RateLimiter is an (optional) client-side rate limiter consulted before every request attempt, it may be shared by several clients
+	RateLimiter *RateLimiter
+	// TracerProvider is the (optional) OpenTelemetry tracer provider used to create a span for every SDK call
+	// and a child span for every attempt of the call, no spans are created by default
+	TracerProvider trace.TracerProvider
+	// MeterProvider is the (optional) OpenTelemetry meter provider used to record call duration, retry and
+	// attempt metrics, no metrics are recorded by default
+	MeterProvider metric.MeterProvider
+	// Logger is the (optional) logger used to log requests and responses (at debug level), retries and token refreshes,
+	// nothing is logged by default
+	Logger *slog.Logger
+	// RedactionRules are (optional) rules applied to logged requests and responses in addition to DefaultRedactionRules
+	RedactionRules []RedactionRule
+	// TypedErrors makes service calls return an *Error for responses with an error status code, which matches the
+	// sentinel errors such as ErrNotFound using errors.Is and carries the service-specific error payload, the
+	// request ID and the operation. A *util.HTTPError is returned by default, it can be retrieved from an *Error
+	// using errors.As
+	TypedErrors bool
+	// LogBodies enables logging request and response bodies at debug level, up to their first 64 KiB which are read into memory to do so
+	LogBodies bool
+	// RetryStrategyConfig, see NewStandardRetryStrategyConfig, NewThrottlingRetryStrategyConfig and NewBackgroundRetryStrategyConfig for presets
 	RetryConfig RetryStrategyConfig
 	// RoundTripper
 	RoundTripper http.RoundTripper
@@ -161,12 +221,17 @@ type Config struct {
 
 // NewRequest creates a new HTTP Request and set proper header
 func (c *BaseClient) NewRequest(httpMethod, url string, body io.Reader, headers map[string]string) (*Request, error) {
-	request, err := http.NewRequest(httpMethod, url, body)
+	return c.NewRequestWithContext(context.Background(), httpMethod, url, body, headers)
+}
+
+// NewRequestWithContext creates a new HTTP Request bound to ctx and set proper header
+func (c *BaseClient) NewRequestWithContext(ctx context.Context, httpMethod, url string, body io.Reader, headers map[string]string) (*Request, error) {
+	request, err := http.NewRequestWithContext(ctx, httpMethod, url, body)
 	if err != nil {
 		return nil, err
 	}
-	if c.tokenContext != nil && len(c.tokenContext.AccessToken) > 0 {
-		request.Header.Set("Authorization", fmt.Sprintf("%s %s", AuthorizationType, c.tokenContext.AccessToken))
+	if tokenCtx := c.tokenSource.Current(); tokenCtx != nil && len(tokenCtx.AccessToken) > 0 {
+		request.Header.Set("Authorization", fmt.Sprintf("%s %s", AuthorizationType, tokenCtx.AccessToken))
 	}
 
 	httpSplunkClient := fmt.Sprintf("%s/%s", UserAgent, Version)
@@ -181,7 +246,7 @@ func (c *BaseClient) NewRequest(httpMethod, url string, body io.Reader, headers
 			request.Header.Set(key, value)
 		}
 	}
-	retryRequest := &Request{request, 0, make(map[string]uint)}
+	retryRequest := &Request{Request: request, NumErrorsByType: make(map[string]uint)}
 	return retryRequest, nil
 }
 
@@ -279,8 +344,8 @@ func (c *BaseClient) BuildURLFromPathParams(queryValues url.Values, serviceClust
 // Do sends out request and returns HTTP response
 func (c *BaseClient) Do(req *Request) (*http.Response, error) {
 	req.NumAttempts++
-	response, err := c.httpClient.Do(req.Request)
-	if len(c.responseHandlers) == 0 {
+	response, sent, err := c.doAttempt(req)
+	if !sent || len(c.responseHandlers) == 0 {
 		// Return immediately if no error/response handling provided
 		return response, err
 	}
@@ -316,61 +381,123 @@ func (c *BaseClient) Do(req *Request) (*http.Response, error) {
 	return response, err
 }
 
+// doAttempt sends a single attempt of req surrounded by calls to the client's attempt handlers,
+// sent is false if an attempt handler prevented the attempt from being sent
+func (c *BaseClient) doAttempt(req *Request) (response *http.Response, sent bool, err error) {
+	// don't rely on the transport to notice a context which is already done
+	if err := req.Context().Err(); err != nil {
+		return nil, false, err
+	}
+	for i, ah := range c.attemptHandlers {
+		if err := ah.BeforeAttempt(c, req); err != nil {
+			for _, prev := range c.attemptHandlers[:i] {
+				prev.AfterAttempt(c, req, nil, err)
+			}
+			return nil, false, err
+		}
+	}
+	attempt := c.telemetry.startAttempt(req)
+	c.logger.logRequest(req)
+	start := time.Now()
+	response, err = c.httpClient.Do(req.Request)
+	c.logger.logResponse(req, response, err, time.Since(start))
+	attempt.end(response, err)
+	for _, ah := range c.attemptHandlers {
+		ah.AfterAttempt(c, req, response, err)
+	}
+	return response, true, err
+}
+
 // Get implements HTTP Get call
 func (c *BaseClient) Get(requestParams gdepservices.RequestParams) (*http.Response, error) {
+	return c.GetWithContext(context.Background(), requestParams)
+}
+
+// GetWithContext implements HTTP Get call bound to ctx
+func (c *BaseClient) GetWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error) {
 	requestParams.Method = http.MethodGet
-	return c.DoRequest(requestParams)
+	return c.DoRequestWithContext(ctx, requestParams)
 }
 
 // Post implements HTTP POST call
 func (c *BaseClient) Post(requestParams gdepservices.RequestParams) (*http.Response, error) {
+	return c.PostWithContext(context.Background(), requestParams)
+}
+
+// PostWithContext implements HTTP POST call bound to ctx
+func (c *BaseClient) PostWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error) {
 	requestParams.Method = http.MethodPost
-	return c.DoRequest(requestParams)
+	return c.DoRequestWithContext(ctx, requestParams)
 }
 
 // Put implements HTTP PUT call
 func (c *BaseClient) Put(requestParams gdepservices.RequestParams) (*http.Response, error) {
+	return c.PutWithContext(context.Background(), requestParams)
+}
+
+// PutWithContext implements HTTP PUT call bound to ctx
+func (c *BaseClient) PutWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error) {
 	requestParams.Method = http.MethodPut
-	return c.DoRequest(requestParams)
+	return c.DoRequestWithContext(ctx, requestParams)
 }
 
 // Delete implements HTTP DELETE call
 // RFC2616 does not explicitly forbid it but in practice some versions of server implementations (tomcat,
 // netty etc) ignore bodies in DELETE requests
 func (c *BaseClient) Delete(requestParams gdepservices.RequestParams) (*http.Response, error) {
+	return c.DeleteWithContext(context.Background(), requestParams)
+}
+
+// DeleteWithContext implements HTTP DELETE call bound to ctx
+func (c *BaseClient) DeleteWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error) {
 	requestParams.Method = http.MethodDelete
-	return c.DoRequest(requestParams)
+	return c.DoRequestWithContext(ctx, requestParams)
 }
 
 // Patch implements HTTP Patch call
 func (c *BaseClient) Patch(requestParams gdepservices.RequestParams) (*http.Response, error) {
+	return c.PatchWithContext(context.Background(), requestParams)
+}
+
+// PatchWithContext implements HTTP Patch call bound to ctx
+func (c *BaseClient) PatchWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error) {
 	requestParams.Method = http.MethodPatch
-	return c.DoRequest(requestParams)
+	return c.DoRequestWithContext(ctx, requestParams)
 }
 
 // DoRequest creates and execute a new request
 func (c *BaseClient) DoRequest(requestParams gdepservices.RequestParams) (*http.Response, error) {
+	return c.DoRequestWithContext(context.Background(), requestParams)
+}
+
+// DoRequestWithContext creates and execute a new request bound to ctx, the context is honored while
+// renewing the access token, sending the request and waiting between retries
+func (c *BaseClient) DoRequestWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error) {
+	ctx, call := c.telemetry.startCall(ctx, c.defaultTenant, requestParams.Method)
+	request, response, err := c.doRequest(ctx, requestParams)
+	call.end(request, response, err)
+	if err != nil {
+		return nil, err
+	}
+	if !c.typedErrors {
+		return util.ParseHTTPStatusCodeInResponse(response)
+	}
+	return parseResponse(request, response)
+}
+
+// doRequest creates and sends a new request, returning the request if it could be created
+func (c *BaseClient) doRequest(ctx context.Context, requestParams gdepservices.RequestParams) (*Request, *http.Response, error) {
 	var request *Request
 	var err error
-	now := time.Now().Add(c.tokenExpireWindow)
-	curEpoch := now.Unix()
 	// renew token if it's about to expire
-	if curEpoch >= c.tokenContext.StartTime+int64(c.tokenContext.ExpiresIn) {
-		c.tokenMux.Lock()
-		ctx, err := c.tokenRetriever.GetTokenContext()
-		if err != nil {
-			c.tokenMux.Unlock()
-			return nil, err
-		}
-		// Update the client such that future requests will use the new access token and retain context information
-		c.UpdateTokenContext(ctx)
-		c.tokenMux.Unlock()
+	if _, err := c.tokenSource.Token(ctx); err != nil {
+		return nil, nil, err
 	}
 
 	if len(requestParams.Headers) > 0 && requestParams.Headers["Content-Type"] == "multipart/form-data" {
-		request, err = c.makeFormRequest(requestParams)
+		request, err = c.makeFormRequest(ctx, requestParams)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 	} else if requestParams.Body != nil {
@@ -388,60 +515,232 @@ func (c *BaseClient) DoRequest(requestParams gdepservices.RequestParams) (*http.
 				content, marshalErr = json.Marshal(requestParams.Body)
 			}
 			if marshalErr != nil {
-				return nil, marshalErr
+				return nil, nil, marshalErr
 			}
 			buffer = bytes.NewBuffer(content)
 		}
-		request, err = c.NewRequest(requestParams.Method, requestParams.URL.String(), buffer, requestParams.Headers)
+		request, err = c.NewRequestWithContext(ctx, requestParams.Method, requestParams.URL.String(), buffer, requestParams.Headers)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 	} else {
-		request, err = c.NewRequest(requestParams.Method, requestParams.URL.String(), nil, requestParams.Headers)
+		request, err = c.NewRequestWithContext(ctx, requestParams.Method, requestParams.URL.String(), nil, requestParams.Headers)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	response, err := c.Do(request)
-	if err != nil {
-		return nil, err
-	}
-	return util.ParseHTTPStatusCodeInResponse(response)
+	return request, response, err
 }
 
-func (c *BaseClient) makeFormRequest(requestParams gdepservices.RequestParams) (*Request, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// makeFormRequest creates a multipart/form-data request whose body is streamed: the form is written to an io.Pipe
+// while the request is sent rather than buffered in memory, gzipped if the Content-Encoding header is gzip. Such
+// a request can only be sent again, hence retried, when the stream of the form is an io.Seeker, e.g. an *os.File,
+// which is rewound to where it was when the request was created.
+func (c *BaseClient) makeFormRequest(ctx context.Context, requestParams gdepservices.RequestParams) (*Request, error) {
 	forms, ok := requestParams.Body.(gdepservices.FormData)
 	if !ok {
 		return nil, errors.New("bad request of form data")
 	}
+	gzipped := requestParams.Headers["Content-Encoding"] == "gzip"
+	body := newFormBody(forms, gzipped, multipart.NewWriter(nil).Boundary())
 
-	part, err := writer.CreateFormFile(forms.Key, forms.Filename)
+	request, err := c.NewRequestWithContext(ctx, requestParams.Method, requestParams.URL.String(), body, requestParams.Headers)
 	if err != nil {
+		body.Close()
 		return nil, err
 	}
-	_, err = io.Copy(part, forms.Stream)
+
+	if seeker, ok := forms.Stream.(io.Seeker); ok {
+		offset, err := seeker.Seek(0, io.SeekCurrent)
+		if err != nil {
+			body.Close()
+			return nil, err
+		}
+		rewind := func() error {
+			_, err := seeker.Seek(offset, io.SeekStart)
+			return err
+		}
+		var mux sync.Mutex
+		last := body
+		request.GetBody = func() (io.ReadCloser, error) {
+			// The body replaces the previous one once it's read, the previous body being sent until then
+			mux.Lock()
+			defer mux.Unlock()
+			next := newFormBody(forms, gzipped, body.boundary)
+			next.prev, next.rewind = last, rewind
+			last = next
+			return next, nil
+		}
+	}
+
+	request.Header.Set("Content-Type", body.contentType())
+	return request, err
+}
+
+// formBody is the body of a multipart/form-data request, the form is written to a pipe by a goroutine started
+// on the first read so that nothing is left running if the body is never read
+type formBody struct {
+	forms    gdepservices.FormData
+	gzip     bool
+	boundary string
+	start    sync.Once
+	pr       *io.PipeReader
+	pw       *io.PipeWriter
+	// done is closed once the form isn't written anymore, or won't ever be
+	done chan struct{}
+	// prev is the body this one replaces, which is stopped before the stream is rewound and read again
+	prev   *formBody
+	rewind func() error
+}
+
+// newFormBody returns the body of forms, whose parts are separated by boundary
+func newFormBody(forms gdepservices.FormData, gzipped bool, boundary string) *formBody {
+	pr, pw := io.Pipe()
+	return &formBody{forms: forms, gzip: gzipped, boundary: boundary, pr: pr, pw: pw, done: make(chan struct{})}
+}
+
+// contentType returns the Content-Type of the form
+func (b *formBody) contentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+func (b *formBody) Read(p []byte) (int, error) {
+	b.start.Do(func() {
+		go func() {
+			defer close(b.done)
+			b.pw.CloseWithError(b.write())
+		}()
+	})
+	return b.pr.Read(p)
+}
+
+// Close stops writing the form, it's called by the transport once the request was sent
+func (b *formBody) Close() error {
+	return b.pr.Close()
+}
+
+// stop closes the body and the bodies it replaces, and waits until their form isn't written anymore, so that
+// its stream can be read again
+func (b *formBody) stop() {
+	b.start.Do(func() {
+		close(b.done)
+	})
+	b.pr.Close()
+	<-b.done
+	if b.prev != nil {
+		b.prev.stop()
+	}
+}
+
+func (b *formBody) write() error {
+	if b.prev != nil {
+		b.prev.stop()
+		if err := b.rewind(); err != nil {
+			return err
+		}
+	}
+	var w io.Writer = b.pw
+	var gz *gzip.Writer
+	if b.gzip {
+		gz = gzip.NewWriter(b.pw)
+		w = gz
+	}
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(b.boundary); err != nil {
+		return err
+	}
+	part, err := writer.CreateFormFile(b.forms.Key, b.forms.Filename)
 	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, b.forms.Stream); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if gz != nil {
+		return gz.Close()
+	}
+	return nil
+}
+
+// ContextClient is implemented by clients which can bind a context.Context to each request,
+// generated service methods ending in WithContext use it when the service's IClient supports it
+type ContextClient interface {
+	GetWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error)
+	PostWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error)
+	PutWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error)
+	DeleteWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error)
+	PatchWithContext(ctx context.Context, requestParams gdepservices.RequestParams) (*http.Response, error)
+}
+
+// GetWithContext implements HTTP Get call for client bound to ctx, if client is not a ContextClient
+// ctx is only checked before the request is sent
+func GetWithContext(ctx context.Context, client gdepservices.IClient, requestParams gdepservices.RequestParams) (*http.Response, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.GetWithContext(ctx, requestParams)
+	}
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	return client.Get(requestParams)
+}
 
-	writer.Close()
+// PostWithContext implements HTTP POST call for client bound to ctx, if client is not a ContextClient
+// ctx is only checked before the request is sent
+func PostWithContext(ctx context.Context, client gdepservices.IClient, requestParams gdepservices.RequestParams) (*http.Response, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.PostWithContext(ctx, requestParams)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return client.Post(requestParams)
+}
 
-	request, err := c.NewRequest(requestParams.Method, requestParams.URL.String(), body, requestParams.Headers)
-	if err != nil {
+// PutWithContext implements HTTP PUT call for client bound to ctx, if client is not a ContextClient
+// ctx is only checked before the request is sent
+func PutWithContext(ctx context.Context, client gdepservices.IClient, requestParams gdepservices.RequestParams) (*http.Response, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.PutWithContext(ctx, requestParams)
+	}
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	return client.Put(requestParams)
+}
 
-	request.Header.Set("Content-Type", writer.FormDataContentType())
-	return request, err
+// DeleteWithContext implements HTTP DELETE call for client bound to ctx, if client is not a ContextClient
+// ctx is only checked before the request is sent
+func DeleteWithContext(ctx context.Context, client gdepservices.IClient, requestParams gdepservices.RequestParams) (*http.Response, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.DeleteWithContext(ctx, requestParams)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return client.Delete(requestParams)
+}
+
+// PatchWithContext implements HTTP Patch call for client bound to ctx, if client is not a ContextClient
+// ctx is only checked before the request is sent
+func PatchWithContext(ctx context.Context, client gdepservices.IClient, requestParams gdepservices.RequestParams) (*http.Response, error) {
+	if cc, ok := client.(ContextClient); ok {
+		return cc.PatchWithContext(ctx, requestParams)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return client.Patch(requestParams)
 }
 
 // UpdateTokenContext the access token in the Authorization: Bearer header and retains related context information
 func (c *BaseClient) UpdateTokenContext(ctx *idp.Context) {
-	c.tokenContext = ctx
+	c.tokenSource.Set(ctx)
 }
 
 // GetDefaultTenant returns the tenant used to form most request URIs
@@ -505,47 +804,78 @@ func NewClient(config *Config) (*BaseClient, error) {
 		clientVersion = config.ClientVersion
 	}
 
-	// Enforce that exactly one of TokenRetriever or Token must be specified
-	if (config.TokenRetriever != nil && config.Token != "") || (config.TokenRetriever == nil && config.Token == "") {
-		return nil, errors.New("either config.TokenRetriever or config.Token must be set, not both")
+	// Enforce that exactly one of TokenRetriever, Token or TokenSource must be specified
+	numTokenOptions := 0
+	for _, set := range []bool{config.TokenRetriever != nil, config.Token != "", config.TokenSource != nil} {
+		if set {
+			numTokenOptions++
+		}
+	}
+	if numTokenOptions != 1 {
+		return nil, errors.New("exactly one of config.TokenRetriever, config.Token or config.TokenSource must be set")
 	}
 
 	var handlers []ResponseHandler
+	tokenSource := config.TokenSource
 	if config.Token != "" {
-		// If static Token is provided then set the token retriever to no-op (just return static token)
-		config.TokenRetriever = &idp.NoOpTokenRetriever{Context: &idp.Context{AccessToken: config.Token}}
+		// If static Token is provided then use a token source which always returns the static token
+		tokenSource = newStaticTokenSource(config.Token)
 		handlers = config.ResponseHandlers
+	} else if config.TokenRetriever != nil {
+		tokenSource = NewTokenSource(config.TokenRetriever, tokenExpireWindow)
 	}
 	if config.RetryRequests {
-		//if knob to RetryRequests is on, Retry Response Handler is created to handle the 429 response and retry the incoming requests that are being throttled based on the retry strategy specified in the config
+		//if knob to RetryRequests is on, Retry Response Handler is created to retry the incoming requests that fail with a retryable error based on the retry strategy specified in the config
 		if config.RetryConfig.ConfigurableRetryConfig == nil {
 			defaultStrategyHandler := DefaultRetryResponseHandler{DefaultRetryConfig{}}
 			handlers = append([]ResponseHandler{ResponseHandler(defaultStrategyHandler)}, config.ResponseHandlers...)
 		} else {
-			configStrategyHandler := ConfigurableRetryResponseHandler{ConfigurableRetryConfig{config.RetryConfig.ConfigurableRetryConfig.RetryNum, config.RetryConfig.ConfigurableRetryConfig.Interval, config.RetryConfig.ConfigurableRetryConfig.ShouldRetryFn}}
+			configStrategyHandler := ConfigurableRetryResponseHandler{*config.RetryConfig.ConfigurableRetryConfig}
 			handlers = append([]ResponseHandler{ResponseHandler(configStrategyHandler)}, config.ResponseHandlers...)
 		}
 	}
-	// Start by retrieving the access token
-	ctx, err := config.TokenRetriever.GetTokenContext()
+	var attemptHandlers []AttemptHandler
+	for _, h := range config.ResponseHandlers {
+		if ah, ok := h.(AttemptHandler); ok {
+			attemptHandlers = append(attemptHandlers, ah)
+		}
+	}
+	if config.RateLimiter != nil {
+		// Rate limit last so that the wait happens as close as possible to sending the request
+		attemptHandlers = append(attemptHandlers, config.RateLimiter)
+	}
+	telemetry, err := newTelemetry(config.TracerProvider, config.MeterProvider)
 	if err != nil {
+		return nil, fmt.Errorf("service.NewClient: error creating telemetry instruments: %s", err)
+	}
+	var logger *requestLogger
+	if config.Logger != nil {
+		logger = newRequestLogger(config.Logger.With(slog.String("tenant", config.Tenant)), config.RedactionRules, config.LogBodies)
+		if config.TokenSource == nil {
+			tokenSource.onRefresh = logger.logTokenRefresh
+		}
+	}
+	// Start by retrieving the access token
+	if _, err := tokenSource.Token(context.Background()); err != nil {
 		return nil, fmt.Errorf("service.NewClient: error retrieving token: %s", err)
 	}
 
 	// Finally, initialize the Client
 	c := &BaseClient{
-		rootDomain:        rootDomain,
-		overrideHost:      overrideHost,
-		scheme:            scheme,
-		defaultTenant:     config.Tenant,
-		httpClient:        &http.Client{Timeout: timeout},
-		tokenRetriever:    config.TokenRetriever,
-		tokenContext:      ctx,
-		responseHandlers:  handlers,
-		tokenExpireWindow: tokenExpireWindow,
-		clientVersion:     clientVersion,
-		tenantScoped:      config.TenantScoped,
-		region:            config.Region,
+		rootDomain:       rootDomain,
+		overrideHost:     overrideHost,
+		scheme:           scheme,
+		defaultTenant:    config.Tenant,
+		httpClient:       &http.Client{Timeout: timeout},
+		tokenSource:      tokenSource,
+		responseHandlers: handlers,
+		attemptHandlers:  attemptHandlers,
+		telemetry:        telemetry,
+		logger:           logger,
+		typedErrors:      config.TypedErrors,
+		clientVersion:    clientVersion,
+		tenantScoped:     config.TenantScoped,
+		region:           config.Region,
 	}
 
 	if config.RoundTripper != nil {
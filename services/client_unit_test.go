@@ -17,11 +17,22 @@
 package services
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	gdepservices "github.com/khulnasoft-lab/go-dependencies/services"
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/idp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -243,7 +254,7 @@ func TestNewTokenClient(t *testing.T) {
 		Timeout: timeout,
 	})
 	require.NoError(t, err)
-	assert.Equal(t, token, client.tokenContext.AccessToken)
+	assert.Equal(t, token, client.tokenSource.Current().AccessToken)
 
 	testURL := client.GetURL("")
 	assert.Equal(t, clusterAPIHostname, testURL.Hostname())
@@ -378,7 +389,7 @@ func TestNewTokenRetrieverClient(t *testing.T) {
 	var tokenRetriever = &tRet{}
 	var client, err = NewClient(&Config{TokenRetriever: tokenRetriever})
 	require.NoError(t, err)
-	assert.Equal(t, client.tokenContext.AccessToken, xyzToken, "access token should have been initialized to X.Y.Z")
+	assert.Equal(t, client.tokenSource.Current().AccessToken, xyzToken, "access token should have been initialized to X.Y.Z")
 }
 
 func TestNewClientTokenAndTokenRetriever(t *testing.T) {
@@ -388,3 +399,145 @@ func TestNewClientTokenAndTokenRetriever(t *testing.T) {
 	// This should fail, users should specify Token or TokenRetriever, not both
 	assert.NotNil(t, err)
 }
+
+type ctxRT struct {
+	N   int
+	ctx context.Context
+}
+
+// RoundTrip for this ctxRT records the context of the request and returns success
+func (rt *ctxRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.N++
+	rt.ctx = req.Context()
+	b := ioutil.NopCloser(bytes.NewReader([]byte("")))
+	return &http.Response{Status: "200 OK", StatusCode: 200, Body: b}, nil
+}
+
+type ctxKey struct{}
+
+func TestClientRequestWithContext(t *testing.T) {
+	rt := &ctxRT{}
+	client, err := NewClient(&Config{
+		Token:        "testtoken",
+		RoundTripper: rt,
+	})
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	resp, err := GetWithContext(ctx, client, gdepservices.RequestParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	require.Equal(t, 1, rt.N)
+	assert.Equal(t, "value", rt.ctx.Value(ctxKey{}), "request-scoped values should be propagated to the request")
+}
+
+func TestClientRequestWithCanceledContext(t *testing.T) {
+	rt := &ctxRT{}
+	client, err := NewClient(&Config{
+		Token:        "testtoken",
+		RoundTripper: rt,
+	})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err := client.PostWithContext(ctx, gdepservices.RequestParams{Body: map[string]string{"a": "b"}})
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, rt.N, "RoundTripper should not be called for a canceled context")
+}
+
+type blockingRetriever struct {
+	release chan struct{}
+}
+
+func (tr *blockingRetriever) GetTokenContext() (*idp.Context, error) {
+	<-tr.release
+	return &idp.Context{AccessToken: xyzToken}, nil
+}
+
+func TestClientTokenRefreshCanceledContext(t *testing.T) {
+	tr := &blockingRetriever{release: make(chan struct{})}
+	close(tr.release)
+	rt := &ctxRT{}
+	client, err := NewClient(&Config{TokenRetriever: tr, RoundTripper: rt})
+	require.NoError(t, err)
+	// Block the next refresh, the zero-valued token context is always considered expired
+	tr.release = make(chan struct{})
+	defer close(tr.release)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.GetWithContext(ctx, gdepservices.RequestParams{})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 0, rt.N, "RoundTripper should not be called when the token refresh is canceled")
+}
+
+// formRT reads the multipart form of every request and responds with status
+type formRT struct {
+	status int
+	N      int
+	files  []string
+}
+
+func (rt *formRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.N++
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = gz
+	}
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	part, err := multipart.NewReader(body, params["boundary"]).NextPart()
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadAll(part)
+	if err != nil {
+		return nil, err
+	}
+	rt.files = append(rt.files, part.FormName()+"/"+part.FileName()+":"+string(content))
+	req.Body.Close()
+	return &http.Response{StatusCode: rt.status, Status: http.StatusText(rt.status), Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil
+}
+
+func TestClientStreamedFormRequest(t *testing.T) {
+	for _, gzipped := range []bool{false, true} {
+		rt := &formRT{status: 200}
+		client, err := NewClient(&Config{Token: "testtoken", RoundTripper: rt, RetryRequests: true})
+		require.NoError(t, err)
+		headers := map[string]string{"Content-Type": "multipart/form-data"}
+		if gzipped {
+			headers["Content-Encoding"] = "gzip"
+		}
+		form := gdepservices.FormData{Key: "upfile", Filename: "events.log", Stream: strings.NewReader("a\nb\n")}
+		_, err = client.PostWithContext(context.Background(), gdepservices.RequestParams{Body: form, Headers: headers})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"upfile/events.log:a\nb\n"}, rt.files)
+	}
+
+	// The stream is rewound to where it was to send the body again
+	rt := &formRT{status: 429}
+	retryConfig := RetryStrategyConfig{ConfigurableRetryConfig: &ConfigurableRetryConfig{RetryNum: 2, Interval: 1}}
+	client, err := NewClient(&Config{Token: "testtoken", RoundTripper: rt, RetryRequests: true, RetryConfig: retryConfig, TypedErrors: true})
+	require.NoError(t, err)
+	stream := strings.NewReader("ab")
+	stream.ReadByte()
+	form := gdepservices.FormData{Key: "upfile", Filename: "events.log", Stream: stream}
+	_, err = client.PostWithContext(context.Background(), gdepservices.RequestParams{Body: form, Headers: map[string]string{"Content-Type": "multipart/form-data"}})
+	assert.True(t, errors.Is(err, ErrThrottled))
+	assert.Equal(t, 3, rt.N)
+	assert.Equal(t, []string{"upfile/events.log:b", "upfile/events.log:b", "upfile/events.log:b"}, rt.files)
+
+	// The body was streamed from a reader which can't be rewound, it can't be sent again
+	rt = &formRT{status: 429}
+	client, err = NewClient(&Config{Token: "testtoken", RoundTripper: rt, RetryRequests: true, RetryConfig: retryConfig, TypedErrors: true})
+	require.NoError(t, err)
+	form = gdepservices.FormData{Key: "upfile", Filename: "events.log", Stream: io.MultiReader(strings.NewReader("a"))}
+	_, err = client.PostWithContext(context.Background(), gdepservices.RequestParams{Body: form, Headers: map[string]string{"Content-Type": "multipart/form-data"}})
+	assert.True(t, errors.Is(err, ErrThrottled))
+	assert.Equal(t, 1, rt.N)
+}
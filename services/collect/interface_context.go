@@ -0,0 +1,113 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package collect
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateExecutionWithContext - Creates an execution for a scheduled job based on the job ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobId: The job ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateExecutionWithContext(ctx context.Context, jobId string, resp ...*http.Response) (*SingleExecutionResponse, error)
+	/*
+		CreateJobWithContext - Creates a job.
+		This API returns &#x60;403&#x60; if the number of collect workers is over a certain limit.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			job: The API request schema for the job.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateJobWithContext(ctx context.Context, job Job, resp ...*http.Response) (*SingleJobResponse, error)
+	/*
+		DeleteJobWithContext - Removes a job based on the job ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobId: The job ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteJobWithContext(ctx context.Context, jobId string, resp ...*http.Response) error
+	/*
+		DeleteJobsWithContext - Removes all jobs on a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteJobsWithContext(ctx context.Context, resp ...*http.Response) (*DeleteJobsResponse, error)
+	/*
+		GetExecutionWithContext - Returns the execution details based on the execution ID and job ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobId: The job ID.
+			executionUid: The execution UID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetExecutionWithContext(ctx context.Context, jobId string, executionUid string, resp ...*http.Response) (*SingleExecutionResponse, error)
+	/*
+		GetJobWithContext - Returns a job based on the job ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobId: The job ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetJobWithContext(ctx context.Context, jobId string, resp ...*http.Response) (*SingleJobResponse, error)
+	/*
+		ListJobsWithContext - Returns a list of all jobs that belong to a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListJobsWithContext(ctx context.Context, query *ListJobsQueryParams, resp ...*http.Response) (*ListJobsResponse, error)
+	/*
+		PatchExecutionWithContext - Modifies an execution based on the job ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobId: The job ID.
+			executionUid: The execution UID.
+			executionPatch: The API request schema for patching an execution.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PatchExecutionWithContext(ctx context.Context, jobId string, executionUid string, executionPatch ExecutionPatch, resp ...*http.Response) error
+	/*
+		PatchJobWithContext - Modifies a job based on the job ID.
+		This API returns &#x60;403&#x60; if the number of collect workers is over a certain limit.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobId: The job ID.
+			jobPatch: The API request schema for patching a job.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PatchJobWithContext(ctx context.Context, jobId string, jobPatch JobPatch, resp ...*http.Response) (*SingleJobResponse, error)
+	/*
+		PatchJobsWithContext - Finds all jobs that match the query and modifies the with the changes specified in the request.
+		This is a non-atomic operation and the results are returned as a list with each job patch result as its element. This API returns &#x60;200 OK&#x60; regardless of how many jobs were successfully patched. You must read the response body to find out if all jobs are patched. When the API is called, the &#x60;jobIDs&#x60; or &#x60;connectorID&#x60; must be specified. Do not specify more than one of them at the same time. This API returns &#x60;403&#x60; if the number of collect workers is over a certain limit.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			jobsPatch: The API request schema for patching jobs.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PatchJobsWithContext(ctx context.Context, jobsPatch JobsPatch, query *PatchJobsQueryParams, resp ...*http.Response) (*PatchJobsResponse, error)
+}
@@ -0,0 +1,372 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package collect
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateExecutionWithContext - Creates an execution for a scheduled job based on the job ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobId: The job ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateExecutionWithContext(ctx context.Context, jobId string, resp ...*http.Response) (*SingleExecutionResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "CreateExecution")
+	pp := struct {
+		JobId string
+	}{
+		JobId: jobId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs/{{.JobId}}/executions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SingleExecutionResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateJobWithContext - Creates a job.
+This API returns &#x60;403&#x60; if the number of collect workers is over a certain limit.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	job: The API request schema for the job.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateJobWithContext(ctx context.Context, job Job, resp ...*http.Response) (*SingleJobResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "CreateJob")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: job})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SingleJobResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteJobWithContext - Removes a job based on the job ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobId: The job ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteJobWithContext(ctx context.Context, jobId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "collect", "DeleteJob")
+	pp := struct {
+		JobId string
+	}{
+		JobId: jobId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs/{{.JobId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteJobsWithContext - Removes all jobs on a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteJobsWithContext(ctx context.Context, resp ...*http.Response) (*DeleteJobsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "DeleteJobs")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb DeleteJobsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetExecutionWithContext - Returns the execution details based on the execution ID and job ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobId: The job ID.
+	executionUid: The execution UID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetExecutionWithContext(ctx context.Context, jobId string, executionUid string, resp ...*http.Response) (*SingleExecutionResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "GetExecution")
+	pp := struct {
+		JobId        string
+		ExecutionUid string
+	}{
+		JobId:        jobId,
+		ExecutionUid: executionUid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs/{{.JobId}}/executions/{{.ExecutionUid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SingleExecutionResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetJobWithContext - Returns a job based on the job ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobId: The job ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetJobWithContext(ctx context.Context, jobId string, resp ...*http.Response) (*SingleJobResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "GetJob")
+	pp := struct {
+		JobId string
+	}{
+		JobId: jobId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs/{{.JobId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SingleJobResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListJobsWithContext - Returns a list of all jobs that belong to a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListJobsWithContext(ctx context.Context, query *ListJobsQueryParams, resp ...*http.Response) (*ListJobsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "ListJobs")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/collect/v1beta1/jobs`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ListJobsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PatchExecutionWithContext - Modifies an execution based on the job ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobId: The job ID.
+	executionUid: The execution UID.
+	executionPatch: The API request schema for patching an execution.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PatchExecutionWithContext(ctx context.Context, jobId string, executionUid string, executionPatch ExecutionPatch, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "collect", "PatchExecution")
+	pp := struct {
+		JobId        string
+		ExecutionUid string
+	}{
+		JobId:        jobId,
+		ExecutionUid: executionUid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs/{{.JobId}}/executions/{{.ExecutionUid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: executionPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+PatchJobWithContext - Modifies a job based on the job ID.
+This API returns &#x60;403&#x60; if the number of collect workers is over a certain limit.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobId: The job ID.
+	jobPatch: The API request schema for patching a job.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PatchJobWithContext(ctx context.Context, jobId string, jobPatch JobPatch, resp ...*http.Response) (*SingleJobResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "PatchJob")
+	pp := struct {
+		JobId string
+	}{
+		JobId: jobId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/collect/v1beta1/jobs/{{.JobId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: jobPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SingleJobResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PatchJobsWithContext - Finds all jobs that match the query and modifies the with the changes specified in the request.
+This is a non-atomic operation and the results are returned as a list with each job patch result as its element. This API returns &#x60;200 OK&#x60; regardless of how many jobs were successfully patched. You must read the response body to find out if all jobs are patched. When the API is called, the &#x60;jobIDs&#x60; or &#x60;connectorID&#x60; must be specified. Do not specify more than one of them at the same time. This API returns &#x60;403&#x60; if the number of collect workers is over a certain limit.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	jobsPatch: The API request schema for patching jobs.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PatchJobsWithContext(ctx context.Context, jobsPatch JobsPatch, query *PatchJobsQueryParams, resp ...*http.Response) (*PatchJobsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "collect", "PatchJobs")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/collect/v1beta1/jobs`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: jobsPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PatchJobsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/util"
+)
+
+// RequestIDHeader is the response header carrying the ID the service assigned to a request
+const RequestIDHeader = "X-Request-Id"
+
+// Sentinel errors matched by errors.Is for errors returned by service calls, according to the response status code,
+// for clients configured with Config.TypedErrors
+var (
+	// ErrValidation matches 400 Bad Request and 422 Unprocessable Entity responses
+	ErrValidation = errors.New("validation failed")
+	// ErrUnauthorized matches 401 Unauthorized responses
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden matches 403 Forbidden responses
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound matches 404 Not Found responses
+	ErrNotFound = errors.New("not found")
+	// ErrConflict matches 409 Conflict responses
+	ErrConflict = errors.New("conflict")
+	// ErrThrottled matches 429 Too Many Requests responses
+	ErrThrottled = errors.New("throttled")
+)
+
+// Error is returned by service calls for responses with an error status code when Config.TypedErrors is set. It wraps the *util.HTTPError
+// and, when the service defines one, the decoded service-specific error payload, both can be retrieved using
+// errors.As e.g. to get the payload of a kvstore error:
+//
+//	var kvErr *kvstore.ErrorResponse
+//	if errors.As(err, &kvErr) { ... }
+type Error struct {
+	*util.HTTPError
+	// Operation is the SDK call which failed, empty if the request was not made by a service method
+	Operation Operation
+	// RequestID is the ID the service assigned to the request, if any
+	RequestID string
+	// Payload is the decoded service-specific error payload, nil if the service defines none or the body could not be decoded
+	Payload error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	var sb strings.Builder
+	if e.Operation != (Operation{}) {
+		sb.WriteString(e.Operation.String())
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.HTTPError.Error())
+	if e.RequestID != "" {
+		sb.WriteString(" (request id: ")
+		sb.WriteString(e.RequestID)
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+// Unwrap returns the *util.HTTPError and the payload, if any
+func (e *Error) Unwrap() []error {
+	if e.Payload != nil {
+		return []error{e.HTTPError, e.Payload}
+	}
+	return []error{e.HTTPError}
+}
+
+// Is matches the sentinel error corresponding to the status code of the response
+func (e *Error) Is(target error) bool {
+	switch e.HTTPStatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return target == ErrValidation
+	case http.StatusUnauthorized:
+		return target == ErrUnauthorized
+	case http.StatusForbidden:
+		return target == ErrForbidden
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	case http.StatusTooManyRequests:
+		return target == ErrThrottled
+	}
+	return false
+}
+
+var (
+	errorPayloadsMux sync.RWMutex
+	errorPayloads    = map[string]func(statusCode int) error{}
+)
+
+// RegisterErrorPayload registers the service-specific error payload of service, newPayload returns a pointer to
+// decode the error body of a response with statusCode into, or nil if the service has no payload for it. Decoded
+// payloads without content, whose fields are all empty or whose Error() is empty, are dropped. It is called by
+// service packages when they are initialized.
+func RegisterErrorPayload(service string, newPayload func(statusCode int) error) {
+	errorPayloadsMux.Lock()
+	defer errorPayloadsMux.Unlock()
+	errorPayloads[service] = newPayload
+}
+
+func decodeErrorPayload(service string, statusCode int, body []byte) error {
+	errorPayloadsMux.RLock()
+	newPayload, ok := errorPayloads[service]
+	errorPayloadsMux.RUnlock()
+	if !ok || len(body) == 0 {
+		return nil
+	}
+	payload := newPayload(statusCode)
+	if payload == nil || json.Unmarshal(body, payload) != nil {
+		return nil
+	}
+	// Error bodies which are valid JSON but hold none of the fields of the payload (e.g. the errors of a gateway) aren't payloads
+	if v := reflect.ValueOf(payload); (v.Kind() == reflect.Ptr && v.Elem().IsZero()) || payload.Error() == "" {
+		return nil
+	}
+	return payload
+}
+
+// parseResponse returns response as is for success status codes, otherwise it returns an *Error
+func parseResponse(request *Request, response *http.Response) (*http.Response, error) {
+	if response.StatusCode >= 200 && response.StatusCode < 400 {
+		return util.ParseHTTPStatusCodeInResponse(response)
+	}
+	var body []byte
+	if response.Body != nil {
+		var err error
+		if body, err = io.ReadAll(response.Body); err != nil {
+			return nil, err
+		}
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	parsed, err := util.ParseHTTPStatusCodeInResponse(response)
+	var httpErr *util.HTTPError
+	if !errors.As(err, &httpErr) {
+		return parsed, err
+	}
+	svcErr := &Error{HTTPError: httpErr, RequestID: response.Header.Get(RequestIDHeader)}
+	if request != nil {
+		svcErr.Operation, _ = OperationFromContext(request.Context())
+	}
+	svcErr.Payload = decodeErrorPayload(svcErr.Operation.Service, httpErr.HTTPStatusCode, body)
+	return parsed, svcErr
+}
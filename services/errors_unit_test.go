@@ -0,0 +1,134 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// errorRT responds with status and body, setting the request ID header
+type errorRT struct {
+	status int
+	body   string
+}
+
+func (rt errorRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: rt.status,
+		Status:     http.StatusText(rt.status),
+		Header:     http.Header{RequestIDHeader: {"req-123"}},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(rt.body))),
+	}, nil
+}
+
+type testErrorPayload struct {
+	Reason string `json:"reason"`
+}
+
+func (p *testErrorPayload) Error() string {
+	return p.Reason
+}
+
+func getWithStatus(t *testing.T, ctx context.Context, status int, body string) error {
+	client, err := NewClient(&Config{Token: "testtoken", RoundTripper: errorRT{status: status, body: body}, TypedErrors: true})
+	require.NoError(t, err)
+	_, err = client.GetWithContext(ctx, services.RequestParams{})
+	return err
+}
+
+func TestErrorSentinels(t *testing.T) {
+	sentinels := map[int]error{
+		400: ErrValidation,
+		401: ErrUnauthorized,
+		403: ErrForbidden,
+		404: ErrNotFound,
+		409: ErrConflict,
+		422: ErrValidation,
+		429: ErrThrottled,
+	}
+	all := []error{ErrValidation, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrThrottled}
+	for status, sentinel := range sentinels {
+		err := getWithStatus(t, context.Background(), status, "")
+		require.Error(t, err)
+		for _, target := range all {
+			assert.Equal(t, target == sentinel, errors.Is(err, target), "status %d, target %v", status, target)
+		}
+	}
+
+	err := getWithStatus(t, context.Background(), 500, "")
+	for _, target := range all {
+		assert.False(t, errors.Is(err, target))
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	RegisterErrorPayload("testsvc", func(statusCode int) error {
+		if statusCode == http.StatusConflict {
+			return &testErrorPayload{}
+		}
+		return nil
+	})
+	ctx := WithOperation(context.Background(), "testsvc", "CreateWidget")
+	err := getWithStatus(t, ctx, 409, `{"reason":"widget exists"}`)
+
+	var svcErr *Error
+	require.True(t, errors.As(err, &svcErr))
+	assert.Equal(t, Operation{Service: "testsvc", Name: "CreateWidget"}, svcErr.Operation)
+	assert.Equal(t, "req-123", svcErr.RequestID)
+	assert.Contains(t, err.Error(), "testsvc.CreateWidget: ")
+	assert.Contains(t, err.Error(), "(request id: req-123)")
+
+	// Existing callers relying on *util.HTTPError keep working
+	var httpErr *util.HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, 409, httpErr.HTTPStatusCode)
+
+	var payload *testErrorPayload
+	require.True(t, errors.As(err, &payload))
+	assert.Equal(t, "widget exists", payload.Reason)
+
+	// No payload is decoded for other status codes or bodies which aren't JSON
+	err = getWithStatus(t, ctx, 404, `{"reason":"no widget"}`)
+	assert.False(t, errors.As(err, &payload))
+	err = getWithStatus(t, ctx, 409, `<html>conflict</html>`)
+	require.True(t, errors.As(err, &svcErr))
+	assert.Nil(t, svcErr.Payload)
+	// nor for JSON bodies holding none of its fields
+	err = getWithStatus(t, ctx, 409, `{"message":"upstream conflict"}`)
+	require.True(t, errors.As(err, &svcErr))
+	assert.Nil(t, svcErr.Payload)
+}
+
+func TestUntypedErrors(t *testing.T) {
+	client, err := NewClient(&Config{Token: "testtoken", RoundTripper: errorRT{status: 404, body: `{"message":"no widget"}`}})
+	require.NoError(t, err)
+	_, err = client.Get(services.RequestParams{})
+	httpErr, ok := err.(*util.HTTPError)
+	require.True(t, ok, "a *util.HTTPError is returned unless TypedErrors is set")
+	assert.Equal(t, 404, httpErr.HTTPStatusCode)
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
@@ -22,4 +22,6 @@ package forwarders
 type Servicer interface {
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
@@ -0,0 +1,56 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package forwarders
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		AddCertificateWithContext - Adds a certificate to a vacant slot on a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			certificate
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	AddCertificateWithContext(ctx context.Context, certificate Certificate, resp ...*http.Response) (*CertificateInfo, error)
+	/*
+		DeleteCertificateWithContext - Removes a certificate on a particular slot on a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			slot
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteCertificateWithContext(ctx context.Context, slot string, resp ...*http.Response) error
+	/*
+		DeleteCertificatesWithContext - Removes all certificates on a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteCertificatesWithContext(ctx context.Context, resp ...*http.Response) error
+	/*
+		ListCertificatesWithContext - Returns a list of all certificates for a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListCertificatesWithContext(ctx context.Context, resp ...*http.Response) ([]CertificateInfo, error)
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package forwarders
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+AddCertificateWithContext - Adds a certificate to a vacant slot on a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	certificate
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) AddCertificateWithContext(ctx context.Context, certificate Certificate, resp ...*http.Response) (*CertificateInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "forwarders", "AddCertificate")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/forwarders/v2beta1/certificates`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: certificate})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb CertificateInfo
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteCertificateWithContext - Removes a certificate on a particular slot on a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	slot
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteCertificateWithContext(ctx context.Context, slot string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "forwarders", "DeleteCertificate")
+	pp := struct {
+		Slot string
+	}{
+		Slot: slot,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/forwarders/v2beta1/certificates/{{.Slot}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteCertificatesWithContext - Removes all certificates on a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteCertificatesWithContext(ctx context.Context, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "forwarders", "DeleteCertificates")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/forwarders/v2beta1/certificates`, nil)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+ListCertificatesWithContext - Returns a list of all certificates for a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListCertificatesWithContext(ctx context.Context, resp ...*http.Response) ([]CertificateInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "forwarders", "ListCertificates")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/forwarders/v2beta1/certificates`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []CertificateInfo
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
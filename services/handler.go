@@ -17,10 +17,8 @@
 package services
 
 import (
-	"errors"
 	"net/http"
-	"syscall"
-
+	"strings"
 	"time"
 
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/idp"
@@ -43,6 +41,19 @@ type ConfigurableRetryConfig struct {
 	Interval int
 	// ShouldRetryFn defines a custom function to determine whether or not to retry the request
 	ShouldRetryFn ShouldRetry
+	// Jitter is the (optional) strategy used to randomize the exponential backoff, NoJitter by default
+	Jitter JitterStrategy
+	// MaxBackoff is the (optional) upper bound of the wait between two attempts, unbounded by default.
+	// Retries are abandoned if the server asks (through Retry-After) to wait longer than MaxBackoff
+	MaxBackoff time.Duration
+	// MaxRetryDuration is the (optional) total time budget for waiting and retrying after the first failed
+	// attempt, no more retries are attempted once the next wait would exceed it. Unbounded by default
+	MaxRetryDuration time.Duration
+	// IgnoreRetryAfter disables honoring the Retry-After header of 429 and 503 responses
+	IgnoreRetryAfter bool
+
+	// clock is used to measure and wait for backoffs, time package is used if nil
+	clock clock
 }
 
 // DefaultRetryConfig that will use a default RetryNumber and a default Interval between retries
@@ -55,20 +66,11 @@ const (
 	defaultIntervalMillis = 500
 )
 
+// defaultShouldRetryFn retries the failures classified as retryable by the default RetryPolicy: throttled requests,
+// refused connections and temporary DNS failures for every method, gateway errors, timeouts and connection resets
+// for idempotent requests only
 func defaultShouldRetryFn(request *Request, reqErr error, response *http.Response, maxRetries uint) bool {
-	// Regardless of other conditions if we're over the retry count, we should not retry
-	if request != nil && request.NumAttempts > maxRetries {
-		return false
-	}
-	// If response is 429, retry
-	if response != nil && response.StatusCode == 429 {
-		return true
-	}
-	// If connection reset error encountered, retry
-	if reqErr != nil && errors.Is(reqErr, syscall.ECONNRESET) {
-		return true
-	}
-	return false
+	return RetryPolicy{}.ShouldRetry(request, reqErr, response, maxRetries)
 }
 
 // RetryStrategyConfig to be specified while creating a NewClient
@@ -92,6 +94,18 @@ type ResponseOrErrorHandler interface {
 	HandleResponse(client *BaseClient, request *Request, response *http.Response) (*http.Response, error)
 }
 
+// AttemptHandler defines the interface for implementing logic around every attempt of a request,
+// including retries. Response handlers found in Config.ResponseHandlers which also implement this
+// interface are consulted before and after each attempt is sent.
+type AttemptHandler interface {
+	// BeforeAttempt is called before an attempt is sent, returning an error prevents the attempt from being
+	// sent and is returned to the caller without being handled by response handlers
+	BeforeAttempt(client *BaseClient, request *Request) error
+	// AfterAttempt is called with the outcome of every attempt for which BeforeAttempt was called,
+	// before any response handler
+	AfterAttempt(client *BaseClient, request *Request, response *http.Response, err error)
+}
+
 // AuthnResponseHandler handles logic for updating the client access token in response to 401 errors
 type AuthnResponseHandler struct {
 	TokenRetriever idp.TokenRetriever
@@ -102,20 +116,22 @@ func (rh AuthnResponseHandler) HandleResponse(client *BaseClient, request *Reque
 	if response.StatusCode != 401 || rh.TokenRetriever == nil || request.GetNumErrorsByResponseCode(401) > DefaultMaxAuthnAttempts {
 		return response, nil
 	}
-	ctx, err := rh.TokenRetriever.GetTokenContext()
+	// Renew the rejected token, concurrent requests rejected with the same token share a single retrieval and
+	// the client is updated such that future requests will use the new access token and retain context information
+	rejected := strings.TrimPrefix(request.Header.Get("Authorization"), AuthorizationType+" ")
+	ctx, err := client.tokenSource.renewWith(request.Context(), rejected, rh.TokenRetriever)
 	if err != nil {
 		return response, err
 	}
 	// Replace the access token in the request's Authorization: Bearer header
 	request.UpdateToken(ctx.AccessToken)
 	// Re-initialize body (otherwise body is empty)
-	body, err := request.GetBody()
-	if err != nil {
+	if request.streamed() {
+		return response, nil
+	}
+	if err := request.rewindBody(); err != nil {
 		return nil, err
 	}
-	request.Body = body
-	// Update the client such that future requests will use the new access token and retain context information
-	client.UpdateTokenContext(ctx)
 	// Retry the request with the updated token
 	return client.Do(request)
 }
@@ -125,15 +141,23 @@ type DefaultRetryResponseHandler struct {
 	DefaultRetryConfig DefaultRetryConfig
 }
 
-// HandleResponse will retry a request once a 429 is encountered using a Default exponential BackOff Retry Strategy
+// defaultConfigurableRetryConfig is the ConfigurableRetryConfig equivalent of DefaultRetryConfig
+var defaultConfigurableRetryConfig = ConfigurableRetryConfig{
+	RetryNum:      defaultMaxRetryCount,
+	Interval:      defaultIntervalMillis,
+	ShouldRetryFn: defaultShouldRetryFn,
+}
+
+// HandleResponse will retry a request once a retryable response (429, 502, 503 or 504) is encountered using a
+// Default exponential BackOff Retry Strategy, see RetryPolicy
 func (defRh DefaultRetryResponseHandler) HandleResponse(client *BaseClient, request *Request, response *http.Response) (*http.Response, error) {
-	return handleRequestResponse(client, request, nil, response, defaultMaxRetryCount, defaultIntervalMillis, defaultShouldRetryFn)
+	return handleRequestResponse(client, request, nil, response, defaultConfigurableRetryConfig)
 }
 
-// HandleRequestError will retry a request if a connection reset is encountered using a
-// Default exponential BackOff Retry Strategy
+// HandleRequestError will retry a request if a retryable error (e.g. a connection reset or a timeout) is encountered
+// using a Default exponential BackOff Retry Strategy, see RetryPolicy
 func (defRh DefaultRetryResponseHandler) HandleRequestError(client *BaseClient, request *Request, err error) (*http.Response, error) {
-	return handleRequestResponse(client, request, err, nil, defaultMaxRetryCount, defaultIntervalMillis, defaultShouldRetryFn)
+	return handleRequestResponse(client, request, err, nil, defaultConfigurableRetryConfig)
 }
 
 // ConfigurableRetryResponseHandler handles logic for retrying requests with user configurable
@@ -145,43 +169,74 @@ type ConfigurableRetryResponseHandler struct {
 // HandleResponse will retry a request if a 429 is encountered using a configurable exponential
 // BackOff Retry Strategy
 func (configRh ConfigurableRetryResponseHandler) HandleResponse(client *BaseClient, request *Request, response *http.Response) (*http.Response, error) {
-	retryFn := configRh.ConfigurableRetryConfig.ShouldRetryFn
-	if retryFn == nil {
-		retryFn = defaultShouldRetryFn
-	}
-	return handleRequestResponse(client, request, nil, response, configRh.ConfigurableRetryConfig.RetryNum, configRh.ConfigurableRetryConfig.Interval, retryFn)
+	return handleRequestResponse(client, request, nil, response, configRh.ConfigurableRetryConfig)
 }
 
 // HandleRequestError will retry a request once a connection reset is encountered using
 // a Configurable exponential BackOff Retry Strategy
 func (configRh ConfigurableRetryResponseHandler) HandleRequestError(client *BaseClient, request *Request, err error) (*http.Response, error) {
-	retryFn := configRh.ConfigurableRetryConfig.ShouldRetryFn
-	if retryFn == nil {
-		retryFn = defaultShouldRetryFn
-	}
-	return handleRequestResponse(client, request, err, nil, configRh.ConfigurableRetryConfig.RetryNum, configRh.ConfigurableRetryConfig.Interval, retryFn)
+	return handleRequestResponse(client, request, err, nil, configRh.ConfigurableRetryConfig)
 }
 
 // handleRequestResponse - helper function to handle the retry to a 429 response
-func handleRequestResponse(client *BaseClient, request *Request, reqErr error, response *http.Response, maxRetries uint, interval int, shouldRetry ShouldRetry) (*http.Response, error) {
+func handleRequestResponse(client *BaseClient, request *Request, reqErr error, response *http.Response, config ConfigurableRetryConfig) (*http.Response, error) {
 	if request == nil {
 		return response, reqErr // can't retry the request without it
 	}
+	// Don't retry once the request's context is done or if its body was streamed
+	if request.Context().Err() != nil || request.streamed() {
+		return response, reqErr
+	}
+	shouldRetry := config.ShouldRetryFn
+	if shouldRetry == nil {
+		shouldRetry = defaultShouldRetryFn
+	}
 	// Is retry warranted? If not, return
-	if !shouldRetry(request, reqErr, response, maxRetries) {
+	if !shouldRetry(request, reqErr, response, config.RetryNum) {
 		return response, reqErr
 	}
+	clk := config.clock
+	if clk == nil {
+		clk = realClock{}
+	}
+	now := clk.Now()
+	if request.retryStart.IsZero() {
+		request.retryStart = now
+	}
 	// implement exponential back off by increasing the waiting time between retries after each retry failure.
-	backoffMillis := time.Duration((1<<request.NumAttempts)*interval) * time.Millisecond
-	time.Sleep(backoffMillis)
+	wait := config.backoff(request.NumAttempts, request.lastBackoff)
+	if !config.IgnoreRetryAfter {
+		if retryAfter, ok := parseRetryAfter(response, now); ok {
+			if config.MaxBackoff > 0 && retryAfter > config.MaxBackoff {
+				// the server won't accept the request before we'd give up waiting
+				return response, reqErr
+			}
+			if retryAfter > wait {
+				wait = retryAfter
+			}
+		}
+	}
+	if config.MaxRetryDuration > 0 && now.Add(wait).Sub(request.retryStart) > config.MaxRetryDuration {
+		return response, reqErr
+	}
+	request.lastBackoff = wait
+	addRetryEvent(request, wait, reqErr, response)
+	client.logger.logRetry(request, wait, reqErr, response)
+
+	timer, stop := clk.NewTimer(wait)
+	select {
+	case <-timer:
+	case <-request.Context().Done():
+		stop()
+		if response != nil {
+			response.Body.Close()
+		}
+		return nil, request.Context().Err()
+	}
 
 	// reinitialize body, otherwise it will be empty
-	if request.Body != nil {
-		body, err := request.GetBody()
-		if err != nil {
-			return nil, reqErr
-		}
-		request.Body = body
+	if err := request.rewindBody(); err != nil {
+		return nil, reqErr
 	}
 	return client.Do(request)
 }
@@ -18,11 +18,14 @@ package services
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"syscall"
 	"testing"
+	"time"
 
 	"github.com/khulnasoft-lab/go-dependencies/services"
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/idp"
@@ -252,3 +255,29 @@ func TestClientCustomRetryFunc(t *testing.T) {
 	// RoundTripper should be called a total of three times due to custom retry func
 	assert.Equal(t, 3, rt.N, "RoundTripper should have been called 3 times")
 }
+
+func TestClientRetryCanceledContext(t *testing.T) {
+	rt := &test429RT{}
+	client, err := NewClient(&Config{
+		Token:         "testtoken",
+		RetryRequests: true,
+		RetryConfig: RetryStrategyConfig{
+			ConfigurableRetryConfig: &ConfigurableRetryConfig{
+				RetryNum: 6,
+				Interval: 60000, // long enough that the test would time out if the wait weren't canceled
+			},
+		},
+		RoundTripper: rt,
+	})
+	require.Nil(t, err, "Error calling NewClient(): %s", err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	resp, err := client.GetWithContext(ctx, services.RequestParams{})
+	assert.Nil(t, resp)
+	require.NotNil(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(start) < 5*time.Second, "retry wait should have been interrupted by the context")
+	// Only the first attempt should have been made before the context expired
+	assert.Equal(t, 1, rt.N, "RoundTripper should have been called once")
+}
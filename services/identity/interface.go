@@ -18,8 +18,41 @@
 
 package identity
 
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
 // Servicer represents the interface for implementing all endpoints for this service
 type Servicer interface {
+	//interfaces that cannot be auto-generated from codegen
+	// AllGroups returns an iterator over every group matching query, requesting pages as they are needed
+	AllGroups(ctx context.Context, query *ListGroupsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Group, error]
+	// AllMembers returns an iterator over every member matching query, requesting pages as they are needed
+	AllMembers(ctx context.Context, query *ListMembersQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Member, error]
+	// AllPrincipals returns an iterator over every principal, requesting pages as they are needed
+	AllPrincipals(ctx context.Context, query *ListPrincipalsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Principal, error]
+	// AllGroupMembers returns an iterator over every member of the group group, requesting pages as they are needed
+	AllGroupMembers(ctx context.Context, group string, query *ListGroupMembersQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[GroupMember, error]
+	// AllGroupRoles returns an iterator over every role of the group group, requesting pages as they are needed
+	AllGroupRoles(ctx context.Context, group string, query *ListGroupRolesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[GroupRole, error]
+	// AllMemberGroups returns an iterator over every group the member member belongs to, requesting pages as they are needed
+	AllMemberGroups(ctx context.Context, member string, query *ListMemberGroupsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Group, error]
+	// AllMemberPermissions returns an iterator over every permission of the member member matching query, requesting pages as they are needed
+	AllMemberPermissions(ctx context.Context, member string, query *ListMemberPermissionsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[string, error]
+	// AllMemberRoles returns an iterator over every role of the member member, requesting pages as they are needed
+	AllMemberRoles(ctx context.Context, member string, query *ListMemberRolesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Role, error]
+	// AllRoleGroups returns an iterator over every group the role role is assigned to, requesting pages as they are needed
+	AllRoleGroups(ctx context.Context, role string, query *ListRoleGroupsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Group, error]
+	// AllRolePermissions returns an iterator over every permission of the role role, requesting pages as they are needed
+	AllRolePermissions(ctx context.Context, role string, query *ListRolePermissionsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[RolePermission, error]
+	// AllRoles returns an iterator over every role, requesting pages as they are needed
+	AllRoles(ctx context.Context, query *ListRolesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Role, error]
+
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
@@ -0,0 +1,279 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package identity
+
+import (
+	"context"
+	"iter"
+	"net/url"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+// AllGroups returns an iterator over every group matching query, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllGroups(ctx context.Context, query *ListGroupsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Group, error] {
+	var q ListGroupsQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Group, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListGroupsWithContext(ctx, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllMembers returns an iterator over every member matching query, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllMembers(ctx context.Context, query *ListMembersQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Member, error] {
+	var q ListMembersQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Member, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListMembersWithContext(ctx, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllPrincipals returns an iterator over every principal, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllPrincipals(ctx context.Context, query *ListPrincipalsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Principal, error] {
+	var q ListPrincipalsQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Principal, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListPrincipalsWithContext(ctx, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllGroupMembers returns an iterator over every member of the group group, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllGroupMembers(ctx context.Context, group string, query *ListGroupMembersQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[GroupMember, error] {
+	var q ListGroupMembersQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]GroupMember, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListGroupMembersWithContext(ctx, group, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllGroupRoles returns an iterator over every role of the group group, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllGroupRoles(ctx context.Context, group string, query *ListGroupRolesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[GroupRole, error] {
+	var q ListGroupRolesQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]GroupRole, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListGroupRolesWithContext(ctx, group, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllMemberGroups returns an iterator over every group the member member belongs to, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllMemberGroups(ctx context.Context, member string, query *ListMemberGroupsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Group, error] {
+	var q ListMemberGroupsQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Group, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListMemberGroupsWithContext(ctx, member, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllMemberPermissions returns an iterator over every permission of the member member matching query, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllMemberPermissions(ctx context.Context, member string, query *ListMemberPermissionsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[string, error] {
+	var q ListMemberPermissionsQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]string, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListMemberPermissionsWithContext(ctx, member, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllMemberRoles returns an iterator over every role of the member member, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllMemberRoles(ctx context.Context, member string, query *ListMemberRolesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Role, error] {
+	var q ListMemberRolesQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Role, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListMemberRolesWithContext(ctx, member, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllRoleGroups returns an iterator over every group the role role is assigned to, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllRoleGroups(ctx context.Context, role string, query *ListRoleGroupsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Group, error] {
+	var q ListRoleGroupsQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Group, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListRoleGroupsWithContext(ctx, role, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllRolePermissions returns an iterator over every permission of the role role, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllRolePermissions(ctx context.Context, role string, query *ListRolePermissionsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[RolePermission, error] {
+	var q ListRolePermissionsQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]RolePermission, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListRolePermissionsWithContext(ctx, role, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// AllRoles returns an iterator over every role, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.PageToken as the token of the first page, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllRoles(ctx context.Context, query *ListRolesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[Role, error] {
+	var q ListRolesQueryParams
+	if query != nil {
+		q = *query
+	}
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewTokenPager(func(ctx context.Context, token string, size int) ([]Role, string, error) {
+		page := q.SetPageSize(int32(size))
+		if token != "" {
+			page.PageToken = token
+		}
+		list, err := s.ListRolesWithContext(ctx, &page)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, nextPageToken(list.NextLink), nil
+	}, opts...).All(ctx)
+}
+
+// nextPageToken returns the page_token query parameter of nextLink, empty if there is no next page
+func nextPageToken(nextLink string) string {
+	if nextLink == "" {
+		return ""
+	}
+	u, err := url.Parse(nextLink)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("page_token")
+}
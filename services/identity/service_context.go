@@ -0,0 +1,1954 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package identity
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+AddGroupMemberWithContext - identity service endpoint
+Adds a member to a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	addGroupMemberBody: The member to add to a group.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) AddGroupMemberWithContext(ctx context.Context, group string, addGroupMemberBody AddGroupMemberBody, resp ...*http.Response) (*GroupMember, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "AddGroupMember")
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}/members`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: addGroupMemberBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupMember
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+AddGroupRoleWithContext - identity service endpoint
+Adds a role to a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	addGroupRoleBody: The role to add to a group.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) AddGroupRoleWithContext(ctx context.Context, group string, addGroupRoleBody AddGroupRoleBody, resp ...*http.Response) (*GroupRole, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "AddGroupRole")
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}/roles`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: addGroupRoleBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupRole
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+AddMemberWithContext - identity service endpoint
+Adds a member to a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	addMemberBody: The member to associate with a tenant.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) AddMemberWithContext(ctx context.Context, addMemberBody AddMemberBody, resp ...*http.Response) (*Member, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "AddMember")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/members`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: addMemberBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Member
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+AddPrincipalPublicKeyWithContext - identity service endpoint
+Add service principal public key
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	ecJwk: Service principal public key
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) AddPrincipalPublicKeyWithContext(ctx context.Context, principal string, ecJwk EcJwk, resp ...*http.Response) (*PrincipalPublicKey, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "AddPrincipalPublicKey")
+	pp := struct {
+		Principal string
+	}{
+		Principal: principal,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/keys`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: ecJwk})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PrincipalPublicKey
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+AddRolePermissionWithContext - identity service endpoint
+Adds permissions to a role in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	addRolePermissionBody: The permission to add to a role.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) AddRolePermissionWithContext(ctx context.Context, role string, addRolePermissionBody AddRolePermissionBody, resp ...*http.Response) (*RolePermission, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "AddRolePermission")
+	pp := struct {
+		Role string
+	}{
+		Role: role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles/{{.Role}}/permissions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: addRolePermissionBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb RolePermission
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateGroupWithContext - identity service endpoint
+Creates a new group in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	createGroupBody: The group definition.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateGroupWithContext(ctx context.Context, createGroupBody CreateGroupBody, resp ...*http.Response) (*Group, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "CreateGroup")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: createGroupBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Group
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateIdentityProviderWithContext - identity service endpoint
+Create an Identity Provider.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	identityProviderConfigBody: The Identity Provider to create.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateIdentityProviderWithContext(ctx context.Context, identityProviderConfigBody IdentityProviderConfigBody, resp ...*http.Response) (*IdentityProviderBody, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "CreateIdentityProvider")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/identityproviders`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: identityProviderConfigBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb IdentityProviderBody
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreatePrincipalWithContext - identity service endpoint
+Create a new principal
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	createPrincipalBody: The new principal to add to the system.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreatePrincipalWithContext(ctx context.Context, createPrincipalBody CreatePrincipalBody, query *CreatePrincipalQueryParams, resp ...*http.Response) (*Principal, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "CreatePrincipal")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/system/identity/v3/principals`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: createPrincipalBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Principal
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateRoleWithContext - identity service endpoint
+Creates a new authorization role in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	createRoleBody: Role definition
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateRoleWithContext(ctx context.Context, createRoleBody CreateRoleBody, resp ...*http.Response) (*Role, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "CreateRole")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: createRoleBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Role
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateSamlClientWithContext - identity service endpoint
+Create a SAML client.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	createSamlClientBody
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateSamlClientWithContext(ctx context.Context, createSamlClientBody CreateSamlClientBody, resp ...*http.Response) (*SamlClient, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "CreateSamlClient")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/clients/saml`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: createSamlClientBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SamlClient
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteGroupWithContext - identity service endpoint
+Deletes a group in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteGroupWithContext(ctx context.Context, group string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "DeleteGroup")
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteIdentityProviderWithContext - identity service endpoint
+Deletes the Identity Provider.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	idp: The Identity Provider name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteIdentityProviderWithContext(ctx context.Context, idp string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "DeleteIdentityProvider")
+	pp := struct {
+		Idp string
+	}{
+		Idp: idp,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/identityproviders/{{.Idp}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeletePrincipalPublicKeyWithContext - identity service endpoint
+Deletes principal public key
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	keyId: Identifier of a public key.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeletePrincipalPublicKeyWithContext(ctx context.Context, principal string, keyId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "DeletePrincipalPublicKey")
+	pp := struct {
+		Principal string
+		KeyId     string
+	}{
+		Principal: principal,
+		KeyId:     keyId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/keys/{{.KeyId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteRoleWithContext - identity service endpoint
+Deletes a defined role for a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteRoleWithContext(ctx context.Context, role string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "DeleteRole")
+	pp := struct {
+		Role string
+	}{
+		Role: role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles/{{.Role}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteSamlClientWithContext - identity service endpoint
+Deletes the SAML client.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	samlClient: The saml client name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteSamlClientWithContext(ctx context.Context, samlClient string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "DeleteSamlClient")
+	pp := struct {
+		SamlClient string
+	}{
+		SamlClient: samlClient,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/clients/saml/{{.SamlClient}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetEntitlementsWithContext - identity service endpoint
+Returns the entitlements for the given tenant and client id
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	entitlementClientId: ID of the client for commerce entitlements
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetEntitlementsWithContext(ctx context.Context, entitlementClientId string, resp ...*http.Response) (*EntitlementList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetEntitlements")
+	pp := struct {
+		EntitlementClientId string
+	}{
+		EntitlementClientId: entitlementClientId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/commerce/subscribed-apps/{{.EntitlementClientId}}/entitlements`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb EntitlementList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetGroupWithContext - identity service endpoint
+Returns information about a given group within a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetGroupWithContext(ctx context.Context, group string, resp ...*http.Response) (*Group, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetGroup")
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Group
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetGroupMemberWithContext - identity service endpoint
+Returns information about a given member within a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	member: The member name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetGroupMemberWithContext(ctx context.Context, group string, member string, resp ...*http.Response) (*GroupMember, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetGroupMember")
+	pp := struct {
+		Group  string
+		Member string
+	}{
+		Group:  group,
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}/members/{{.Member}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupMember
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetGroupRoleWithContext - identity service endpoint
+Returns information about a given role within a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	role: The role name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetGroupRoleWithContext(ctx context.Context, group string, role string, resp ...*http.Response) (*GroupRole, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetGroupRole")
+	pp := struct {
+		Group string
+		Role  string
+	}{
+		Group: group,
+		Role:  role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}/roles/{{.Role}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupRole
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetIdentityProviderWithContext - identity service endpoint
+Returns the Identity Provider for the given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	idp: The Identity Provider name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetIdentityProviderWithContext(ctx context.Context, idp string, resp ...*http.Response) (*IdentityProviderBody, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetIdentityProvider")
+	pp := struct {
+		Idp string
+	}{
+		Idp: idp,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/identityproviders/{{.Idp}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb IdentityProviderBody
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetMemberWithContext - identity service endpoint
+Returns a member of a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	member: The member name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetMemberWithContext(ctx context.Context, member string, resp ...*http.Response) (*Member, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetMember")
+	pp := struct {
+		Member string
+	}{
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/members/{{.Member}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Member
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPrincipalWithContext - identity service endpoint
+Returns the details of a principal, including its tenant membership and any relevant profile information.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPrincipalWithContext(ctx context.Context, principal string, resp ...*http.Response) (*Principal, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetPrincipal")
+	pp := struct {
+		Principal string
+	}{
+		Principal: principal,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Principal
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPrincipalPublicKeyWithContext - identity service endpoint
+Returns principal public key
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	keyId: Identifier of a public key.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPrincipalPublicKeyWithContext(ctx context.Context, principal string, keyId string, resp ...*http.Response) (*PrincipalPublicKey, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetPrincipalPublicKey")
+	pp := struct {
+		Principal string
+		KeyId     string
+	}{
+		Principal: principal,
+		KeyId:     keyId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/keys/{{.KeyId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PrincipalPublicKey
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPrincipalPublicKeysWithContext - identity service endpoint
+Returns principal public keys
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPrincipalPublicKeysWithContext(ctx context.Context, principal string, resp ...*http.Response) (*PrincipalPublicKeys, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetPrincipalPublicKeys")
+	pp := struct {
+		Principal string
+	}{
+		Principal: principal,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/keys`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PrincipalPublicKeys
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetRoleWithContext - identity service endpoint
+Returns a role for a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetRoleWithContext(ctx context.Context, role string, resp ...*http.Response) (*Role, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetRole")
+	pp := struct {
+		Role string
+	}{
+		Role: role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles/{{.Role}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Role
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetRolePermissionWithContext - identity service endpoint
+Gets a permission for the specified role.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	permission: The permission string.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetRolePermissionWithContext(ctx context.Context, role string, permission string, resp ...*http.Response) (*RolePermission, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetRolePermission")
+	pp := struct {
+		Role       string
+		Permission string
+	}{
+		Role:       role,
+		Permission: permission,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles/{{.Role}}/permissions/{{.Permission}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb RolePermission
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetSamlClientWithContext - identity service endpoint
+Returns the SAML client.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	samlClient: The saml client name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetSamlClientWithContext(ctx context.Context, samlClient string, resp ...*http.Response) (*SamlClient, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "GetSamlClient")
+	pp := struct {
+		SamlClient string
+	}{
+		SamlClient: samlClient,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/clients/saml/{{.SamlClient}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SamlClient
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListGroupMembersWithContext - identity service endpoint
+Returns a list of the members within a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListGroupMembersWithContext(ctx context.Context, group string, query *ListGroupMembersQueryParams, resp ...*http.Response) (*GroupMemberList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListGroupMembers")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/groups/{{.Group}}/members`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupMemberList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListGroupRolesWithContext - identity service endpoint
+Returns a list of the roles that are attached to a group within a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListGroupRolesWithContext(ctx context.Context, group string, query *ListGroupRolesQueryParams, resp ...*http.Response) (*GroupRoleList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListGroupRoles")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/groups/{{.Group}}/roles`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupRoleList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListGroupsWithContext - identity service endpoint
+List the groups that exist in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListGroupsWithContext(ctx context.Context, query *ListGroupsQueryParams, resp ...*http.Response) (*GroupList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListGroups")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/groups`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListIdentityProviderWithContext - identity service endpoint
+Returns the list of Identity Providers for the given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListIdentityProviderWithContext(ctx context.Context, resp ...*http.Response) ([]IdentityProviderBody, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListIdentityProvider")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/identityproviders`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []IdentityProviderBody
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListMemberGroupsWithContext - identity service endpoint
+Returns a list of groups that a member belongs to within a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	member: The member name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListMemberGroupsWithContext(ctx context.Context, member string, query *ListMemberGroupsQueryParams, resp ...*http.Response) (*GroupList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListMemberGroups")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Member string
+	}{
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/members/{{.Member}}/groups`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListMemberPermissionsWithContext - identity service endpoint
+Returns a set of permissions granted to the member within the tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	member: The member name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListMemberPermissionsWithContext(ctx context.Context, member string, query *ListMemberPermissionsQueryParams, resp ...*http.Response) (*PermissionList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListMemberPermissions")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Member string
+	}{
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/members/{{.Member}}/permissions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PermissionList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListMemberRolesWithContext - identity service endpoint
+Returns a set of roles that a given member holds within the tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	member: The member name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListMemberRolesWithContext(ctx context.Context, member string, query *ListMemberRolesQueryParams, resp ...*http.Response) (*RoleList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListMemberRoles")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Member string
+	}{
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/members/{{.Member}}/roles`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb RoleList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListMembersWithContext - identity service endpoint
+Returns a list of members in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListMembersWithContext(ctx context.Context, query *ListMembersQueryParams, resp ...*http.Response) (*MemberList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListMembers")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/members`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb MemberList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListPrincipalsWithContext - identity service endpoint
+Returns the list of principals that the Identity service knows about.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListPrincipalsWithContext(ctx context.Context, query *ListPrincipalsQueryParams, resp ...*http.Response) (*PrincipalList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListPrincipals")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/system/identity/v3/principals`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PrincipalList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListRoleGroupsWithContext - identity service endpoint
+Gets a list of groups for a role in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListRoleGroupsWithContext(ctx context.Context, role string, query *ListRoleGroupsQueryParams, resp ...*http.Response) (*GroupList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListRoleGroups")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Role string
+	}{
+		Role: role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/roles/{{.Role}}/groups`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb GroupList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListRolePermissionsWithContext - identity service endpoint
+Gets the permissions for a role in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListRolePermissionsWithContext(ctx context.Context, role string, query *ListRolePermissionsQueryParams, resp ...*http.Response) (*RolePermissionList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListRolePermissions")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Role string
+	}{
+		Role: role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/roles/{{.Role}}/permissions`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb RolePermissionList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListRolesWithContext - identity service endpoint
+Returns all roles for a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListRolesWithContext(ctx context.Context, query *ListRolesQueryParams, resp ...*http.Response) (*RoleList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListRoles")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/roles`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb RoleList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListSamlClientsWithContext - identity service endpoint
+List SAML clients.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListSamlClientsWithContext(ctx context.Context, resp ...*http.Response) (*SamlClientsList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ListSamlClients")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/clients/saml`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SamlClientsList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+RemoveGroupMemberWithContext - identity service endpoint
+Removes the member from a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	member: The member name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RemoveGroupMemberWithContext(ctx context.Context, group string, member string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "RemoveGroupMember")
+	pp := struct {
+		Group  string
+		Member string
+	}{
+		Group:  group,
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}/members/{{.Member}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+RemoveGroupRoleWithContext - identity service endpoint
+Removes a role from a given group.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	role: The role name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RemoveGroupRoleWithContext(ctx context.Context, group string, role string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "RemoveGroupRole")
+	pp := struct {
+		Group string
+		Role  string
+	}{
+		Group: group,
+		Role:  role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}/roles/{{.Role}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+RemoveMemberWithContext - identity service endpoint
+Removes a member from a given tenant
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	member: The member name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RemoveMemberWithContext(ctx context.Context, member string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "RemoveMember")
+	pp := struct {
+		Member string
+	}{
+		Member: member,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/members/{{.Member}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+RemoveRolePermissionWithContext - identity service endpoint
+Removes a permission from the role.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	permission: The permission string.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RemoveRolePermissionWithContext(ctx context.Context, role string, permission string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "RemoveRolePermission")
+	pp := struct {
+		Role       string
+		Permission string
+	}{
+		Role:       role,
+		Permission: permission,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles/{{.Role}}/permissions/{{.Permission}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+ResetPasswordWithContext - identity service endpoint
+Sends an email which allows a principal to reset a forgotten password.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resetPasswordBody: The principal information to recover password.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ResetPasswordWithContext(ctx context.Context, resetPasswordBody ResetPasswordBody, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ResetPassword")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/reset-password`, nil)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: resetPasswordBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+RevokePrincipalAuthTokensWithContext - identity service endpoint
+Revoke all existing access tokens issued to a principal. Principals can reset their password by visiting https://login.splunk.com/en_us/page/lost_password
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RevokePrincipalAuthTokensWithContext(ctx context.Context, principal string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "RevokePrincipalAuthTokens")
+	pp := struct {
+		Principal string
+	}{
+		Principal: principal,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/revoke`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+UpdateEntitlementsWithContext - identity service endpoint
+Update the entitlements for the given tenant and client id
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	entitlementClientId: ID of the client for commerce entitlements
+	setEntitlement: The desired entitlements to be set
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateEntitlementsWithContext(ctx context.Context, entitlementClientId string, setEntitlement []SetEntitlement, resp ...*http.Response) (*EntitlementList, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdateEntitlements")
+	pp := struct {
+		EntitlementClientId string
+	}{
+		EntitlementClientId: entitlementClientId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/commerce/subscribed-apps/{{.EntitlementClientId}}/entitlements`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: setEntitlement})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb EntitlementList
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateGroupWithContext - identity service endpoint
+Updates a group&#39;s display name or description.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	group: The group name.
+	updateGroupBody: The updated group information
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateGroupWithContext(ctx context.Context, group string, updateGroupBody UpdateGroupBody, resp ...*http.Response) (*Group, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdateGroup")
+	pp := struct {
+		Group string
+	}{
+		Group: group,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/groups/{{.Group}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updateGroupBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Group
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateIdentityProviderWithContext - identity service endpoint
+Update the configuration for an Identity Provider.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	idp: The Identity Provider name.
+	identityProviderConfigBody: The properties to update the Identity Provider with.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateIdentityProviderWithContext(ctx context.Context, idp string, identityProviderConfigBody IdentityProviderConfigBody, resp ...*http.Response) (*IdentityProviderBody, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdateIdentityProvider")
+	pp := struct {
+		Idp string
+	}{
+		Idp: idp,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/identityproviders/{{.Idp}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: identityProviderConfigBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb IdentityProviderBody
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdatePasswordWithContext - identity service endpoint
+Update principal password
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	updatePasswordBody: The new password to set for the principal.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdatePasswordWithContext(ctx context.Context, principal string, updatePasswordBody UpdatePasswordBody, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdatePassword")
+	pp := struct {
+		Principal string
+	}{
+		Principal: principal,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/password`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updatePasswordBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+UpdatePrincipalPublicKeyWithContext - identity service endpoint
+Update principal public key
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	principal: The principal name.
+	keyId: Identifier of a public key.
+	principalPublicKeyStatusBody: Status of the public key
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdatePrincipalPublicKeyWithContext(ctx context.Context, principal string, keyId string, principalPublicKeyStatusBody PrincipalPublicKeyStatusBody, resp ...*http.Response) (*PrincipalPublicKey, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdatePrincipalPublicKey")
+	pp := struct {
+		Principal string
+		KeyId     string
+	}{
+		Principal: principal,
+		KeyId:     keyId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/identity/v3/principals/{{.Principal}}/keys/{{.KeyId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: principalPublicKeyStatusBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PrincipalPublicKey
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateRoleWithContext - identity service endpoint
+Update a role&#39;s display name or description for a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	role: The role name.
+	updateRoleBody: The updated role information
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateRoleWithContext(ctx context.Context, role string, updateRoleBody UpdateRoleBody, resp ...*http.Response) (*Role, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdateRole")
+	pp := struct {
+		Role string
+	}{
+		Role: role,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/roles/{{.Role}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updateRoleBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Role
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateSamlClientWithContext - identity service endpoint
+Update the SAML client.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	samlClient: The saml client name.
+	updateSamlClientBody
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateSamlClientWithContext(ctx context.Context, samlClient string, updateSamlClientBody UpdateSamlClientBody, resp ...*http.Response) (*SamlClient, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "UpdateSamlClient")
+	pp := struct {
+		SamlClient string
+	}{
+		SamlClient: samlClient,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/identity/v3/clients/saml/{{.SamlClient}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updateSamlClientBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SamlClient
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ValidateTokenWithContext - identity service endpoint
+Validates the access token obtained from the authorization header and returns the principal name and tenant memberships.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ValidateTokenWithContext(ctx context.Context, query *ValidateTokenQueryParams, resp ...*http.Response) (*ValidateInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "identity", "ValidateToken")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/identity/v3/validate`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ValidateInfo
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
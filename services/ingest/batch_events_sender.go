@@ -17,25 +17,14 @@
 package ingest
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math"
-	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/util"
 )
 
-// UserErrHandler defines the type of user callback function for batchEventSender
-type UserErrHandler func(*BatchEventsSender)
-
-// ingestError defines the type of the event payload sent and ingest error incurred
-type ingestError struct {
-	Error  error
-	Events []Event
-}
-
 const (
 	//Default Payload Size in unit Bytes
 	payLoadSize = 1040000 // ~1MiB 1048576 bytes
@@ -43,233 +32,327 @@ const (
 	eventCount = 500
 )
 
-// BatchEventsSender sends events in batches or periodically if batch is not full to Splunk Cloud ingest service endpoints
-type BatchEventsSender struct {
-	PayLoadBytes   int
-	BatchSize      int
-	EventsChan     chan Event
-	EventsQueue    []Event
-	QuitChan       chan struct{}
-	EventService   *Service
-	IngestTicker   *util.Ticker
-	WaitGroup      *sync.WaitGroup
-	ErrorChan      chan struct{}
-	IsRunning      bool
-	mux            sync.Mutex
-	callbackFunc   UserErrHandler
-	stopMux        sync.Mutex
-	chanWaitMillis int
-	resetMux       sync.Mutex
-	Errors         []ingestError
+var (
+	// ErrBatchEventsSenderNotRunning is returned when events are added before Run is called
+	ErrBatchEventsSenderNotRunning = errors.New("ingest: need to start the BatchEventsSender first, call Run()")
+	// ErrBatchEventsSenderClosed is returned when events are added after Close is called or after the
+	// maximum number of errors allowed was reached
+	ErrBatchEventsSenderClosed = errors.New("ingest: BatchEventsSender is closed")
+)
+
+// DeliveryResult is the outcome of sending a batch of events
+type DeliveryResult struct {
+	// Events are the events of the batch
+	Events []Event
+	// Err is why the events were not delivered, nil if they were
+	Err error
 }
 
-// SetCallbackHandler allows users to pass their own callback function
-func (b *BatchEventsSender) SetCallbackHandler(callback UserErrHandler) {
-	b.callbackFunc = callback
+// DeliveryHandler is called with the result of every batch, one at a time and in the order the batches are sent
+type DeliveryHandler func(DeliveryResult)
+
+// queuedEvent is an event added to the sender, segment is the ID of the spool segment holding it or 0 without spool
+type queuedEvent struct {
+	event   Event
+	segment uint64
 }
 
-// Run sets up ticker and starts a new goroutine
-func (b *BatchEventsSender) Run() {
-	go b.loop()
-	b.IsRunning = true
+// batch is a group of events to send, events which can't be sent at all are queued as a batch with err set.
+// segments are the spool segments of the events, if any.
+type batch struct {
+	events   []Event
+	segments []uint64
+	err      error
 }
 
-// loop is a infinity loop which listens to three channels
-// the loop will break only if there's signal in QuitChan
-// otherwise it'll constantly checking conditions for ticker and events
-func (b *BatchEventsSender) loop() {
-	errorMsgCount := 0
-	batchPayLoadSize := 0
-
-	defer close(b.EventsChan)
-	for {
-		select {
-		case <-b.ErrorChan:
-			errorMsgCount++
-
-			if errorMsgCount >= cap(b.ErrorChan) {
-				b.Stop()
-			}
+// BatchEventsSender sends events in batches or periodically if batch is not full to Splunk Cloud ingest service endpoints.
+//
+// Events are added with AddEvent, which blocks while the sender is busy sending previous batches. A single
+// goroutine accumulates events into batches and another one sends them one at a time, reporting the result of
+// every batch to the DeliveryHandler. Close sends all the events added so far before returning.
+//
+// Events are only held in memory unless a Spool is set, see SetSpool.
+type BatchEventsSender struct {
+	// batchSize is the maximum number of events in a batch
+	batchSize int
+	// payLoadBytes is the maximum size of the JSON body of the requests sending the batches
+	payLoadBytes int
+	// EventService is the service sending the batches
+	EventService *Service
+
+	*batcher[queuedEvent, batch, DeliveryResult]
+
+	spool     *Spool
+	validator *Validator
+	ids       IDStrategy
+	dedup     *dedupWindow
+	// duplicates is the number of events dropped by the dedup window
+	duplicates atomic.Uint64
+	// slots holds a token per event queued with a spool, it's taken before the event is persisted so that the
+	// events which aren't accepted never reach the spool
+	slots chan struct{}
+	// requeued are the spooled events of the batches which failed, added again by the loop every interval
+	requeued []queuedEvent
+}
 
-			if b.callbackFunc != nil {
-				go b.callbackFunc(b)
-			}
+// BatchSize returns the maximum number of events in a batch
+func (b *BatchEventsSender) BatchSize() int {
+	return b.batchSize
+}
 
-		case <-b.QuitChan:
-			b.IsRunning = false
-			return
+// PayLoadBytes returns the maximum size of the JSON body of the requests sending the batches
+func (b *BatchEventsSender) PayLoadBytes() int {
+	return b.payLoadBytes
+}
 
-		case <-b.IngestTicker.GetChan():
-			b.WaitGroup.Add(1)
-			go b.flush(0)
+// SetDeliveryHandler sets the function called with the result of every batch, it must be called before Run
+func (b *BatchEventsSender) SetDeliveryHandler(handler DeliveryHandler) {
+	b.handler = handler
+}
 
-		case event := <-b.EventsChan:
+// SetSpool sets the write-ahead log of the sender, it must be called before Run. Every event is then persisted
+// in the spool before AddEvent returns, and the events found in the spool when it was opened are sent again when
+// the sender runs. The events of the batches which fail are sent again every interval, they stay in the spool
+// until they're sent. The spool must be closed after the sender.
+func (b *BatchEventsSender) SetSpool(spool *Spool) {
+	b.spool = spool
+	b.slots = make(chan struct{}, cap(b.items))
+	b.received = b.dequeued
+	b.retries = b.takeRequeued
+}
 
-			b.EventsQueue = append(b.EventsQueue, event)
+// SetValidator sets the Validator checking the events added, it must be called before Run. AddEvent then returns
+// the *ValidationError of the events rejected, and oversized events are handled according to the policy of
+// validator. Without validator, events which can't be encoded are reported to the DeliveryHandler and an event
+// larger than PayLoadBytes() is sent in a batch of its own.
+func (b *BatchEventsSender) SetValidator(validator *Validator) {
+	b.validator = validator
+}
 
-			for i := 0; i < len(b.EventsQueue); i++ {
+// SetIDStrategy sets the strategy assigning IDs to the events added without one, it must be called before Run.
+// The ID is assigned when the event is added, so that it's kept when the batch is sent again by the retries of
+// the client and when the event is replayed from the spool.
+func (b *BatchEventsSender) SetIDStrategy(strategy IDStrategy) {
+	b.ids = strategy
+}
 
-				size, err := b.readEvent(b.EventsQueue[i])
-				if err != nil {
-					fmt.Printf("Error: %v\n", err)
-					b.Errors = append(b.Errors, ingestError{Error: err, Events: b.EventsQueue[i : i+1]})
-				}
+// SetDedupWindow drops the events identical to an event added within window, it must be called before Run.
+// Events are identical when their JSON encoding is, before their ID is assigned. AddEvent returns nil for the
+// events dropped, see Duplicates. Only the events AddEvent accepted are remembered, up to maxEntries of them
+// (DefaultDedupMaxEntries if zero), the oldest being forgotten first.
+func (b *BatchEventsSender) SetDedupWindow(window time.Duration, maxEntries int) {
+	b.dedup = newDedupWindow(window, maxEntries)
+}
 
-				batchPayLoadSize += size
-			}
-			if len(b.EventsQueue) >= b.BatchSize || batchPayLoadSize >= b.PayLoadBytes {
-				b.WaitGroup.Add(1)
-				go b.flush(1)
-				batchPayLoadSize = 0
-			}
+// Duplicates returns the number of events dropped by the dedup window so far
+func (b *BatchEventsSender) Duplicates() uint64 {
+	return b.duplicates.Load()
+}
 
+// Run starts the goroutines accumulating and sending batches
+func (b *BatchEventsSender) Run() {
+	b.run(func() []queuedEvent {
+		if b.spool == nil {
+			return nil
 		}
-	}
+		return b.spool.takeReplay()
+	})
 }
 
-// Stop sends a signal to QuitChan, wait for all registered goroutines to finish, stop ticker and clear queue
-func (b *BatchEventsSender) Stop() {
-	b.stopMux.Lock()
-	defer b.stopMux.Unlock()
+// AddEvent adds event to the next batch. It blocks while the queue of events is full, until ctx is done.
+// With a spool, event is persisted before it's queued. With a validator, event is checked first and its
+// fragments, if any, are added in its place. Duplicates are dropped and IDs assigned before that, if configured.
+func (b *BatchEventsSender) AddEvent(ctx context.Context, event Event) error {
+	return b.addEvent(ctx, event, true)
+}
 
-	if b.IsRunning == false && len(b.EventsQueue) == 0 {
-		return
+// addEvent adds event to the next batch, blocking while the queue is full if block is true and returning
+// errQueueFull otherwise
+func (b *BatchEventsSender) addEvent(ctx context.Context, event Event, block bool) error {
+	if err := b.enter(); err != nil {
+		return err
 	}
-
-	b.IsRunning = false
-	// Wait until no element is in channel
-	for {
-		if len(b.EventsChan) == 0 {
-			break
+	defer b.adders.Done()
+
+	if b.dedup != nil {
+		// Events which can't be encoded are reported by the validator or when their batch is sent
+		if hash, err := eventHash(event); err == nil {
+			ok, release := b.dedup.reserve(hash, time.Now())
+			if !ok {
+				b.duplicates.Add(1)
+				return nil
+			}
+			// Only the events accepted are remembered, so that an event which wasn't can be added again
+			if err := b.addUniqueEvent(ctx, event, block); err != nil {
+				release()
+				return err
+			}
+			return nil
 		}
 	}
-
-	b.IngestTicker.Stop()
-
-	b.WaitGroup.Add(1)
-	// flush one last time before stop
-	go b.flush(2)
-	b.WaitGroup.Wait()
-	b.QuitChan <- struct{}{}
+	return b.addUniqueEvent(ctx, event, block)
 }
 
-// AddEvent pushes a single event into EventsChan
-func (b *BatchEventsSender) AddEvent(event Event) error {
-	if !b.IsRunning {
-		return errors.New("Need to start the BatchEventsSender first, call Run() ")
+// addUniqueEvent adds event once it's known not to be a duplicate
+func (b *BatchEventsSender) addUniqueEvent(ctx context.Context, event Event, block bool) error {
+	if b.ids != nil {
+		if err := assignEventID(&event, b.ids); err != nil {
+			return fmt.Errorf("ingest: can't assign the ID of the event: %w", err)
+		}
 	}
-
-	// Intend to only start ticker when first event is received.
-	if len(b.EventsQueue) == 0 && len(b.EventsChan) == 0 && b.IngestTicker.IsRunning() == false {
-		b.IngestTicker.Start()
+	events := []Event{event}
+	if b.validator != nil {
+		var err error
+		if events, err = b.validator.Event(event); err != nil {
+			return err
+		}
 	}
-
-	for len(b.EventsChan) >= cap(b.EventsChan) {
-		time.Sleep(time.Duration(b.chanWaitMillis) * time.Millisecond)
+	for _, e := range events {
+		if err := b.queueEvent(ctx, e, block); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if b.IsRunning {
-		b.EventsChan <- event
+// queueEvent persists event in the spool, if any, and queues it
+func (b *BatchEventsSender) queueEvent(ctx context.Context, event Event, block bool) error {
+	if b.spool == nil {
+		return enqueue(ctx, b.items, queuedEvent{event: event}, block)
+	}
+	if err := enqueue(ctx, b.slots, struct{}{}, block); err != nil {
+		return err
 	}
+	segment, err := b.spool.append(event)
+	if err != nil {
+		<-b.slots
+		return err
+	}
+	// The slot taken guarantees room in the channel
+	b.items <- queuedEvent{event: event, segment: segment}
 	return nil
 }
 
-// flush sends off all events currently in EventsQueue and resets ticker afterwards
-// If EventsQueue size is bigger than BatchSize, it'll slice the queue into batches and send batches one by one
-func (b *BatchEventsSender) flush(flushSource int) {
-	defer b.WaitGroup.Done()
-	defer b.mux.Unlock()
-
-	b.mux.Lock()
-	// Reset ticker
-	if flushSource == 0 {
-		b.IngestTicker.Reset()
-	} else if flushSource == 1 && len(b.EventsQueue) < b.BatchSize {
-		// it is possible different threads send flush signal while the previous flush already flush everything in queue
-		return
-	}
+// dequeued releases the slot of an event received from the queue
+func (b *BatchEventsSender) dequeued() {
+	<-b.slots
+}
 
-	events := append([]Event(nil), b.EventsQueue...)
-	b.ResetQueue()
+// Flush sends the events added so far without waiting for the batch to be full or the interval to elapse, and
+// waits until they were sent or ctx is done. Delivery failures are reported to the DeliveryHandler.
+func (b *BatchEventsSender) Flush(ctx context.Context) error {
+	return b.flush(ctx)
+}
 
-	// slice events into batch size to send
-	b.sendEventInBatches(events)
+// Close stops accepting events and waits until the events added so far were sent. If ctx is done first the
+// batches left are aborted, and reported to the DeliveryHandler with the error of ctx, before Close returns
+// ctx.Err(). The sender can't be used anymore once closed.
+func (b *BatchEventsSender) Close(ctx context.Context) error {
+	return b.stop(ctx)
+}
 
+// Errors returns the results of the batches which failed to be delivered so far
+func (b *BatchEventsSender) Errors() []DeliveryResult {
+	return b.deliveryErrors()
 }
 
-// sendEventInBatches will slice Event Queue into batches.
-// Add events from event queue into a batch until either the batch events counts size is reached or the payload size limit is hit
-// Once the batch is flushed, another batch is initialized with the remaining elements from events queue until either of the two limits are reached
-func (b *BatchEventsSender) sendEventInBatches(events []Event) {
-	if len(events) <= 0 {
-		return
+// postBatch sends bt. The events sent, and the ones which can't be encoded, are removed from the spool while the
+// events of the batches which failed are requeued.
+func (b *BatchEventsSender) postBatch(ctx context.Context, bt batch) error {
+	err := bt.err
+	if err == nil {
+		_, err = b.EventService.PostEventsWithContext(ctx, bt.events)
 	}
-	start := 0
-	end := start
-	batchPayLoadSize := 0
-
-	for end < len(events) {
-		size, err := b.readEvent(events[end])
-		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			b.Errors = append(b.Errors, ingestError{Error: err, Events: events[end : end+1]})
+	switch {
+	case err == nil || bt.err != nil:
+		// Events which can't be encoded are never sent, they're removed from the spool as well
+		if ackErr := b.ackSpool(bt.segments); err == nil {
+			err = ackErr
 		}
+	case b.spool != nil:
+		b.requeue(bt)
+	}
+	return err
+}
 
-		batchPayLoadSize = batchPayLoadSize + size
-
-		//Increment batch until Payload Size limit is reached or batch events count is hit
-		if batchPayLoadSize > b.PayLoadBytes || end-start == b.BatchSize || end == len(events)-1 {
-			// start send
-			_, err := b.EventService.PostEvents(events[start:end])
-
-			if err != nil {
-				b.Errors = append(b.Errors, ingestError{Error: err, Events: events})
+// requeue queues the events of bt, which failed to be sent, to be added again by the loop. They stay in the spool
+// until they're sent, and are sent again when the spool is opened again if the sender is closed first.
+func (b *BatchEventsSender) requeue(bt batch) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	for i, event := range bt.events {
+		b.requeued = append(b.requeued, queuedEvent{event: event, segment: bt.segments[i]})
+	}
+}
 
-				for len(b.EventsChan) >= cap(b.EventsChan) {
-					time.Sleep(time.Duration(b.chanWaitMillis) * time.Millisecond)
-				}
-				if b.IsRunning {
-					b.ErrorChan <- struct{}{}
-				}
-			}
+// takeRequeued returns the events requeued since it was last called
+func (b *BatchEventsSender) takeRequeued() []queuedEvent {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	requeued := b.requeued
+	b.requeued = nil
+	return requeued
+}
 
-			start = end + 1
-			end = start
-		} else {
-			end = end + 1
+// ackSpool removes the events sent from the spool, the events of failed batches stay in it until they're sent
+func (b *BatchEventsSender) ackSpool(segments []uint64) error {
+	if b.spool == nil {
+		return nil
+	}
+	for i := 0; i < len(segments); {
+		j := i
+		for j < len(segments) && segments[j] == segments[i] {
+			j++
 		}
+		if err := b.spool.ack(segments[i], j-i); err != nil {
+			return fmt.Errorf("ingest: events were sent but could not be removed from the spool: %w", err)
+		}
+		i = j
 	}
+	return nil
 }
 
+// readEvent returns the size of event encoded in JSON, the way it's sent
 func (b *BatchEventsSender) readEvent(event Event) (int, error) {
-	bytes, err := json.Marshal(event.Body)
-	if err == nil {
-		return len(bytes), nil
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return 0, errors.New("can't read event:" + err.Error())
 	}
-
-	// return the max value so that all previous events can be flushed
-	return math.MaxInt32, errors.New("can't read event:" + err.Error())
+	return len(bytes), nil
 }
 
-// ResetQueue sets b.EventsQueue to empty, but keep memory allocated for underlying array
-func (b *BatchEventsSender) ResetQueue() {
-	b.EventsQueue = b.EventsQueue[:0]
-}
+// eventsAccumulator accumulates events into batches of at most batchSize events and payLoadBytes bytes
+type eventsAccumulator struct {
+	batchSize    int
+	payLoadBytes int
+	read         func(Event) (int, error)
 
-// Restart will reset batch event sender, clear up queue, error msg, timer etc.
-func (b *BatchEventsSender) Restart() {
-	defer b.resetMux.Unlock()
-	b.resetMux.Lock()
+	queue    []Event
+	segments []uint64
+	size     int
+}
 
-	if b.IsRunning {
-		b.Stop()
+func (a *eventsAccumulator) add(qe queuedEvent, queue func(batch)) {
+	size, err := a.read(qe.event)
+	if err != nil {
+		queue(batch{events: []Event{qe.event}, segments: []uint64{qe.segment}, err: err})
+		return
 	}
+	if len(a.queue) > 0 && batchSize(a.size, len(a.queue), size) > a.payLoadBytes {
+		bt, _ := a.take()
+		queue(bt)
+	}
+	a.size = batchSize(a.size, len(a.queue), size)
+	a.queue = append(a.queue, qe.event)
+	a.segments = append(a.segments, qe.segment)
+	if len(a.queue) >= a.batchSize || a.size >= a.payLoadBytes {
+		bt, _ := a.take()
+		queue(bt)
+	}
+}
 
-	// reopen channels
-	b.EventsChan = make(chan Event, b.BatchSize)
-	b.ErrorChan = make(chan struct{}, cap(b.ErrorChan))
-
-	b.Errors = nil
-	b.ResetQueue()
-	b.Run()
+func (a *eventsAccumulator) take() (batch, bool) {
+	bt := batch{events: a.queue, segments: a.segments}
+	a.queue, a.segments, a.size = nil, nil, 0
+	return bt, len(bt.events) > 0
 }
@@ -17,7 +17,16 @@
 package ingest
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
 	"github.com/stretchr/testify/assert"
@@ -69,7 +78,7 @@ func TestBatchEventsSenderInitializationWithZeroPayloadSize(t *testing.T) {
 
 	ingestClient := NewService(client)
 	collector, _ := ingestClient.NewBatchEventsSender(5, 10000, 0)
-	assert.Equal(t, collector.PayLoadBytes, 1040000)
+	assert.Equal(t, collector.PayLoadBytes(), 1040000)
 }
 
 func TestBatchEventsSenderInitializationWithNonZeroPayloadSize(t *testing.T) {
@@ -81,7 +90,7 @@ func TestBatchEventsSenderInitializationWithNonZeroPayloadSize(t *testing.T) {
 
 	ingestClient := NewService(client)
 	collector, _ := ingestClient.NewBatchEventsSender(5, 1000, 1000)
-	assert.Equal(t, collector.PayLoadBytes, 1000)
+	assert.Equal(t, collector.PayLoadBytes(), 1000)
 }
 
 func TestBatchEventsSenderState(t *testing.T) {
@@ -96,14 +105,17 @@ func TestBatchEventsSenderState(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Validate initial values
-	assert.Equal(t, 0, len(collector.EventsQueue))
-	assert.Equal(t, 5, cap(collector.EventsQueue))
-	assert.Equal(t, 0, len(collector.EventsChan))
-	assert.Equal(t, 5, cap(collector.EventsChan))
-	assert.Equal(t, 0, len(collector.QuitChan))
-	assert.Equal(t, 1, cap(collector.QuitChan))
-	assert.Equal(t, 5, collector.BatchSize)
-	assert.Equal(t, 20, collector.PayLoadBytes)
+	assert.Equal(t, 0, len(collector.items))
+	assert.Equal(t, 5, cap(collector.items))
+	assert.Equal(t, 5, collector.BatchSize())
+	assert.Equal(t, 20, collector.PayLoadBytes())
+	assert.Equal(t, time.Second, collector.interval)
+	assert.Equal(t, 1, collector.maxErrors)
+
+	// Events can't be added until the sender runs
+	assert.Equal(t, ErrBatchEventsSenderNotRunning, collector.AddEvent(context.Background(), Event{Body: "e"}))
+	assert.NoError(t, collector.Close(context.Background()))
+	assert.True(t, errors.Is(collector.AddEvent(context.Background(), Event{Body: "e"}), ErrBatchEventsSenderClosed))
 }
 
 func TestReadEvent(t *testing.T) {
@@ -121,23 +133,23 @@ func TestReadEvent(t *testing.T) {
 	event = Event{Body: 1}
 	size, err := collector.readEvent(event)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, size)
+	assert.Equal(t, len(`{"body":1}`), size)
 
 	event = Event{Body: true}
 	size, err = collector.readEvent(event)
 	assert.NoError(t, err)
-	assert.Equal(t, 4, size)
+	assert.Equal(t, len(`{"body":true}`), size)
 
 	str := "str"
 	event = Event{Body: str}
 	size, err = collector.readEvent(event)
 	assert.NoError(t, err)
-	assert.Equal(t, 5, size)
+	assert.Equal(t, len(`{"body":"str"}`), size)
 
 	event = Event{Body: `[1,"h"]`}
 	size, err = collector.readEvent(event)
 	assert.NoError(t, err)
-	assert.Equal(t, 11, size)
+	assert.Equal(t, 20, size)
 
 	jsonstr := `{"age": 27,
 		"address": {
@@ -149,5 +161,226 @@ func TestReadEvent(t *testing.T) {
 	event = Event{Body: jsonstr}
 	size, err = collector.readEvent(event)
 	assert.NoError(t, err)
-	assert.Equal(t, 179, size)
+	assert.Equal(t, 188, size)
+
+	// The envelope fields are counted along with the body
+	host := "h"
+	event = Event{Body: str, Host: &host, Attributes: map[string]interface{}{"k": "v"}}
+	size, err = collector.readEvent(event)
+	assert.NoError(t, err)
+	assert.Equal(t, len(`{"body":"str","attributes":{"k":"v"},"host":"h"}`), size)
+}
+
+// ingestRT records the events posted to it and responds with status, requests block while gate is set and open
+type ingestRT struct {
+	status int
+	gate   chan struct{}
+
+	mux     sync.Mutex
+	batches [][]string
+}
+
+func (rt *ingestRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.gate != nil {
+		select {
+		case <-rt.gate:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	var events []Event
+	if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+		return nil, err
+	}
+	bodies := make([]string, len(events))
+	for i, e := range events {
+		bodies[i] = fmt.Sprint(e.Body)
+	}
+	rt.mux.Lock()
+	rt.batches = append(rt.batches, bodies)
+	rt.mux.Unlock()
+	status := rt.status
+	if status == 0 {
+		status = 200
+	}
+	body := ioutil.NopCloser(bytes.NewReader([]byte(`{"code":"SUCCESS","message":"ok"}`)))
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: body, Header: http.Header{}}, nil
+}
+
+func (rt *ingestRT) posted() [][]string {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	return append([][]string(nil), rt.batches...)
+}
+
+// newTestSender returns a running sender posting to rt and spooling events to spool if set, it never flushes on
+// its own interval
+func newTestSender(t *testing.T, rt http.RoundTripper, batchSize, dataSize, maxErrors int, spool *Spool) (*BatchEventsSender, *[]DeliveryResult, *sync.Mutex) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, TypedErrors: true})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSenderWithMaxAllowedError(batchSize, int64(time.Hour/time.Millisecond), dataSize, maxErrors)
+	require.NoError(t, err)
+	var results []DeliveryResult
+	var mux sync.Mutex
+	sender.SetDeliveryHandler(func(result DeliveryResult) {
+		mux.Lock()
+		defer mux.Unlock()
+		results = append(results, result)
+	})
+	if spool != nil {
+		sender.SetSpool(spool)
+	}
+	sender.Run()
+	return sender, &results, &mux
+}
+
+func TestBatchEventsSenderBatchesAndFlush(t *testing.T) {
+	rt := &ingestRT{}
+	sender, results, mux := newTestSender(t, rt, 3, 0, 1, nil)
+	ctx := context.Background()
+	for i := 0; i < 7; i++ {
+		require.NoError(t, sender.AddEvent(ctx, Event{Body: fmt.Sprintf("e%d", i)}))
+	}
+	require.NoError(t, sender.Flush(ctx))
+	assert.Equal(t, [][]string{{"e0", "e1", "e2"}, {"e3", "e4", "e5"}, {"e6"}}, rt.posted())
+
+	require.NoError(t, sender.Close(ctx))
+
+	// Batches don't exceed the payload size, each event below is 13 bytes and two of them take 29 bytes once encoded
+	small, smallResults, smallMux := newTestSender(t, rt, 3, 29, 1, nil)
+	for i := 7; i < 10; i++ {
+		require.NoError(t, small.AddEvent(ctx, Event{Body: fmt.Sprintf("e%d", i)}))
+	}
+	require.NoError(t, small.Close(ctx))
+	assert.Equal(t, [][]string{{"e7", "e8"}, {"e9"}}, rt.posted()[3:])
+
+	mux.Lock()
+	defer mux.Unlock()
+	smallMux.Lock()
+	defer smallMux.Unlock()
+	require.Len(t, *results, 3)
+	require.Len(t, *smallResults, 2)
+	for _, r := range append(*results, *smallResults...) {
+		assert.NoError(t, r.Err)
+	}
+	assert.Empty(t, sender.Errors())
+	assert.Empty(t, small.Errors())
+}
+
+func TestBatchEventsSenderDeliveryFailures(t *testing.T) {
+	rt := &ingestRT{status: 401}
+	sender, results, mux := newTestSender(t, rt, 2, 0, 2, nil)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, sender.AddEvent(ctx, Event{Body: fmt.Sprintf("e%d", i)}))
+	}
+	require.NoError(t, sender.Flush(ctx))
+	require.NoError(t, sender.Close(ctx))
+
+	mux.Lock()
+	defer mux.Unlock()
+	require.Len(t, *results, 2)
+	assert.Equal(t, []Event{{Body: "e0"}, {Body: "e1"}}, (*results)[0].Events)
+	assert.True(t, errors.Is((*results)[0].Err, services.ErrUnauthorized))
+	assert.Equal(t, []Event{{Body: "e2"}, {Body: "e3"}}, (*results)[1].Events)
+	assert.Len(t, sender.Errors(), 2)
+
+	// The sender stopped after the maximum number of errors allowed
+	err := sender.AddEvent(ctx, Event{Body: "e4"})
+	assert.True(t, errors.Is(err, ErrBatchEventsSenderClosed))
+	assert.Contains(t, err.Error(), "2 batches failed")
+}
+
+func TestBatchEventsSenderUnreadableEvent(t *testing.T) {
+	rt := &ingestRT{}
+	sender, results, mux := newTestSender(t, rt, 5, 0, 5, nil)
+	ctx := context.Background()
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "e0"}))
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: make(chan int)}))
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "e2"}))
+	require.NoError(t, sender.Close(ctx))
+
+	assert.Equal(t, [][]string{{"e0", "e2"}}, rt.posted())
+	mux.Lock()
+	defer mux.Unlock()
+	var failed []DeliveryResult
+	for _, r := range *results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+	require.Len(t, failed, 1)
+	assert.Len(t, failed[0].Events, 1)
+	assert.Contains(t, failed[0].Err.Error(), "can't read event")
+}
+
+func TestBatchEventsSenderBackpressureAndClose(t *testing.T) {
+	rt := &ingestRT{gate: make(chan struct{})}
+	sender, results, mux := newTestSender(t, rt, 1, 0, 100, nil)
+
+	// While the first batch is being sent, the following ones are queued until the events channel is full
+	var err error
+	added := 0
+	for err == nil && added < 10 {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		err = sender.AddEvent(ctx, Event{Body: fmt.Sprintf("e%d", added)})
+		cancel()
+		if err == nil {
+			added++
+		}
+	}
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, added, 10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.True(t, errors.Is(sender.Flush(ctx), context.DeadlineExceeded))
+
+	// Closing with a deadline aborts the batches left, which are all reported
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.True(t, errors.Is(sender.Close(ctx), context.DeadlineExceeded))
+	mux.Lock()
+	defer mux.Unlock()
+	assert.Len(t, *results, added)
+	for _, r := range *results {
+		assert.Error(t, r.Err)
+	}
+}
+
+func TestBatchEventsSenderConcurrentAdders(t *testing.T) {
+	rt := &ingestRT{}
+	sender, results, mux := newTestSender(t, rt, 7, 0, 1, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.NoError(t, sender.AddEvent(context.Background(), Event{Body: fmt.Sprintf("e%d-%d", i, j)}))
+				if j%20 == 0 {
+					assert.NoError(t, sender.Flush(context.Background()))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.NoError(t, sender.Close(context.Background()))
+
+	seen := map[string]bool{}
+	for _, batch := range rt.posted() {
+		assert.LessOrEqual(t, len(batch), 7)
+		for _, body := range batch {
+			assert.False(t, seen[body], "%s was sent twice", body)
+			seen[body] = true
+		}
+	}
+	assert.Len(t, seen, 500)
+	mux.Lock()
+	defer mux.Unlock()
+	delivered := 0
+	for _, r := range *results {
+		delivered += len(r.Events)
+	}
+	assert.Equal(t, 500, delivered)
 }
@@ -0,0 +1,350 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	// GaugeMetricType is the type of metrics whose last value over the interval is sent, the default type
+	GaugeMetricType = "g"
+	// CounterMetricType is the type of metrics whose values over the interval are summed
+	CounterMetricType = "c"
+)
+
+var (
+	// ErrBatchMetricsSenderNotRunning is returned when metrics are added before Run is called
+	ErrBatchMetricsSenderNotRunning = errors.New("ingest: need to start the BatchMetricsSender first, call Run()")
+	// ErrBatchMetricsSenderClosed is returned when metrics are added after Close is called or after the
+	// maximum number of errors allowed was reached
+	ErrBatchMetricsSenderClosed = errors.New("ingest: BatchMetricsSender is closed")
+)
+
+// MetricsDeliveryResult is the outcome of sending a batch of metric events
+type MetricsDeliveryResult struct {
+	// MetricEvents are the metric events of the batch, after merging and aggregation
+	MetricEvents []MetricEvent
+	// Err is why the metric events were not delivered, nil if they were
+	Err error
+}
+
+// MetricsDeliveryHandler is called with the result of every batch, one at a time and in the order the batches are sent
+type MetricsDeliveryHandler func(MetricsDeliveryResult)
+
+// metricsBatch is a group of metric events to send, see batch
+type metricsBatch struct {
+	events []MetricEvent
+	err    error
+}
+
+// BatchMetricsSender sends metric events in batches or periodically if batch is not full to Splunk Cloud ingest
+// service endpoints.
+//
+// Metric events added within a batch are merged when they share their host, source, sourcetype and attributes,
+// and the metrics they hold are pre-aggregated: the values of counters (type "c") with the same name, dimensions
+// and unit are summed and only the last value of gauges (type "g", the default) is kept. The merged event takes
+// the latest timestamp of the events merged into it. Metrics of other types or without a value and metric events
+// with an ID are sent as they were added. Metric events holding more than BatchSize() metrics are split across
+// batches.
+//
+// Metric events are added with AddMetricEvent, which blocks while the sender is busy sending previous batches.
+// Close sends all the metric events added so far before returning.
+type BatchMetricsSender struct {
+	// batchSize is the maximum number of metrics in a batch
+	batchSize int
+	// payLoadBytes is the maximum size of the metric events in a batch
+	payLoadBytes int
+	// MetricService is the service sending the batches
+	MetricService *Service
+
+	*batcher[MetricEvent, metricsBatch, MetricsDeliveryResult]
+
+	validator *Validator
+}
+
+// BatchSize returns the maximum number of metrics in a batch
+func (b *BatchMetricsSender) BatchSize() int {
+	return b.batchSize
+}
+
+// PayLoadBytes returns the maximum size of the metric events in a batch
+func (b *BatchMetricsSender) PayLoadBytes() int {
+	return b.payLoadBytes
+}
+
+// SetDeliveryHandler sets the function called with the result of every batch, it must be called before Run
+func (b *BatchMetricsSender) SetDeliveryHandler(handler MetricsDeliveryHandler) {
+	b.handler = handler
+}
+
+// SetValidator sets the Validator checking the metric events added, it must be called before Run.
+// AddMetricEvent then returns the *ValidationError of the metric events rejected.
+func (b *BatchMetricsSender) SetValidator(validator *Validator) {
+	b.validator = validator
+}
+
+// Run starts the goroutines aggregating and sending batches
+func (b *BatchMetricsSender) Run() {
+	b.run(nil)
+}
+
+// AddMetricEvent adds event to the next batch. It blocks while the queue of metric events is full, until ctx is done.
+func (b *BatchMetricsSender) AddMetricEvent(ctx context.Context, event MetricEvent) error {
+	if b.validator != nil {
+		if err := b.validator.MetricEvent(event); err != nil {
+			return err
+		}
+	}
+	if err := b.enter(); err != nil {
+		return err
+	}
+	defer b.adders.Done()
+	return enqueue(ctx, b.items, event, true)
+}
+
+// Flush sends the metric events added so far without waiting for the batch to be full or the interval to elapse,
+// and waits until they were sent or ctx is done. Delivery failures are reported to the MetricsDeliveryHandler.
+func (b *BatchMetricsSender) Flush(ctx context.Context) error {
+	return b.flush(ctx)
+}
+
+// Close stops accepting metric events and waits until the ones added so far were sent. If ctx is done first the
+// batches left are aborted, and reported to the MetricsDeliveryHandler with the error of ctx, before Close returns
+// ctx.Err(). The sender can't be used anymore once closed.
+func (b *BatchMetricsSender) Close(ctx context.Context) error {
+	return b.stop(ctx)
+}
+
+// Errors returns the results of the batches which failed to be delivered so far
+func (b *BatchMetricsSender) Errors() []MetricsDeliveryResult {
+	return b.deliveryErrors()
+}
+
+// postBatch sends bt, the metric events which can't be encoded are reported without being sent
+func (b *BatchMetricsSender) postBatch(ctx context.Context, bt metricsBatch) error {
+	if bt.err != nil {
+		return bt.err
+	}
+	_, err := b.MetricService.PostMetricsWithContext(ctx, bt.events)
+	return err
+}
+
+// metricsAccumulator aggregates metric events into batches of at most batchSize metrics and payLoadBytes bytes
+type metricsAccumulator struct {
+	batchSize    int
+	payLoadBytes int
+	read         func(MetricEvent) (int, error)
+	agg          *metricsAggregator
+}
+
+func (a *metricsAccumulator) add(event MetricEvent, queue func(metricsBatch)) {
+	for _, part := range splitMetricEvent(event, a.batchSize) {
+		size, err := a.read(part)
+		if err != nil {
+			queue(metricsBatch{events: []MetricEvent{part}, err: err})
+			continue
+		}
+		// Assume the event won't be merged, so that the limits are never exceeded
+		if a.agg.count > 0 && (a.agg.count+len(part.Body) > a.batchSize || a.agg.size+size > a.payLoadBytes) {
+			bt, _ := a.take()
+			queue(bt)
+		}
+		a.agg.add(part)
+		if a.agg.count >= a.batchSize || a.agg.size >= a.payLoadBytes {
+			bt, _ := a.take()
+			queue(bt)
+		}
+	}
+}
+
+func (a *metricsAccumulator) take() (metricsBatch, bool) {
+	if a.agg.count == 0 {
+		return metricsBatch{}, false
+	}
+	return metricsBatch{events: a.agg.take()}, true
+}
+
+func (b *BatchMetricsSender) readMetricEvent(event MetricEvent) (int, error) {
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return 0, errors.New("can't read metric event:" + err.Error())
+	}
+	return len(bytes), nil
+}
+
+// splitMetricEvent splits event into events holding at most size of its metrics each
+func splitMetricEvent(event MetricEvent, size int) []MetricEvent {
+	if len(event.Body) <= size {
+		return []MetricEvent{event}
+	}
+	parts := make([]MetricEvent, 0, (len(event.Body)+size-1)/size)
+	for start := 0; start < len(event.Body); start += size {
+		end := start + size
+		if end > len(event.Body) {
+			end = len(event.Body)
+		}
+		part := event
+		part.Body = event.Body[start:end]
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// metricsAggregator merges metric events and aggregates their metrics, count is the number of metrics and size
+// the approximate size of the merged events
+type metricsAggregator struct {
+	groups []*metricGroup
+	byKey  map[string]*metricGroup
+	count  int
+	size   int
+}
+
+// metricGroup is a merged metric event, series maps the key of every aggregated metric to its index in the body
+type metricGroup struct {
+	event  MetricEvent
+	series map[string]int
+}
+
+func newMetricsAggregator() *metricsAggregator {
+	return &metricsAggregator{byKey: map[string]*metricGroup{}}
+}
+
+// add merges event into the group of events sharing its host, source, sourcetype and attributes
+func (a *metricsAggregator) add(event MetricEvent) {
+	var g *metricGroup
+	key, mergeable := metricGroupKey(event)
+	if mergeable {
+		g = a.byKey[key]
+	}
+	if g == nil {
+		g = &metricGroup{event: event, series: map[string]int{}}
+		g.event.Body = nil
+		a.groups = append(a.groups, g)
+		if mergeable {
+			a.byKey[key] = g
+		}
+		a.size += jsonSize(g.event)
+	} else if laterTimestamp(event, g.event) {
+		g.event.Timestamp, g.event.Nanos = event.Timestamp, event.Nanos
+	}
+
+	for _, metric := range event.Body {
+		typ := GaugeMetricType
+		if metric.Type != nil {
+			typ = *metric.Type
+		} else if event.Attributes != nil && event.Attributes.DefaultType != nil {
+			typ = *event.Attributes.DefaultType
+		}
+		var seriesKey string
+		// Metrics without a value are sent as they were added
+		if mergeable && metric.Value != nil && (typ == GaugeMetricType || typ == CounterMetricType) {
+			seriesKey = metricSeriesKey(metric, typ, event.Attributes)
+			if i, ok := g.series[seriesKey]; ok {
+				aggregated := &g.event.Body[i]
+				value := *metric.Value
+				if typ == CounterMetricType {
+					value += *aggregated.Value
+				}
+				aggregated.Value = &value
+				continue
+			}
+			g.series[seriesKey] = len(g.event.Body)
+		}
+		if metric.Value != nil {
+			// Copy the value, which is updated by aggregation
+			value := *metric.Value
+			metric.Value = &value
+		}
+		g.event.Body = append(g.event.Body, metric)
+		a.count++
+		a.size += jsonSize(metric) + 1
+	}
+}
+
+// take returns the merged events and resets the aggregator
+func (a *metricsAggregator) take() []MetricEvent {
+	events := make([]MetricEvent, 0, len(a.groups))
+	for _, g := range a.groups {
+		if len(g.event.Body) > 0 {
+			events = append(events, g.event)
+		}
+	}
+	*a = *newMetricsAggregator()
+	return events
+}
+
+// metricGroupKey returns the key of the events event can be merged with, events with an ID are never merged
+func metricGroupKey(event MetricEvent) (string, bool) {
+	if event.Id != nil {
+		return "", false
+	}
+	key, err := json.Marshal(struct {
+		Host       *string
+		Source     *string
+		Sourcetype *string
+		Attributes *MetricAttribute
+	}{event.Host, event.Source, event.Sourcetype, event.Attributes})
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// metricSeriesKey returns the key of the metrics metric is aggregated with: the same name, type, unit and dimensions
+// once the defaults of attributes are applied
+func metricSeriesKey(metric Metric, typ string, attributes *MetricAttribute) string {
+	dimensions := map[string]string{}
+	unit := metric.Unit
+	if attributes != nil {
+		for k, v := range attributes.DefaultDimensions {
+			dimensions[k] = v
+		}
+		if unit == nil {
+			unit = attributes.DefaultUnit
+		}
+	}
+	for k, v := range metric.Dimensions {
+		dimensions[k] = v
+	}
+	// Maps are marshaled with their keys sorted
+	key, _ := json.Marshal(struct {
+		Name       string
+		Type       string
+		Unit       *string
+		Dimensions map[string]string
+	}{metric.Name, typ, unit, dimensions})
+	return string(key)
+}
+
+// laterTimestamp returns whether event has a timestamp later than the one of other
+func laterTimestamp(event, other MetricEvent) bool {
+	if event.Timestamp == nil {
+		return false
+	}
+	if other.Timestamp == nil || *event.Timestamp != *other.Timestamp {
+		return other.Timestamp == nil || *event.Timestamp > *other.Timestamp
+	}
+	return event.Nanos != nil && (other.Nanos == nil || *event.Nanos > *other.Nanos)
+}
+
+func jsonSize(v interface{}) int {
+	bytes, _ := json.Marshal(v)
+	return len(bytes)
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metricsRT records the metric events posted to it and responds with status
+type metricsRT struct {
+	status int
+
+	mux     sync.Mutex
+	batches [][]MetricEvent
+}
+
+func (rt *metricsRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	var events []MetricEvent
+	if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+		return nil, err
+	}
+	rt.mux.Lock()
+	rt.batches = append(rt.batches, events)
+	rt.mux.Unlock()
+	status := rt.status
+	if status == 0 {
+		status = 200
+	}
+	body := ioutil.NopCloser(bytes.NewReader([]byte(`{"code":"SUCCESS","message":"ok"}`)))
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: body, Header: http.Header{}}, nil
+}
+
+func (rt *metricsRT) posted() [][]MetricEvent {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	return append([][]MetricEvent(nil), rt.batches...)
+}
+
+// newTestMetricsSender returns a running sender posting to rt, it never flushes on its own interval
+func newTestMetricsSender(t *testing.T, rt http.RoundTripper, batchSize, dataSize, maxErrors int) (*BatchMetricsSender, *[]MetricsDeliveryResult, *sync.Mutex) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, TypedErrors: true})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchMetricsSender(batchSize, int64(time.Hour/time.Millisecond), dataSize, maxErrors)
+	require.NoError(t, err)
+	var results []MetricsDeliveryResult
+	var mux sync.Mutex
+	sender.SetDeliveryHandler(func(result MetricsDeliveryResult) {
+		mux.Lock()
+		defer mux.Unlock()
+		results = append(results, result)
+	})
+	sender.Run()
+	return sender, &results, &mux
+}
+
+func metric(name, typ string, value float64, dimensions map[string]string) Metric {
+	m := Metric{Name: name, Value: &value, Dimensions: dimensions}
+	if typ != "" {
+		m.Type = &typ
+	}
+	return m
+}
+
+func TestBatchMetricsSenderInitialization(t *testing.T) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN"})
+	require.NoError(t, err)
+	ingestClient := NewService(client)
+	_, err = ingestClient.NewBatchMetricsSender(0, 1000, 0, 1)
+	assert.EqualError(t, err, "batchSize cannot be 0")
+	_, err = ingestClient.NewBatchMetricsSender(5, 0, 0, 1)
+	assert.EqualError(t, err, "interval cannot be 0")
+
+	sender, err := ingestClient.NewBatchMetricsSender(1000, 1000, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 500, sender.BatchSize())
+	assert.Equal(t, 1040000, sender.PayLoadBytes())
+	assert.Equal(t, 1, sender.maxErrors)
+	assert.Equal(t, ErrBatchMetricsSenderNotRunning, sender.AddMetricEvent(context.Background(), MetricEvent{}))
+}
+
+func TestBatchMetricsSenderAggregation(t *testing.T) {
+	rt := &metricsRT{}
+	sender, _, _ := newTestMetricsSender(t, rt, 100, 0, 1)
+	ctx := context.Background()
+	host, other, id := "web-1", "web-2", "id-1"
+	ts := func(ms int64) *int64 { return &ms }
+	counter := "c"
+	attributes := &MetricAttribute{DefaultType: &counter, DefaultDimensions: map[string]string{"region": "us"}}
+
+	events := []MetricEvent{
+		{Host: &host, Timestamp: ts(1000), Body: []Metric{
+			metric("requests", "c", 1, map[string]string{"code": "200"}),
+			metric("cpu", "", 10, nil),
+		}},
+		{Host: &host, Timestamp: ts(3000), Body: []Metric{
+			metric("requests", "c", 2, map[string]string{"code": "200"}),
+			metric("requests", "c", 5, map[string]string{"code": "500"}),
+			metric("cpu", "g", 30, nil),
+			metric("latency", "h", 7, nil),
+		}},
+		{Host: &host, Timestamp: ts(2000), Body: []Metric{
+			metric("requests", "c", 4, map[string]string{"code": "200"}),
+			metric("latency", "h", 9, nil),
+		}},
+		{Host: &other, Body: []Metric{metric("cpu", "", 50, nil)}},
+		{Host: &host, Id: &id, Body: []Metric{metric("requests", "c", 100, map[string]string{"code": "200"})}},
+		// The attributes differ, the default type makes counters which inherit the default dimensions
+		{Host: &host, Attributes: attributes, Body: []Metric{
+			metric("errors", "", 1, nil),
+			metric("errors", "", 1, map[string]string{"region": "us"}),
+		}},
+	}
+	for _, event := range events {
+		require.NoError(t, sender.AddMetricEvent(ctx, event))
+	}
+	require.NoError(t, sender.Close(ctx))
+
+	posted := rt.posted()
+	require.Len(t, posted, 1)
+	require.Len(t, posted[0], 4)
+	merged := posted[0][0]
+	assert.Equal(t, "web-1", *merged.Host)
+	assert.Equal(t, int64(3000), *merged.Timestamp, "the merged event has the latest timestamp")
+	values := map[string][]float64{}
+	for _, m := range merged.Body {
+		key := m.Name
+		if code, ok := m.Dimensions["code"]; ok {
+			key += "/" + code
+		}
+		values[key] = append(values[key], *m.Value)
+	}
+	assert.Equal(t, map[string][]float64{
+		"requests/200": {7},
+		"requests/500": {5},
+		"cpu":          {30},
+		"latency":      {7, 9},
+	}, values)
+
+	assert.Equal(t, "web-2", *posted[0][1].Host)
+	assert.Equal(t, []Metric{metric("cpu", "", 50, nil)}, posted[0][1].Body)
+	assert.Equal(t, "id-1", *posted[0][2].Id)
+	assert.Equal(t, float64(100), *posted[0][2].Body[0].Value)
+	require.Len(t, posted[0][3].Body, 1)
+	assert.Equal(t, float64(2), *posted[0][3].Body[0].Value)
+
+	// The events added were left untouched
+	assert.Equal(t, float64(1), *events[0].Body[0].Value)
+}
+
+func TestBatchMetricsSenderTriggers(t *testing.T) {
+	rt := &metricsRT{}
+	sender, _, _ := newTestMetricsSender(t, rt, 3, 0, 1)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		// Aggregated series don't count towards the batch size
+		require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Body: []Metric{metric("m", "c", 1, nil)}}))
+	}
+	require.NoError(t, sender.Flush(ctx))
+	require.Len(t, rt.posted(), 1)
+	assert.Equal(t, float64(4), *rt.posted()[0][0].Body[0].Value)
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Body: []Metric{metric(name, "", 1, nil)}}))
+	}
+	require.NoError(t, sender.Flush(ctx))
+	posted := rt.posted()
+	require.Len(t, posted, 3)
+	assert.Len(t, posted[1][0].Body, 3)
+	assert.Len(t, posted[2][0].Body, 1)
+
+	require.NoError(t, sender.Close(ctx))
+
+	// Batches don't exceed the payload size
+	small, _, _ := newTestMetricsSender(t, rt, 3, 60, 1)
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, small.AddMetricEvent(ctx, MetricEvent{Body: []Metric{metric(name, "", 1, nil)}}))
+	}
+	require.NoError(t, small.Close(ctx))
+	posted = rt.posted()
+	require.Len(t, posted, 5)
+	for _, batch := range posted[3:] {
+		b, err := json.Marshal(batch)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(b), 60)
+	}
+}
+
+func TestBatchMetricsSenderLargeEventsAndMissingValues(t *testing.T) {
+	rt := &metricsRT{}
+	sender, _, _ := newTestMetricsSender(t, rt, 3, 0, 1)
+	ctx := context.Background()
+	host := "web-1"
+	var body []Metric
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		body = append(body, metric(name, "", 1, nil))
+	}
+	// The metrics of an event exceeding the batch size are split across batches
+	require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Host: &host, Body: body}))
+	require.NoError(t, sender.Flush(ctx))
+	posted := rt.posted()
+	require.Len(t, posted, 3)
+	for i, size := range []int{3, 3, 1} {
+		require.Len(t, posted[i], 1)
+		assert.Equal(t, "web-1", *posted[i][0].Host)
+		assert.Len(t, posted[i][0].Body, size)
+	}
+
+	// Counters without a value aren't aggregated as 0
+	missing := metric("requests", "c", 0, nil)
+	missing.Value = nil
+	require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Body: []Metric{missing, metric("requests", "c", 2, nil)}}))
+	require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Body: []Metric{metric("requests", "c", 3, nil)}}))
+	require.NoError(t, sender.Close(ctx))
+	posted = rt.posted()
+	require.Len(t, posted, 4)
+	require.Len(t, posted[3], 1)
+	require.Len(t, posted[3][0].Body, 2)
+	assert.Nil(t, posted[3][0].Body[0].Value)
+	assert.Equal(t, float64(5), *posted[3][0].Body[1].Value)
+}
+
+func TestBatchMetricsSenderFailures(t *testing.T) {
+	rt := &metricsRT{status: 429}
+	sender, results, mux := newTestMetricsSender(t, rt, 10, 0, 2)
+	ctx := context.Background()
+	require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Body: []Metric{metric("nan", "", math.NaN(), nil)}}))
+	require.NoError(t, sender.AddMetricEvent(ctx, MetricEvent{Body: []Metric{metric("m", "", 1, nil)}}))
+	require.NoError(t, sender.Flush(ctx))
+	require.NoError(t, sender.Close(ctx))
+
+	assert.Len(t, rt.posted(), 1)
+	mux.Lock()
+	defer mux.Unlock()
+	require.Len(t, *results, 2)
+	assert.Contains(t, (*results)[0].Err.Error(), "can't read metric event")
+	assert.True(t, errors.Is((*results)[1].Err, services.ErrThrottled))
+	assert.Len(t, sender.Errors(), 2)
+	err := sender.AddMetricEvent(ctx, MetricEvent{})
+	assert.True(t, errors.Is(err, ErrBatchMetricsSenderClosed))
+	assert.Contains(t, err.Error(), "2 batches failed")
+}
@@ -0,0 +1,337 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errQueueFull is returned by enqueue when the queue is full and it must not block
+var errQueueFull = errors.New("ingest: the queue of events is full")
+
+// batcher is the lifecycle shared by BatchEventsSender and BatchMetricsSender: items are queued by the adders, a
+// goroutine accumulates them into batches and another one sends the batches one at a time, reporting the result
+// of every batch. T is the type of the items, B the one of the batches and R the one of the delivery results.
+type batcher[T, B, R any] struct {
+	interval  time.Duration
+	maxErrors int
+	// errNotRunning is returned until Run is called, errClosed once closed
+	errNotRunning error
+	errClosed     error
+
+	// acc accumulates the items into batches, it's only used by the loop
+	acc accumulator[T, B]
+	// post sends a batch, ctx is canceled when Close times out
+	post func(ctx context.Context, bt B) error
+	// result returns the delivery result of a batch
+	result  func(bt B, err error) R
+	handler func(R)
+	// received, if set, is called by the loop with every item received from the queue
+	received func()
+	// retries, if set, returns the items to add again every interval
+	retries func() []T
+
+	items    chan T
+	flushes  chan chan struct{}
+	batches  chan queuedBatch[B]
+	loopDone chan struct{}
+	done     chan struct{}
+
+	// sendCtx is canceled when Close times out, to abort the batches being sent
+	sendCtx    context.Context
+	cancelSend context.CancelFunc
+
+	mux     sync.Mutex
+	running bool
+	closed  bool
+	// closeErr is returned to the adders once closed
+	closeErr error
+	// adders tracks the adders in progress, items is closed once they are done
+	adders    sync.WaitGroup
+	numErrors int
+	errs      []R
+}
+
+// accumulator accumulates the items of a batcher into batches
+type accumulator[T, B any] interface {
+	// add adds item, calling queue with the batches it completes. An item which can't be sent is queued in a
+	// batch of its own carrying the error.
+	add(item T, queue func(B))
+	// take returns the batch of the items added since the last batch, false if there are none
+	take() (B, bool)
+}
+
+// queuedBatch is a batch queued by the loop, ok is false for the batches only carrying done. done, if set, is
+// closed once the batch and every batch before it were sent.
+type queuedBatch[B any] struct {
+	batch B
+	ok    bool
+	done  chan struct{}
+}
+
+// newBatcher creates a batcher queuing up to size items, its hooks are set by the sender
+func newBatcher[T, B, R any](size int, interval time.Duration, maxErrors int, errNotRunning, errClosed error) *batcher[T, B, R] {
+	sendCtx, cancelSend := context.WithCancel(context.Background())
+	return &batcher[T, B, R]{
+		interval:      interval,
+		maxErrors:     maxErrors,
+		errNotRunning: errNotRunning,
+		errClosed:     errClosed,
+		items:         make(chan T, size),
+		flushes:       make(chan chan struct{}),
+		batches:       make(chan queuedBatch[B], 1),
+		loopDone:      make(chan struct{}),
+		done:          make(chan struct{}),
+		sendCtx:       sendCtx,
+		cancelSend:    cancelSend,
+	}
+}
+
+// run starts the goroutines accumulating and sending batches, initial, if set, is called once they start and
+// returns the items to add first
+func (b *batcher[T, B, R]) run(initial func() []T) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.running || b.closed {
+		return
+	}
+	b.running = true
+	var items []T
+	if initial != nil {
+		items = initial()
+	}
+	go b.loop(items)
+	go b.send()
+}
+
+// accepting returns whether items can be added, i.e. the batcher is running and not closed
+func (b *batcher[T, B, R]) accepting() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.running && !b.closed
+}
+
+// enter registers an adder, which must call b.adders.Done once its items are queued. It returns errNotRunning
+// or the error the batcher was closed with if items can't be added.
+func (b *batcher[T, B, R]) enter() error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.closed {
+		return b.closeErr
+	}
+	if !b.running {
+		return b.errNotRunning
+	}
+	b.adders.Add(1)
+	return nil
+}
+
+// enqueue sends v to ch, it returns errQueueFull if ch is full and block is false, ctx.Err() if ctx is done first
+func enqueue[T any](ctx context.Context, ch chan<- T, v T, block bool) error {
+	select {
+	case ch <- v:
+		return nil
+	default:
+	}
+	if !block {
+		return errQueueFull
+	}
+	select {
+	case ch <- v:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush sends the items added so far and waits until they were sent or ctx is done
+func (b *batcher[T, B, R]) flush(ctx context.Context) error {
+	b.mux.Lock()
+	running := b.running
+	b.mux.Unlock()
+	if !running {
+		return b.errNotRunning
+	}
+	done := make(chan struct{})
+	select {
+	case b.flushes <- done:
+	case <-b.loopDone:
+		// Closing, all the items added are sent before b.done is closed
+		done = b.done
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stop stops accepting items and waits until the items added so far were sent. If ctx is done first the batches
+// left are aborted, and reported with the error of ctx, before stop returns ctx.Err().
+func (b *batcher[T, B, R]) stop(ctx context.Context) error {
+	b.shutdown(b.errClosed)
+	b.mux.Lock()
+	running := b.running
+	b.mux.Unlock()
+	if !running {
+		return nil
+	}
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		b.cancelSend()
+		<-b.done
+		return ctx.Err()
+	}
+}
+
+// deliveryErrors returns the results of the batches which failed to be delivered so far
+func (b *batcher[T, B, R]) deliveryErrors() []R {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return append([]R(nil), b.errs...)
+}
+
+// shutdown stops accepting items, the adders get err from now on. The items channel is closed once the adders in
+// progress are done, the loop then queues the last batch and stops.
+func (b *batcher[T, B, R]) shutdown(err error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	b.closeErr = err
+	if !b.running {
+		return
+	}
+	go func() {
+		b.adders.Wait()
+		close(b.items)
+	}()
+}
+
+// loop accumulates items into batches until the items channel is closed, a batch is queued when it's full, when
+// the interval elapses and when a flush is requested. The initial items come first, the retries are added every
+// interval.
+func (b *batcher[T, B, R]) loop(initial []T) {
+	defer close(b.loopDone)
+	defer close(b.batches)
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	// Unlike ticker, retries isn't reset when a batch is queued so that failed items are retried under load too
+	var retries <-chan time.Time
+	if b.retries != nil {
+		retryTicker := time.NewTicker(b.interval)
+		defer retryTicker.Stop()
+		retries = retryTicker.C
+	}
+
+	queue := func(bt B) {
+		b.batches <- queuedBatch[B]{batch: bt, ok: true}
+		ticker.Reset(b.interval)
+	}
+	queueBatch := func(done chan struct{}) {
+		bt, ok := b.acc.take()
+		if ok || done != nil {
+			b.batches <- queuedBatch[B]{batch: bt, ok: ok, done: done}
+			ticker.Reset(b.interval)
+		}
+	}
+	receive := func(item T) {
+		if b.received != nil {
+			b.received()
+		}
+		b.acc.add(item, queue)
+	}
+
+	for _, item := range initial {
+		b.acc.add(item, queue)
+	}
+	for {
+		select {
+		case item, ok := <-b.items:
+			if !ok {
+				queueBatch(nil)
+				return
+			}
+			receive(item)
+		case <-ticker.C:
+			queueBatch(nil)
+		case <-retries:
+			for _, item := range b.retries() {
+				b.acc.add(item, queue)
+			}
+		case done := <-b.flushes:
+			// Every item added before flush was called is either accumulated or in the channel
+			for drained := false; !drained; {
+				select {
+				case item, ok := <-b.items:
+					if !ok {
+						queueBatch(done)
+						return
+					}
+					receive(item)
+				default:
+					drained = true
+				}
+			}
+			queueBatch(done)
+		}
+	}
+}
+
+// send sends the batches queued by loop one at a time
+func (b *batcher[T, B, R]) send() {
+	defer close(b.done)
+	defer b.cancelSend()
+	for qb := range b.batches {
+		if qb.ok {
+			b.deliver(qb.batch, b.post(b.sendCtx, qb.batch))
+		}
+		if qb.done != nil {
+			close(qb.done)
+		}
+	}
+}
+
+// deliver records and reports the result of bt, the batcher is closed once the maximum number of errors allowed
+// is reached
+func (b *batcher[T, B, R]) deliver(bt B, err error) {
+	result := b.result(bt, err)
+	if err != nil {
+		b.mux.Lock()
+		b.errs = append(b.errs, result)
+		b.numErrors++
+		numErrors := b.numErrors
+		b.mux.Unlock()
+		if numErrors >= b.maxErrors {
+			b.shutdown(fmt.Errorf("%w: %d batches failed, last error: %v", b.errClosed, numErrors, err))
+		}
+	}
+	if b.handler != nil {
+		b.handler(result)
+	}
+}
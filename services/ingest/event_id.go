@@ -0,0 +1,255 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IDStrategy assigns IDs to the events which don't have one, the ingest service deduplicates the events sent
+// several times with the same ID
+type IDStrategy interface {
+	// EventID returns the ID of event
+	EventID(event Event) (string, error)
+}
+
+// IDStrategyFunc is an IDStrategy computing the IDs with a user-supplied function
+type IDStrategyFunc func(event Event) (string, error)
+
+// EventID calls f
+func (f IDStrategyFunc) EventID(event Event) (string, error) {
+	return f(event)
+}
+
+// ContentHashIDs assigns the SHA-256 of the JSON encoding of events as their ID, identical events then share
+// their ID and only one of them is indexed
+var ContentHashIDs IDStrategy = IDStrategyFunc(func(event Event) (string, error) {
+	sum, err := eventHash(event)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum[:]), nil
+})
+
+// ULIDs assigns a new ULID to every event, IDs are sortable by the time they were assigned at
+var ULIDs IDStrategy = &ulidGenerator{}
+
+/*
+AssignEventIDs - Assigns an ID to the events which don't have one, in place, so that the events keep their ID when
+they are sent again e.g. after a timeout.
+Parameters:
+
+	events
+	strategy: the strategy computing the IDs
+*/
+func AssignEventIDs(events []Event, strategy IDStrategy) error {
+	for i := range events {
+		if err := assignEventID(&events[i], strategy); err != nil {
+			return fmt.Errorf("ingest: can't assign the ID of event %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func assignEventID(event *Event, strategy IDStrategy) error {
+	if event.Id != nil {
+		return nil
+	}
+	id, err := strategy.EventID(*event)
+	if err != nil {
+		return err
+	}
+	event.Id = &id
+	return nil
+}
+
+/*
+PostEventsWithIDs - Sends events, after assigning an ID to the ones which don't have one.
+Parameters:
+
+	events: the events, their IDs are assigned in place so that sending them again doesn't duplicate them
+	strategy: the strategy computing the IDs
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PostEventsWithIDs(events []Event, strategy IDStrategy, resp ...*http.Response) (*HttpResponse, error) {
+	return s.PostEventsWithIDsWithContext(context.Background(), events, strategy, resp...)
+}
+
+/*
+PostEventsWithIDsWithContext - Sends events, after assigning an ID to the ones which don't have one.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	events: the events, their IDs are assigned in place so that sending them again doesn't duplicate them
+	strategy: the strategy computing the IDs
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PostEventsWithIDsWithContext(ctx context.Context, events []Event, strategy IDStrategy, resp ...*http.Response) (*HttpResponse, error) {
+	if err := AssignEventIDs(events, strategy); err != nil {
+		return nil, err
+	}
+	return s.PostEventsWithContext(ctx, events, resp...)
+}
+
+// eventHash returns the SHA-256 of the JSON encoding of event
+func eventHash(event Event) ([sha256.Size]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// ulidGenerator generates ULIDs, see https://github.com/ulid/spec. The ULIDs generated within the same
+// millisecond are monotonic.
+type ulidGenerator struct {
+	mux     sync.Mutex
+	lastMs  uint64
+	lastHi  uint16
+	lastLow uint64
+}
+
+// crockford is the Crockford's base32 alphabet ULIDs are encoded with
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func (g *ulidGenerator) EventID(Event) (string, error) {
+	return g.next(time.Now())
+}
+
+func (g *ulidGenerator) next(now time.Time) (string, error) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	ms := uint64(now.UnixMilli())
+	if ms == g.lastMs {
+		// Increment the 80 bits random part
+		g.lastLow++
+		if g.lastLow == 0 {
+			g.lastHi++
+			if g.lastHi == 0 {
+				return "", errors.New("ingest: too many ULIDs generated within the same millisecond")
+			}
+		}
+	} else {
+		var entropy [10]byte
+		if _, err := rand.Read(entropy[:]); err != nil {
+			return "", err
+		}
+		g.lastMs = ms
+		g.lastHi = binary.BigEndian.Uint16(entropy[:2])
+		g.lastLow = binary.BigEndian.Uint64(entropy[2:])
+	}
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[:8], g.lastMs<<16|uint64(g.lastHi))
+	binary.BigEndian.PutUint64(id[8:], g.lastLow)
+	return encodeULID(id), nil
+}
+
+// encodeULID encodes the 128 bits of id as 26 characters, 5 bits at a time starting with the 2 most significant
+func encodeULID(id [16]byte) string {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// DefaultDedupMaxEntries is the number of events remembered by the dedup window of a BatchEventsSender unless
+// configured otherwise
+const DefaultDedupMaxEntries = 100000
+
+// dedupWindow remembers the hashes of the events seen within a time window, up to maxEntries of them
+type dedupWindow struct {
+	window     time.Duration
+	maxEntries int
+
+	mux sync.Mutex
+	// seen holds the sequence number of the entry of every hash remembered
+	seen map[[sha256.Size]byte]uint64
+	// order holds the hashes by the time they were seen at, to expire them
+	order []dedupEntry
+	seq   uint64
+}
+
+type dedupEntry struct {
+	hash [sha256.Size]byte
+	at   time.Time
+	seq  uint64
+}
+
+func newDedupWindow(window time.Duration, maxEntries int) *dedupWindow {
+	if maxEntries <= 0 {
+		maxEntries = DefaultDedupMaxEntries
+	}
+	return &dedupWindow{window: window, maxEntries: maxEntries, seen: map[[sha256.Size]byte]uint64{}}
+}
+
+// reserve records hash at now unless it was seen within the window, in which case ok is false. The oldest hash is
+// forgotten once maxEntries are remembered. release forgets hash again, e.g. when its event wasn't accepted, unless
+// it was forgotten since.
+func (d *dedupWindow) reserve(hash [sha256.Size]byte, now time.Time) (ok bool, release func()) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.expire(now)
+	if _, seen := d.seen[hash]; seen {
+		return false, nil
+	}
+	if len(d.order) >= d.maxEntries {
+		delete(d.seen, d.order[0].hash)
+		d.order = d.order[1:]
+	}
+	d.seq++
+	seq := d.seq
+	d.seen[hash] = seq
+	d.order = append(d.order, dedupEntry{hash: hash, at: now, seq: seq})
+	return true, func() {
+		d.mux.Lock()
+		defer d.mux.Unlock()
+		if d.seen[hash] != seq {
+			return
+		}
+		delete(d.seen, hash)
+		for i, e := range d.order {
+			if e.seq == seq {
+				d.order = append(d.order[:i:i], d.order[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// expire forgets the hashes seen before the window at now, it must be called with d.mux held
+func (d *dedupWindow) expire(now time.Time) {
+	expired := 0
+	for expired < len(d.order) && now.Sub(d.order[expired].at) >= d.window {
+		delete(d.seen, d.order[expired].hash)
+		expired++
+	}
+	d.order = d.order[expired:]
+}
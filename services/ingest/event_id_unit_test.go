@@ -0,0 +1,211 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestULIDs(t *testing.T) {
+	var id [16]byte
+	// The timestamp of the example of the ULID specification
+	ms := uint64(1469918176385)
+	id[0], id[1], id[2], id[3], id[4], id[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	assert.Equal(t, "01ARYZ6S410000000000000000", encodeULID(id))
+	for i := range id {
+		id[i] = 0xff
+	}
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", encodeULID(id))
+
+	g := &ulidGenerator{}
+	now := time.UnixMilli(1469918176385)
+	first, err := g.next(now)
+	require.NoError(t, err)
+	second, err := g.next(now)
+	require.NoError(t, err)
+	later, err := g.next(now.Add(time.Millisecond))
+	require.NoError(t, err)
+	assert.Len(t, first, 26)
+	assert.Equal(t, "01ARYZ6S41", first[:10])
+	assert.Less(t, first, second, "ULIDs of the same millisecond are monotonic")
+	assert.Less(t, second, later)
+
+	g.lastHi, g.lastLow = 0xffff, 1<<64-1
+	_, err = g.next(now.Add(time.Millisecond))
+	assert.Error(t, err, "the random part overflowed")
+}
+
+func TestAssignEventIDs(t *testing.T) {
+	id := "mine"
+	events := []Event{{Body: "a"}, {Body: "a"}, {Body: "b", Id: &id}}
+	require.NoError(t, AssignEventIDs(events, ContentHashIDs))
+	require.NotNil(t, events[0].Id)
+	assert.Len(t, *events[0].Id, 64)
+	assert.Equal(t, *events[0].Id, *events[1].Id, "identical events have the same content hash")
+	assert.Equal(t, "mine", *events[2].Id, "IDs are kept")
+
+	events = []Event{{Body: "a"}, {Body: "a"}}
+	require.NoError(t, AssignEventIDs(events, ULIDs))
+	assert.NotEqual(t, *events[0].Id, *events[1].Id)
+
+	events = []Event{{Body: "a"}}
+	require.NoError(t, AssignEventIDs(events, IDStrategyFunc(func(event Event) (string, error) {
+		return "user-" + event.Body.(string), nil
+	})))
+	assert.Equal(t, "user-a", *events[0].Id)
+	err := AssignEventIDs([]Event{{Body: make(chan int)}}, ContentHashIDs)
+	assert.Error(t, err)
+}
+
+func TestPostEventsWithIDs(t *testing.T) {
+	rt := &eventsRT{}
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, TypedErrors: true})
+	require.NoError(t, err)
+	events := []Event{{Body: "a"}, {Body: "b"}}
+	_, err = NewService(client).PostEventsWithIDs(events, ULIDs)
+	require.NoError(t, err)
+	posted := rt.posted()
+	require.Len(t, posted, 2)
+	assert.Equal(t, events[0].Id, posted[0].Id, "the IDs are assigned in place, to be sent again")
+	assert.Equal(t, events[1].Id, posted[1].Id)
+}
+
+func TestBatchEventsSenderIDsAndDedup(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: &ingestRT{status: 401}, TypedErrors: true})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSenderWithMaxAllowedError(10, int64(time.Hour/time.Millisecond), 0, 10)
+	require.NoError(t, err)
+	sender.SetSpool(spool)
+	sender.SetIDStrategy(ULIDs)
+	sender.SetDedupWindow(time.Minute, 0)
+	sender.Run()
+	ctx := context.Background()
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "a"}))
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "a"}), "duplicates are dropped silently")
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "b"}))
+	require.NoError(t, sender.Close(ctx))
+	require.NoError(t, spool.Close())
+	assert.Equal(t, uint64(1), sender.Duplicates())
+
+	errs := sender.Errors()
+	require.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0].Err, services.ErrUnauthorized))
+	failed := errs[0].Events
+	require.Len(t, failed, 2)
+	require.NotNil(t, failed[0].Id)
+
+	// The events which failed are replayed with the IDs they were first sent with
+	spool, err = OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	defer spool.Close()
+	replay := spool.takeReplay()
+	require.Len(t, replay, 2)
+	assert.Equal(t, *failed[0].Id, *replay[0].event.Id)
+	assert.Equal(t, *failed[1].Id, *replay[1].event.Id)
+}
+
+func TestDedupWindow(t *testing.T) {
+	d := newDedupWindow(10*time.Second, 0)
+	assert.Equal(t, DefaultDedupMaxEntries, d.maxEntries)
+	a, b, c := sha256.Sum256([]byte("a")), sha256.Sum256([]byte("b")), sha256.Sum256([]byte("c"))
+	start := time.Now()
+	reserve := func(hash [sha256.Size]byte, now time.Time) bool {
+		ok, _ := d.reserve(hash, now)
+		return ok
+	}
+	assert.True(t, reserve(a, start))
+	assert.True(t, reserve(b, start.Add(5*time.Second)))
+	assert.False(t, reserve(a, start.Add(9*time.Second)))
+	assert.True(t, reserve(a, start.Add(10*time.Second)), "a expired")
+	assert.False(t, reserve(b, start.Add(14*time.Second)))
+	assert.Len(t, d.order, 2)
+
+	// A released hash is forgotten, unless it was reserved again since
+	ok, release := d.reserve(c, start.Add(14*time.Second))
+	require.True(t, ok)
+	release()
+	assert.Len(t, d.order, 2)
+	assert.True(t, reserve(c, start.Add(14*time.Second)))
+	release()
+	assert.False(t, reserve(c, start.Add(14*time.Second)))
+
+	// The oldest hashes are forgotten beyond the maximum number of entries
+	d = newDedupWindow(time.Minute, 2)
+	assert.True(t, reserve(a, start))
+	assert.True(t, reserve(b, start))
+	assert.False(t, reserve(b, start))
+	assert.Len(t, d.order, 2, "hashes are remembered once")
+	assert.True(t, reserve(c, start))
+	assert.True(t, reserve(a, start), "a was forgotten")
+	assert.Len(t, d.seen, 2)
+}
+
+func TestBatchEventsSenderDedupRejectedEvents(t *testing.T) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: &ingestRT{}})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSender(2, 1000, 0)
+	require.NoError(t, err)
+	sender.SetDedupWindow(time.Minute, 0)
+	// The sender accepts events without sending them, its queue holds 2 events
+	sender.running = true
+	ctx := context.Background()
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "a"}))
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "b"}))
+
+	// The event wasn't accepted since the queue is full, it can be added again
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, context.Canceled, sender.AddEvent(canceled, Event{Body: "c"}))
+	<-sender.items
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "c"}))
+	assert.Len(t, sender.items, 2)
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "c"}))
+	assert.Equal(t, uint64(1), sender.Duplicates())
+}
+
+func TestBatchEventsSenderDedupConcurrentEvents(t *testing.T) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: &ingestRT{}})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSender(100, 1000, 0)
+	require.NoError(t, err)
+	sender.SetDedupWindow(time.Minute, 0)
+	sender.running = true
+	// The same event is added by concurrent requests, only one of them queues it
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, sender.AddEvent(context.Background(), Event{Body: "a"}))
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, sender.items, 1)
+	assert.Equal(t, uint64(49), sender.Duplicates())
+}
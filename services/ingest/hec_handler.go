@@ -0,0 +1,358 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHECMaxBodyBytes is the maximum size of a request accepted by a HECHandler unless configured otherwise
+const DefaultHECMaxBodyBytes = 8 << 20
+
+// HEC response codes, see the HTTP Event Collector documentation
+const (
+	hecSuccess          = 0
+	hecTokenRequired    = 2
+	hecInvalidAuth      = 3
+	hecInvalidToken     = 4
+	hecNoData           = 5
+	hecInvalidFormat    = 6
+	hecServerBusy       = 9
+	hecEventRequired    = 12
+	hecEventBlank       = 13
+	hecHealthy          = 17
+	hecAuthScheme       = "Splunk"
+	hecDefaultHealthMsg = "HEC is healthy"
+)
+
+// HECHandlerConfig configures a HECHandler
+type HECHandlerConfig struct {
+	// Tokens are the HEC tokens accepted in the `Authorization: Splunk <token>` header
+	Tokens []string
+	// MaxBodyBytes is the maximum size of a request body, once decompressed, DefaultHECMaxBodyBytes if zero
+	MaxBodyBytes int64
+}
+
+// HECHandler is an http.Handler speaking the Splunk HTTP Event Collector (HEC) protocol, so that agents which only
+// support HEC can send events to the ingest service. The events received are added to a BatchEventsSender.
+//
+// It serves the following paths, wherever the handler is mounted:
+//
+//	/services/collector/event, /services/collector and /services/collector/event/1.0 accept HEC JSON events,
+//	concatenated with or without newlines e.g. {"event":"a","time":1700000000.5}\n{"event":{"b":1},"host":"h"}
+//	/services/collector/raw and /services/collector/raw/1.0 accept raw events, one per line
+//	/services/collector/health reports whether the handler accepts events: it's busy (503) while the sender isn't
+//	running, before Run or once closed
+//
+// The time, host, source and sourcetype of HEC events, or of the query parameters of the request, are those of the
+// ingest events and their fields, along with the index if any, are the attributes. Requests are rejected as a
+// whole if any of their events is invalid, including for the Validator of the sender, and bodies compressed with
+// `Content-Encoding: gzip` are supported. Once validated, the events are added in order: when the sender stops
+// accepting them midway, e.g. because it's closed, the 503 response holds the index of the first event which
+// wasn't accepted as its invalid-event-number, the ones before it were.
+type HECHandler struct {
+	sender       *BatchEventsSender
+	maxBodyBytes int64
+
+	mux    sync.RWMutex
+	tokens map[string]bool
+}
+
+// ErrBodyTooLarge is returned by ReadRequestBody when the body of a request exceeds the maximum size
+var ErrBodyTooLarge = errors.New("ingest: request body is too large")
+
+// hecEvent is an event of the HEC JSON format
+type hecEvent struct {
+	Time       json.RawMessage        `json:"time"`
+	Host       string                 `json:"host"`
+	Source     string                 `json:"source"`
+	Sourcetype string                 `json:"sourcetype"`
+	Index      string                 `json:"index"`
+	Fields     map[string]interface{} `json:"fields"`
+	Event      json.RawMessage        `json:"event"`
+}
+
+// hecResponse is the body of HEC responses
+type hecResponse struct {
+	Text               string `json:"text"`
+	Code               int    `json:"code"`
+	InvalidEventNumber *int   `json:"invalid-event-number,omitempty"`
+}
+
+// NewHECHandler creates a HECHandler adding the events received to sender
+func NewHECHandler(sender *BatchEventsSender, config HECHandlerConfig) *HECHandler {
+	h := &HECHandler{sender: sender, maxBodyBytes: config.MaxBodyBytes}
+	if h.maxBodyBytes <= 0 {
+		h.maxBodyBytes = DefaultHECMaxBodyBytes
+	}
+	h.SetTokens(config.Tokens)
+	return h
+}
+
+// SetTokens replaces the HEC tokens accepted by the handler, e.g. when they are rotated
+func (h *HECHandler) SetTokens(tokens []string) {
+	accepted := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		accepted[token] = true
+	}
+	h.mux.Lock()
+	defer h.mux.Unlock()
+	h.tokens = accepted
+}
+
+// ServeHTTP implements http.Handler
+func (h *HECHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	var raw bool
+	switch {
+	case strings.HasSuffix(path, "/services/collector/health"), strings.HasSuffix(path, "/services/collector/health/1.0"):
+		if !h.sender.accepting() {
+			writeHECResponse(w, http.StatusServiceUnavailable, hecResponse{Text: "Server is busy", Code: hecServerBusy})
+			return
+		}
+		writeHECResponse(w, http.StatusOK, hecResponse{Text: hecDefaultHealthMsg, Code: hecHealthy})
+		return
+	case strings.HasSuffix(path, "/services/collector/raw"), strings.HasSuffix(path, "/services/collector/raw/1.0"):
+		raw = true
+	case strings.HasSuffix(path, "/services/collector"), strings.HasSuffix(path, "/services/collector/event"),
+		strings.HasSuffix(path, "/services/collector/event/1.0"):
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if status, resp := h.authorize(r); status != http.StatusOK {
+		writeHECResponse(w, status, resp)
+		return
+	}
+
+	body, err := ReadRequestBody(w, r, h.maxBodyBytes)
+	if errors.Is(err, ErrBodyTooLarge) {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		writeHECResponse(w, http.StatusBadRequest, hecResponse{Text: "Invalid data format", Code: hecInvalidFormat})
+		return
+	}
+	var events []Event
+	var resp hecResponse
+	if raw {
+		events = parseHECRaw(body, r)
+	} else {
+		events, resp = parseHECEvents(body, r)
+		if resp.Code != hecSuccess {
+			writeHECResponse(w, http.StatusBadRequest, resp)
+			return
+		}
+	}
+	if len(events) == 0 {
+		writeHECResponse(w, http.StatusBadRequest, hecResponse{Text: "No data", Code: hecNoData})
+		return
+	}
+	if validator := h.sender.validator; validator != nil {
+		for i := range events {
+			if _, err := validator.Event(events[i]); err != nil {
+				writeHECResponse(w, http.StatusBadRequest, hecResponse{Text: "Invalid data format", Code: hecInvalidFormat, InvalidEventNumber: &i})
+				return
+			}
+		}
+	}
+	for i, event := range events {
+		if err := h.sender.AddEvent(r.Context(), event); err != nil {
+			// The events before the first one which wasn't accepted were, they must not be sent again
+			resp := hecResponse{Text: "Server is busy", Code: hecServerBusy}
+			if i > 0 {
+				resp.InvalidEventNumber = &i
+			}
+			writeHECResponse(w, http.StatusServiceUnavailable, resp)
+			return
+		}
+	}
+	writeHECResponse(w, http.StatusOK, hecResponse{Text: "Success", Code: hecSuccess})
+}
+
+// authorize checks the HEC token of r
+func (h *HECHandler) authorize(r *http.Request) (int, hecResponse) {
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		return http.StatusUnauthorized, hecResponse{Text: "Token is required", Code: hecTokenRequired}
+	}
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, hecAuthScheme) || token == "" {
+		return http.StatusUnauthorized, hecResponse{Text: "Invalid authorization", Code: hecInvalidAuth}
+	}
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+	if !h.tokens[strings.TrimSpace(token)] {
+		return http.StatusForbidden, hecResponse{Text: "Invalid token", Code: hecInvalidToken}
+	}
+	return http.StatusOK, hecResponse{}
+}
+
+// ReadRequestBody reads the body of r received by a receiver such as the HECHandler, decompressing it if its
+// Content-Encoding is gzip, up to maxBytes. It returns ErrBodyTooLarge for larger bodies.
+func ReadRequestBody(w http.ResponseWriter, r *http.Request, maxBytes int64) ([]byte, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxBytes)
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		body = gz
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxBytes+1))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || int64(len(data)) > maxBytes {
+		return nil, ErrBodyTooLarge
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// hecDefaults returns an event with the host, source and sourcetype of the query parameters of r
+func hecDefaults(r *http.Request) Event {
+	var event Event
+	query := r.URL.Query()
+	if host := query.Get("host"); host != "" {
+		event.Host = &host
+	}
+	if source := query.Get("source"); source != "" {
+		event.Source = &source
+	}
+	if sourcetype := query.Get("sourcetype"); sourcetype != "" {
+		event.Sourcetype = &sourcetype
+	}
+	return event
+}
+
+// parseHECRaw returns an event for every non-empty line of body
+func parseHECRaw(body []byte, r *http.Request) []Event {
+	defaults := hecDefaults(r)
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(nil, len(body)+1)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		event := defaults
+		event.Body = line
+		events = append(events, event)
+	}
+	return events
+}
+
+// parseHECEvents decodes the HEC JSON events of body, the response code is not hecSuccess if any is invalid
+func parseHECEvents(body []byte, r *http.Request) ([]Event, hecResponse) {
+	defaults := hecDefaults(r)
+	var events []Event
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	for i := 0; ; i++ {
+		invalid := func(text string, code int) ([]Event, hecResponse) {
+			return nil, hecResponse{Text: text, Code: code, InvalidEventNumber: &i}
+		}
+		var he hecEvent
+		if err := decoder.Decode(&he); err == io.EOF {
+			return events, hecResponse{}
+		} else if err != nil {
+			return invalid("Invalid data format", hecInvalidFormat)
+		}
+		if len(he.Event) == 0 || string(he.Event) == "null" {
+			return invalid("Event field is required", hecEventRequired)
+		}
+		if string(he.Event) == `""` {
+			return invalid("Event field cannot be blank", hecEventBlank)
+		}
+		event := defaults
+		var eventBody interface{}
+		eventDecoder := json.NewDecoder(bytes.NewReader(he.Event))
+		eventDecoder.UseNumber()
+		if err := eventDecoder.Decode(&eventBody); err != nil {
+			return invalid("Invalid data format", hecInvalidFormat)
+		}
+		event.Body = eventBody
+		if len(he.Time) > 0 && string(he.Time) != "null" {
+			timestamp, nanos, err := parseHECTime(he.Time)
+			if err != nil {
+				return invalid("Invalid data format", hecInvalidFormat)
+			}
+			event.Timestamp, event.Nanos = &timestamp, &nanos
+		}
+		if he.Host != "" {
+			event.Host = &he.Host
+		}
+		if he.Source != "" {
+			event.Source = &he.Source
+		}
+		if he.Sourcetype != "" {
+			event.Sourcetype = &he.Sourcetype
+		}
+		if len(he.Fields) > 0 || he.Index != "" {
+			event.Attributes = make(map[string]interface{}, len(he.Fields)+1)
+			for k, v := range he.Fields {
+				event.Attributes[k] = v
+			}
+			if he.Index != "" {
+				event.Attributes["index"] = he.Index
+			}
+		}
+		events = append(events, event)
+	}
+}
+
+// parseHECTime parses a HEC time, epoch seconds with an optional fraction as a number or a string, into epoch
+// milliseconds and the nanoseconds of the millisecond
+func parseHECTime(raw json.RawMessage) (int64, int32, error) {
+	value := string(raw)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		return 0, 0, fmt.Errorf("ingest: invalid HEC time %s", raw)
+	}
+	t := time.Unix(0, int64(math.Round(seconds*1e6))*int64(time.Microsecond))
+	return t.UnixMilli(), int32(t.Nanosecond() % int(time.Millisecond)), nil
+}
+
+func writeHECResponse(w http.ResponseWriter, status int, resp hecResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hecRequest(t *testing.T, h http.Handler, path, token, body string, gzipped bool) (int, hecResponse) {
+	var reader *bytes.Buffer
+	if gzipped {
+		reader = &bytes.Buffer{}
+		gz := gzip.NewWriter(reader)
+		_, err := gz.Write([]byte(body))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+	} else {
+		reader = bytes.NewBufferString(body)
+	}
+	req := httptest.NewRequest(http.MethodPost, path, reader)
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	if gzipped {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var resp hecResponse
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec.Code, resp
+}
+
+func TestHECHandlerEvents(t *testing.T) {
+	rt := &eventsRT{}
+	sender, _, _ := newTestSender(t, rt, 100, 0, 1, nil)
+	h := NewHECHandler(sender, HECHandlerConfig{Tokens: []string{"t1"}})
+
+	body := `{"event":"a","time":1700000000.123456,"host":"h1","source":"s1","sourcetype":"st1","index":"main","fields":{"f":"v"}}
+{"event":{"n":12345678901234567890},"time":"1700000001"}{"event":["x"]}`
+	status, resp := hecRequest(t, h, "/services/collector/event?host=default", "Splunk t1", body, false)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, hecResponse{Text: "Success", Code: 0}, resp)
+	status, _ = hecRequest(t, h, "/hec/services/collector/raw?sourcetype=raw", "Splunk t1", "line 1\r\n\nline 2", true)
+	assert.Equal(t, http.StatusOK, status)
+	require.NoError(t, sender.Close(context.Background()))
+
+	events := rt.posted()
+	require.Len(t, events, 5)
+	assert.Equal(t, "a", events[0].Body)
+	assert.Equal(t, int64(1700000000123), *events[0].Timestamp)
+	assert.Equal(t, int32(456000), *events[0].Nanos)
+	assert.Equal(t, "h1", *events[0].Host)
+	assert.Equal(t, "s1", *events[0].Source)
+	assert.Equal(t, "st1", *events[0].Sourcetype)
+	assert.Equal(t, map[string]interface{}{"f": "v", "index": "main"}, events[0].Attributes)
+
+	body1, err := json.Marshal(events[1].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"n":12345678901234567890}`, string(body1), "numbers are not rounded")
+	assert.Equal(t, int64(1700000001000), *events[1].Timestamp)
+	assert.Equal(t, "default", *events[1].Host, "the query parameters are the defaults")
+	assert.Nil(t, events[2].Timestamp)
+
+	assert.Equal(t, "line 1", events[3].Body)
+	assert.Equal(t, "line 2", events[4].Body)
+	assert.Equal(t, "raw", *events[4].Sourcetype)
+}
+
+func TestHECHandlerErrors(t *testing.T) {
+	rt := &eventsRT{}
+	sender, _, _ := newTestSender(t, rt, 100, 0, 1, nil)
+	h := NewHECHandler(sender, HECHandlerConfig{Tokens: []string{"t1"}, MaxBodyBytes: 64})
+	path := "/services/collector"
+	two := 2
+
+	for _, tc := range []struct {
+		name, path, token, body string
+		status                  int
+		resp                    hecResponse
+	}{
+		{name: "no token", path: path, body: `{"event":"a"}`, status: 401, resp: hecResponse{Text: "Token is required", Code: 2}},
+		{name: "bad scheme", path: path, token: "Bearer t1", body: `{"event":"a"}`, status: 401, resp: hecResponse{Text: "Invalid authorization", Code: 3}},
+		{name: "bad token", path: path, token: "Splunk t2", body: `{"event":"a"}`, status: 403, resp: hecResponse{Text: "Invalid token", Code: 4}},
+		{name: "no data", path: path, token: "Splunk t1", body: "", status: 400, resp: hecResponse{Text: "No data", Code: 5}},
+		{name: "no event", path: path, token: "Splunk t1", body: `{"event":"a"}{"event":"b"}{"host":"h"}`, status: 400, resp: hecResponse{Text: "Event field is required", Code: 12, InvalidEventNumber: &two}},
+		{name: "blank event", path: path, token: "Splunk t1", body: `{"event":""}`, status: 400, resp: hecResponse{Text: "Event field cannot be blank", Code: 13, InvalidEventNumber: new(int)}},
+		{name: "bad time", path: path, token: "Splunk t1", body: `{"event":"a","time":"now"}`, status: 400, resp: hecResponse{Text: "Invalid data format", Code: 6, InvalidEventNumber: new(int)}},
+		{name: "bad json", path: path, token: "Splunk t1", body: `{"event":`, status: 400, resp: hecResponse{Text: "Invalid data format", Code: 6, InvalidEventNumber: new(int)}},
+		{name: "too large", path: path, token: "Splunk t1", body: strings.Repeat(`{"event":"a"}`, 10), status: 413},
+		{name: "health", path: "/services/collector/health", status: 200, resp: hecResponse{Text: "HEC is healthy", Code: 17}},
+		{name: "not found", path: "/services/other", token: "Splunk t1", status: 404},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, resp := hecRequest(t, h, tc.path, tc.token, tc.body, false)
+			assert.Equal(t, tc.status, status)
+			assert.Equal(t, tc.resp, resp)
+		})
+	}
+
+	// Tokens can be rotated
+	h.SetTokens([]string{"t2"})
+	status, _ := hecRequest(t, h, path, "Splunk t2", `{"event":"a"}`, false)
+	assert.Equal(t, http.StatusOK, status)
+
+	// Once the sender is closed, the handler is busy
+	require.NoError(t, sender.Close(context.Background()))
+	status, resp := hecRequest(t, h, path, "Splunk t2", `{"event":"a"}`, false)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, 9, resp.Code)
+	assert.Len(t, rt.posted(), 1, "events of invalid requests are not sent")
+}
+
+func TestHECHandlerHealth(t *testing.T) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: &eventsRT{}})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSender(2, 1000, 0)
+	require.NoError(t, err)
+	h := NewHECHandler(sender, HECHandlerConfig{})
+	busy := hecResponse{Text: "Server is busy", Code: 9}
+
+	status, resp := hecRequest(t, h, "/services/collector/health", "", "", false)
+	assert.Equal(t, http.StatusServiceUnavailable, status, "the sender isn't running yet")
+	assert.Equal(t, busy, resp)
+
+	sender.Run()
+	status, resp = hecRequest(t, h, "/services/collector/health/1.0", "", "", false)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, hecResponse{Text: "HEC is healthy", Code: 17}, resp)
+
+	require.NoError(t, sender.Close(context.Background()))
+	status, resp = hecRequest(t, h, "/services/collector/health", "", "", false)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, busy, resp)
+}
+
+func TestHECHandlerPartialRequests(t *testing.T) {
+	rt := &eventsRT{}
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSender(2, 1000, 0)
+	require.NoError(t, err)
+	sender.SetValidator(NewValidator(DefaultLimits, RejectOversize))
+	h := NewHECHandler(sender, HECHandlerConfig{Tokens: []string{"t1"}})
+	// The sender accepts events without sending them, its queue holds 2 events
+	sender.running = true
+
+	// No event is added if any is rejected by the validator of the sender
+	one := 1
+	status, resp := hecRequest(t, h, "/services/collector", "Splunk t1", `{"event":"a"}{"event":"b","fields":{"_f":1}}`, false)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, hecResponse{Text: "Invalid data format", Code: 6, InvalidEventNumber: &one}, resp)
+	assert.Empty(t, sender.items)
+
+	// The first event which wasn't accepted is reported
+	req := httptest.NewRequest(http.MethodPost, "/services/collector", strings.NewReader(`{"event":"a"}{"event":"b"}{"event":"c"}`))
+	req.Header.Set("Authorization", "Splunk t1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req.WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{"text":"Server is busy","code":9,"invalid-event-number":2}`, rec.Body.String())
+	assert.Len(t, sender.items, 2)
+}
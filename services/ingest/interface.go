@@ -19,8 +19,13 @@
 package ingest
 
 import (
+	"context"
 	"io"
+	"iter"
 	"net/http"
+	"time"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
 )
 
 // Servicer represents the interface for implementing all endpoints for this service
@@ -34,7 +39,7 @@ type Servicer interface {
 	   		batchSize: maximum number of events to reach before sending the batch, default maximum is 500
 	   		interval: milliseconds to wait before sending the batch if other conditions have not been met
 	   		dataSize: bytes that the overall payload should not exceed before sending, default maximum is 1040000 ~1MiB
-	   		maxErrorsAllowed: number of errors after which the BatchEventsSender will stop
+	   		maxErrorsAllowed: number of batches failing to be sent after which the BatchEventsSender will stop
 	*/
 	NewBatchEventsSenderWithMaxAllowedError(batchSize int, interval int64, dataSize int, maxErrorsAllowed int) (*BatchEventsSender, error)
 	/*
@@ -46,6 +51,23 @@ type Servicer interface {
 	   		payLoadSize: bytes that the overall payload should not exceed before sending, default maximum is 1040000 ~1MiB
 	*/
 	NewBatchEventsSender(batchSize int, interval int64, payLoadSize int) (*BatchEventsSender, error)
+	/*
+	   	NewBatchMetricsSender initializes a BatchMetricsSender to merge and aggregate metric events and send them as a
+	           single batched request when a maximum number of metrics, time interval, or maximum payload size is reached.
+	   	Parameters:
+	   		batchSize: maximum number of metrics to reach before sending the batch, default maximum is 500
+	   		interval: milliseconds over which metrics are aggregated before sending the batch if other conditions have not been met
+	   		dataSize: bytes that the overall payload should not exceed before sending, default maximum is 1040000 ~1MiB
+	   		maxErrorsAllowed: number of batches failing to be sent after which the BatchMetricsSender will stop
+	*/
+	NewBatchMetricsSender(batchSize int, interval int64, dataSize int, maxErrorsAllowed int) (*BatchMetricsSender, error)
+	/*
+		NewTokenManager initializes a TokenManager to rotate HEC collector tokens and reconcile them with a declared set of tokens.
+		Parameters:
+			overlap: how long both tokens stay valid during a rotation once onRotate returned, before the old token is deleted
+			onRotate: an optional function called during a rotation once the replacement token is created
+	*/
+	NewTokenManager(overlap time.Duration, onRotate TokenRotationHook) *TokenManager
 	/*
 		UploadFilesStream - Upload stream of io.Reader.
 		Parameters:
@@ -53,7 +75,45 @@ type Servicer interface {
 			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
 	*/
 	UploadFilesStream(stream io.Reader, resp ...*http.Response) error
+	/*
+		UploadFilesStreamWithContext - Upload stream of io.Reader. The multipart request body is streamed as it's sent,
+		only the chunk being uploaded is held in memory so that it can be retried. Streams larger than the file limit are
+		uploaded in several chunks, in order, see UploadOptions.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			stream
+			opts: the upload options, the defaults are used if nil
+			resp: an optional pointer to a http.Response to be populated by this method, with the response of the last chunk uploaded. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UploadFilesStreamWithContext(ctx context.Context, stream io.Reader, opts *UploadOptions, resp ...*http.Response) error
+	/*
+		PostEventsWithIDs - Sends events, after assigning an ID to the ones which don't have one.
+		Parameters:
+			events: the events, their IDs are assigned in place so that sending them again doesn't duplicate them
+			strategy: the strategy computing the IDs
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PostEventsWithIDs(events []Event, strategy IDStrategy, resp ...*http.Response) (*HttpResponse, error)
+	/*
+		PostEventsWithIDsWithContext - Sends events, after assigning an ID to the ones which don't have one.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			events: the events, their IDs are assigned in place so that sending them again doesn't duplicate them
+			strategy: the strategy computing the IDs
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PostEventsWithIDsWithContext(ctx context.Context, events []Event, strategy IDStrategy, resp ...*http.Response) (*HttpResponse, error)
+	/*
+		AllCollectorTokens returns an iterator over every HEC collector token, requesting pages as they are needed.
+		Parameters:
+			ctx: the context of the requests, used for cancellation, deadlines and request-scoped values
+			query: query.Limit is used as the page size and query.Offset as the offset of the first token, nil for the defaults
+			opts: options overriding the page size and the number of pages requested at the same time
+	*/
+	AllCollectorTokens(ctx context.Context, query *ListCollectorTokensQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[HecTokenAccessResponse, error]
 
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
@@ -0,0 +1,100 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		DeleteAllCollectorTokensWithContext - Delete All dsphec tokens for a given tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteAllCollectorTokensWithContext(ctx context.Context, resp ...*http.Response) (*map[string]interface{}, error)
+	/*
+		DeleteCollectorTokenWithContext - Delete dsphec token by name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			tokenName
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteCollectorTokenWithContext(ctx context.Context, tokenName string, resp ...*http.Response) (*map[string]interface{}, error)
+	/*
+		GetCollectorTokenWithContext - Get the metadata of a dsphec token by name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			tokenName
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetCollectorTokenWithContext(ctx context.Context, tokenName string, resp ...*http.Response) (*HecTokenAccessResponse, error)
+	/*
+		ListCollectorTokensWithContext - List dsphec tokens for a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListCollectorTokensWithContext(ctx context.Context, query *ListCollectorTokensQueryParams, resp ...*http.Response) ([]HecTokenAccessResponse, error)
+	/*
+		PostCollectorTokensWithContext - Creates dsphec tokens.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			hecTokenCreateRequest: The API request schema for the token.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PostCollectorTokensWithContext(ctx context.Context, hecTokenCreateRequest HecTokenCreateRequest, resp ...*http.Response) (*HecTokenCreateResponse, error)
+	/*
+		PostEventsWithContext - Sends events.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			event
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PostEventsWithContext(ctx context.Context, event []Event, resp ...*http.Response) (*HttpResponse, error)
+	/*
+		PostMetricsWithContext - Sends metric events.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			metricEvent
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PostMetricsWithContext(ctx context.Context, metricEvent []MetricEvent, resp ...*http.Response) (*HttpResponse, error)
+	/*
+		PutCollectorTokenWithContext - Update the metadata of a dsphec token by name.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			tokenName
+			hecTokenUpdateRequest: The API request schema for the token.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PutCollectorTokenWithContext(ctx context.Context, tokenName string, hecTokenUpdateRequest HecTokenUpdateRequest, resp ...*http.Response) (*HecTokenAccessResponse, error)
+	/*
+	   UploadFilesWithContext - Upload a CSV or text file that contains events. Files larger than the file limit of 1MB are
+	   uploaded in chunks split at line boundaries, the header of .csv files being repeated in every chunk, see
+	   UploadFilesStreamWithContext. UploadFiles, whose implementation is generated, sends the file in a single request instead.
+	   Parameters:
+	       ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	       filename
+	       resp: an optional pointer to a http.Response to be populated by this method, with the response of the last chunk uploaded. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UploadFilesWithContext(ctx context.Context, filename string, resp ...*http.Response) error
+}
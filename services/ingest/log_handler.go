@@ -0,0 +1,264 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// LogHandlerOptions configures a LogHandler
+type LogHandlerOptions struct {
+	// Level is the minimum level of the records sent, slog.LevelInfo if nil
+	Level slog.Leveler
+	// Host, Source and Sourcetype are assigned to every event, if set
+	Host       string
+	Source     string
+	Sourcetype string
+	// Attributes are index-time fields added to the attributes of every event
+	Attributes map[string]interface{}
+	// AddSource adds the file, line and function which emitted the record to the body of the events
+	AddSource bool
+}
+
+// LogHandlerStats are the counts of records handled by a LogHandler
+type LogHandlerStats struct {
+	// Sent is the number of records added to the BatchEventsSender
+	Sent uint64
+	// Dropped is the number of records dropped because the queue of the BatchEventsSender was full
+	Dropped uint64
+	// Failed is the number of records Handle returned an error for: records which can't be marshaled, rejected
+	// by the Validator of the sender, or handled while the sender isn't running
+	Failed uint64
+}
+
+// LogHandler is a slog.Handler shipping log records to the ingest service through a BatchEventsSender:
+//
+//	sender, err := client.IngestService.NewBatchEventsSender(100, 1000, 0)
+//	...
+//	sender.Run()
+//	defer sender.Close(ctx)
+//	logger := slog.New(ingest.NewLogHandler(sender, &ingest.LogHandlerOptions{Source: "checkout"}))
+//
+// Each record is an Event whose body is a JSON object holding the level, the message and the attributes of the
+// record, groups being nested objects, and whose attributes hold the level for indexing. The timestamp of the
+// event is the time of the record.
+//
+// Handle doesn't wait for room in the queue of the sender: records are dropped, and counted, while it's full. It
+// still writes the records to the spool of the sender, if any, before returning. The sender must not use a client
+// logging to this handler, its own request logs would be shipped along with the application's.
+type LogHandler struct {
+	sender *BatchEventsSender
+	opts   LogHandlerOptions
+	// scopes are the groups and attributes added with WithGroup and WithAttrs, outermost first
+	scopes []logScope
+	stats  *logHandlerStats
+}
+
+// logScope is a group opened by WithGroup, or the attributes added by WithAttrs if group is empty
+type logScope struct {
+	group string
+	attrs []slog.Attr
+}
+
+// logGroup holds the attributes of a group, it's a distinct type so that only groups are pruned when empty
+type logGroup map[string]interface{}
+
+type logHandlerStats struct {
+	sent    atomic.Uint64
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// NewLogHandler creates a LogHandler adding the records to sender, the default options are used if opts is nil
+func NewLogHandler(sender *BatchEventsSender, opts *LogHandlerOptions) *LogHandler {
+	h := &LogHandler{sender: sender, stats: &logHandlerStats{}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	return h
+}
+
+// Stats returns the counts of records handled so far, shared by the handlers derived with WithAttrs and WithGroup
+func (h *LogHandler) Stats() LogHandlerStats {
+	return LogHandlerStats{Sent: h.stats.sent.Load(), Dropped: h.stats.dropped.Load(), Failed: h.stats.failed.Load()}
+}
+
+// Enabled implements slog.Handler
+func (h *LogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle implements slog.Handler, it converts r into an Event and adds it to the sender without waiting for room
+// in its queue. The records dropped because the queue is full are counted, Handle returns the error of the other
+// records which can't be sent.
+func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	event, err := h.event(r)
+	if err == nil {
+		err = h.sender.addEvent(ctx, event, false)
+	}
+	switch {
+	case err == nil:
+		h.stats.sent.Add(1)
+	case errors.Is(err, errQueueFull):
+		h.stats.dropped.Add(1)
+		return nil
+	default:
+		h.stats.failed.Add(1)
+	}
+	return err
+}
+
+// WithAttrs implements slog.Handler
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.with(logScope{attrs: attrs})
+}
+
+// WithGroup implements slog.Handler
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.with(logScope{group: name})
+}
+
+func (h *LogHandler) with(scope logScope) *LogHandler {
+	h2 := *h
+	h2.scopes = append(h.scopes[:len(h.scopes):len(h.scopes)], scope)
+	return &h2
+}
+
+func (h *LogHandler) event(r slog.Record) (Event, error) {
+	body := logGroup{}
+	current := body
+	for _, scope := range h.scopes {
+		if scope.group != "" {
+			group := logGroup{}
+			current[scope.group] = group
+			current = group
+			continue
+		}
+		for _, attr := range scope.attrs {
+			addLogAttr(current, attr)
+		}
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		addLogAttr(current, attr)
+		return true
+	})
+	pruneEmptyGroups(body)
+	if h.opts.AddSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		body[slog.SourceKey] = map[string]interface{}{"file": frame.File, "line": frame.Line, "function": frame.Function}
+	}
+	body[slog.LevelKey] = r.Level.String()
+	body[slog.MessageKey] = r.Message
+
+	attributes := map[string]interface{}{slog.LevelKey: r.Level.String()}
+	for k, v := range h.opts.Attributes {
+		attributes[k] = v
+	}
+	event := Event{Body: body, Attributes: attributes}
+	if !r.Time.IsZero() {
+		timestamp := r.Time.UnixMilli()
+		nanos := int32(r.Time.Nanosecond() % int(time.Millisecond))
+		event.Timestamp, event.Nanos = &timestamp, &nanos
+	}
+	if h.opts.Host != "" {
+		event.Host = &h.opts.Host
+	}
+	if h.opts.Source != "" {
+		event.Source = &h.opts.Source
+	}
+	if h.opts.Sourcetype != "" {
+		event.Sourcetype = &h.opts.Sourcetype
+	}
+	if _, err := json.Marshal(event.Body); err != nil {
+		return Event{}, fmt.Errorf("ingest: can't send log record %q: %w", r.Message, err)
+	}
+	return event, nil
+}
+
+// addLogAttr adds attr to m, following the rules of slog.Handler: empty attributes are ignored and the attributes
+// of groups without a key are inlined
+func addLogAttr(m logGroup, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		group := m
+		if attr.Key != "" {
+			group = logGroup{}
+			m[attr.Key] = group
+		}
+		for _, a := range attr.Value.Group() {
+			addLogAttr(group, a)
+		}
+		return
+	}
+	m[attr.Key] = logValue(attr.Value)
+}
+
+// logValue converts v to a value marshaled to JSON. Unlike slog.JSONHandler, which writes durations as integer
+// nanoseconds and marshals fmt.Stringers as is, durations are written as strings such as "1.5s" and the values
+// implementing fmt.Stringer, but neither json.Marshaler nor encoding.TextMarshaler, as their String.
+func logValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindAny:
+		switch a := v.Any().(type) {
+		case error:
+			return a.Error()
+		case json.Marshaler:
+			return a
+		case encoding.TextMarshaler:
+			return a
+		case fmt.Stringer:
+			return a.String()
+		}
+	}
+	return v.Any()
+}
+
+// pruneEmptyGroups removes the groups without attributes, at any depth
+func pruneEmptyGroups(m logGroup) {
+	for k, v := range m {
+		if group, ok := v.(logGroup); ok {
+			pruneEmptyGroups(group)
+			if len(group) == 0 {
+				delete(m, k)
+			}
+		}
+	}
+}
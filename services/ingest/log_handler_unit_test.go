@@ -0,0 +1,158 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"sync"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventsRT records the events posted to it
+type eventsRT struct {
+	mux    sync.Mutex
+	events []Event
+}
+
+func (rt *eventsRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	var events []Event
+	if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+		return nil, err
+	}
+	rt.mux.Lock()
+	rt.events = append(rt.events, events...)
+	rt.mux.Unlock()
+	body := ioutil.NopCloser(bytes.NewReader([]byte(`{"code":"SUCCESS","message":"ok"}`)))
+	return &http.Response{StatusCode: 200, Status: "OK", Body: body, Header: http.Header{}}, nil
+}
+
+func (rt *eventsRT) posted() []Event {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	return append([]Event(nil), rt.events...)
+}
+
+func TestLogHandlerConformance(t *testing.T) {
+	rt := &eventsRT{}
+	sender, _, _ := newTestSender(t, rt, 100, 0, 1, nil)
+	handler := NewLogHandler(sender, &LogHandlerOptions{Level: slog.LevelDebug})
+	results := func() []map[string]any {
+		require.NoError(t, sender.Flush(context.Background()))
+		var results []map[string]any
+		for _, event := range rt.posted() {
+			body := event.Body.(map[string]any)
+			if event.Timestamp != nil {
+				body[slog.TimeKey] = time.UnixMilli(*event.Timestamp)
+			}
+			results = append(results, body)
+		}
+		return results
+	}
+	require.NoError(t, slogtest.TestHandler(handler, results))
+	require.NoError(t, sender.Close(context.Background()))
+}
+
+func TestLogHandlerEvents(t *testing.T) {
+	rt := &eventsRT{}
+	sender, _, _ := newTestSender(t, rt, 100, 0, 1, nil)
+	handler := NewLogHandler(sender, &LogHandlerOptions{
+		Host:       "web-1",
+		Source:     "checkout",
+		Sourcetype: "json",
+		Attributes: map[string]interface{}{"env": "prod"},
+		AddSource:  true,
+	})
+	logger := slog.New(handler).With("request", "r1").WithGroup("http")
+	logger.Debug("filtered out")
+	logger.Warn("slow", "duration", 1500*time.Millisecond, slog.Group("route", "path", "/cart"), slog.Group("empty"))
+	require.NoError(t, sender.Close(context.Background()))
+
+	events := rt.posted()
+	require.Len(t, events, 1)
+	event := events[0]
+	assert.Equal(t, "web-1", *event.Host)
+	assert.Equal(t, "checkout", *event.Source)
+	assert.Equal(t, "json", *event.Sourcetype)
+	assert.Equal(t, map[string]interface{}{"level": "WARN", "env": "prod"}, event.Attributes)
+	require.NotNil(t, event.Timestamp)
+	assert.WithinDuration(t, time.Now(), time.UnixMilli(*event.Timestamp), time.Minute)
+
+	body := event.Body.(map[string]interface{})
+	assert.Equal(t, "WARN", body["level"])
+	assert.Equal(t, "slow", body["msg"])
+	assert.Equal(t, "r1", body["request"])
+	assert.Equal(t, map[string]interface{}{"duration": "1.5s", "route": map[string]interface{}{"path": "/cart"}}, body["http"])
+	source := body["source"].(map[string]interface{})
+	assert.Contains(t, source["file"], "log_handler_unit_test.go")
+	assert.Equal(t, LogHandlerStats{Sent: 1}, handler.Stats())
+}
+
+func TestLogHandlerDrops(t *testing.T) {
+	rt := &ingestRT{gate: make(chan struct{})}
+	sender, _, _ := newTestSender(t, rt, 1, 0, 1, nil)
+	handler := NewLogHandler(sender, nil)
+	logger := slog.New(handler)
+
+	// The sender is stuck, records are dropped once its queue is full rather than blocking
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			logger.Info("record", "i", i)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging blocked")
+	}
+	stats := handler.Stats()
+	assert.Equal(t, uint64(20), stats.Sent+stats.Dropped)
+	assert.NotZero(t, stats.Dropped)
+
+	// Records which can't be marshaled or are handled once the sender is closed are reported, not dropped
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "bad", 0)
+	record.AddAttrs(slog.Any("ch", make(chan int)))
+	assert.Error(t, handler.Handle(context.Background(), record))
+	close(rt.gate)
+	require.NoError(t, sender.Close(context.Background()))
+	assert.Error(t, handler.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "after close", 0)))
+	assert.Equal(t, LogHandlerStats{Sent: stats.Sent, Dropped: stats.Dropped, Failed: 2}, handler.Stats())
+}
+
+func TestLogHandlerNotRunning(t *testing.T) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: &ingestRT{}})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSender(10, 1000, 0)
+	require.NoError(t, err)
+	handler := NewLogHandler(sender, nil)
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "early", 0)
+	assert.Equal(t, ErrBatchEventsSenderNotRunning, handler.Handle(context.Background(), record))
+	assert.Equal(t, LogHandlerStats{Failed: 1}, handler.Stats())
+}
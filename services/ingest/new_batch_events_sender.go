@@ -6,12 +6,8 @@
 package ingest
 
 import (
-	"sync"
-	"time"
-
 	"errors"
-
-	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/util"
+	"time"
 )
 
 /*
@@ -22,7 +18,7 @@ import (
 			batchSize: maximum number of events to reach before sending the batch, default maximum is 500
 			interval: milliseconds to wait before sending the batch if other conditions have not been met
 			dataSize: bytes that the overall payload should not exceed before sending, default maximum is 1040000 ~1MiB
-			maxErrorsAllowed: number of errors after which the BatchEventsSender will stop
+			maxErrorsAllowed: number of batches failing to be sent after which the BatchEventsSender will stop
 */
 func (s *Service) NewBatchEventsSenderWithMaxAllowedError(batchSize int, interval int64, dataSize int, maxErrorsAllowed int) (*BatchEventsSender, error) {
 	// Rather than return a super general error for both it will block on batchSize first
@@ -39,30 +35,21 @@ func (s *Service) NewBatchEventsSenderWithMaxAllowedError(batchSize int, interva
 		dataSize = payLoadSize
 	}
 
-	if maxErrorsAllowed < 0 {
+	if maxErrorsAllowed < 1 {
 		maxErrorsAllowed = 1
 	}
 
-	eventsChan := make(chan Event, batchSize)
-	eventsQueue := make([]Event, 0, batchSize)
-	quit := make(chan struct{}, 1)
-	ticker := util.NewTicker(time.Duration(interval) * time.Millisecond)
-	var wg sync.WaitGroup
-	errorChan := make(chan struct{}, maxErrorsAllowed)
-
 	batchEventsSender := &BatchEventsSender{
-		BatchSize:      batchSize,
-		PayLoadBytes:   dataSize,
-		EventsChan:     eventsChan,
-		EventsQueue:    eventsQueue,
-		EventService:   s,
-		QuitChan:       quit,
-		IngestTicker:   ticker,
-		WaitGroup:      &wg,
-		ErrorChan:      errorChan,
-		IsRunning:      false,
-		chanWaitMillis: 300,
-		callbackFunc:   nil,
+		batchSize:    batchSize,
+		payLoadBytes: dataSize,
+		EventService: s,
+		batcher: newBatcher[queuedEvent, batch, DeliveryResult](batchSize, time.Duration(interval)*time.Millisecond,
+			maxErrorsAllowed, ErrBatchEventsSenderNotRunning, ErrBatchEventsSenderClosed),
+	}
+	batchEventsSender.acc = &eventsAccumulator{batchSize: batchSize, payLoadBytes: dataSize, read: batchEventsSender.readEvent}
+	batchEventsSender.post = batchEventsSender.postBatch
+	batchEventsSender.result = func(bt batch, err error) DeliveryResult {
+		return DeliveryResult{Events: bt.events, Err: err}
 	}
 
 	return batchEventsSender, nil
@@ -0,0 +1,54 @@
+/*
+* Splunk Ingest Service
+*
+ */
+
+package ingest
+
+import (
+	"errors"
+	"time"
+)
+
+/*
+		NewBatchMetricsSender initializes a BatchMetricsSender to merge and aggregate metric events and send them as a
+	        single batched request when a maximum number of metrics, time interval, or maximum payload size is reached.
+		Parameters:
+			batchSize: maximum number of metrics to reach before sending the batch, default maximum is 500
+			interval: milliseconds over which metrics are aggregated before sending the batch if other conditions have not been met
+			dataSize: bytes that the overall payload should not exceed before sending, default maximum is 1040000 ~1MiB
+			maxErrorsAllowed: number of batches failing to be sent after which the BatchMetricsSender will stop
+*/
+func (s *Service) NewBatchMetricsSender(batchSize int, interval int64, dataSize int, maxErrorsAllowed int) (*BatchMetricsSender, error) {
+	if batchSize == 0 {
+		return nil, errors.New("batchSize cannot be 0")
+	}
+	if batchSize > eventCount {
+		batchSize = eventCount
+	}
+	if interval == 0 {
+		return nil, errors.New("interval cannot be 0")
+	}
+	if dataSize == 0 {
+		dataSize = payLoadSize
+	}
+
+	if maxErrorsAllowed < 1 {
+		maxErrorsAllowed = 1
+	}
+
+	batchMetricsSender := &BatchMetricsSender{
+		batchSize:     batchSize,
+		payLoadBytes:  dataSize,
+		MetricService: s,
+		batcher: newBatcher[MetricEvent, metricsBatch, MetricsDeliveryResult](batchSize, time.Duration(interval)*time.Millisecond,
+			maxErrorsAllowed, ErrBatchMetricsSenderNotRunning, ErrBatchMetricsSenderClosed),
+	}
+	batchMetricsSender.acc = &metricsAccumulator{batchSize: batchSize, payLoadBytes: dataSize, read: batchMetricsSender.readMetricEvent, agg: newMetricsAggregator()}
+	batchMetricsSender.post = batchMetricsSender.postBatch
+	batchMetricsSender.result = func(bt metricsBatch, err error) MetricsDeliveryResult {
+		return MetricsDeliveryResult{MetricEvents: bt.events, Err: err}
+	}
+
+	return batchMetricsSender, nil
+}
@@ -0,0 +1,438 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package otlp receives OpenTelemetry logs and metrics with the OTLP/HTTP protocol and forwards them to the ingest
+// service, it's kept apart from the ingest package so that only its users depend on the OTLP and protobuf modules.
+package otlp
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/ingest"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMaxBodyBytes is the maximum size of a request accepted by a Handler unless configured otherwise
+const DefaultMaxBodyBytes = 8 << 20
+
+const (
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+	// hostAttribute and serviceAttribute are the resource attributes used as the host and source of events
+	hostAttribute    = "host.name"
+	serviceAttribute = "service.name"
+)
+
+// HandlerConfig configures a Handler
+type HandlerConfig struct {
+	// MaxBodyBytes is the maximum size of a request body, once decompressed, DefaultMaxBodyBytes if zero
+	MaxBodyBytes int64
+}
+
+// Handler is an http.Handler receiving OpenTelemetry logs and metrics with the OTLP/HTTP protocol, encoded in
+// protobuf or JSON, and forwarding them to the ingest service:
+//
+//	/v1/logs accepts ExportLogsServiceRequest messages, the log records are added to the ingest.BatchEventsSender
+//	/v1/metrics accepts ExportMetricsServiceRequest messages, the data points are added to the ingest.BatchMetricsSender
+//
+// The paths are matched wherever the handler is mounted, and a path is not served if its sender is nil. See
+// LogsToEvents and MetricsToMetricEvents for how the OTLP data is mapped. Bodies compressed with
+// `Content-Encoding: gzip` are supported.
+//
+// The request fails with 503 Service Unavailable, which OTLP exporters retry, when the sender is closed or the
+// request is canceled while the queue of the sender is full. The records added before then are sent nonetheless,
+// they are duplicated if the request is retried.
+type Handler struct {
+	events       *ingest.BatchEventsSender
+	metrics      *ingest.BatchMetricsSender
+	maxBodyBytes int64
+}
+
+// NewHandler creates a Handler adding the log records received to events and the metrics to metrics,
+// either may be nil
+func NewHandler(events *ingest.BatchEventsSender, metrics *ingest.BatchMetricsSender, config HandlerConfig) *Handler {
+	h := &Handler{events: events, metrics: metrics, maxBodyBytes: config.MaxBodyBytes}
+	if h.maxBodyBytes <= 0 {
+		h.maxBodyBytes = DefaultMaxBodyBytes
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	var logs bool
+	switch {
+	case strings.HasSuffix(path, "/v1/logs") && h.events != nil:
+		logs = true
+	case strings.HasSuffix(path, "/v1/metrics") && h.metrics != nil:
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != protobufContentType && contentType != jsonContentType {
+		http.Error(w, http.StatusText(http.StatusUnsupportedMediaType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ingest.ReadRequestBody(w, r, h.maxBodyBytes)
+	if errors.Is(err, ingest.ErrBodyTooLarge) {
+		writeStatus(w, contentType, http.StatusRequestEntityTooLarge, codes.InvalidArgument, err)
+		return
+	}
+	if err != nil {
+		writeStatus(w, contentType, http.StatusBadRequest, codes.InvalidArgument, err)
+		return
+	}
+	var resp proto.Message
+	if logs {
+		resp, err = h.exportLogs(r, contentType, body)
+	} else {
+		resp, err = h.exportMetrics(r, contentType, body)
+	}
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		writeStatus(w, contentType, http.StatusBadRequest, codes.InvalidArgument, err)
+		return
+	}
+	if err != nil {
+		writeStatus(w, contentType, http.StatusServiceUnavailable, codes.Unavailable, err)
+		return
+	}
+	writeMessage(w, contentType, http.StatusOK, resp)
+}
+
+// decodeError is returned when the body of a request is not a valid OTLP message
+type decodeError struct {
+	err error
+}
+
+func (e *decodeError) Error() string {
+	return "otlp: invalid request: " + e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+func (h *Handler) exportLogs(r *http.Request, contentType string, body []byte) (proto.Message, error) {
+	req := &collogspb.ExportLogsServiceRequest{}
+	if err := unmarshal(contentType, body, req); err != nil {
+		return nil, err
+	}
+	for _, event := range LogsToEvents(req) {
+		if err := h.events.AddEvent(r.Context(), event); err != nil {
+			return nil, err
+		}
+	}
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (h *Handler) exportMetrics(r *http.Request, contentType string, body []byte) (proto.Message, error) {
+	req := &colmetricspb.ExportMetricsServiceRequest{}
+	if err := unmarshal(contentType, body, req); err != nil {
+		return nil, err
+	}
+	events, rejected := MetricsToMetricEvents(req)
+	for _, event := range events {
+		if err := h.metrics.AddMetricEvent(r.Context(), event); err != nil {
+			return nil, err
+		}
+	}
+	resp := &colmetricspb.ExportMetricsServiceResponse{}
+	if rejected > 0 {
+		resp.PartialSuccess = &colmetricspb.ExportMetricsPartialSuccess{
+			RejectedDataPoints: rejected,
+			ErrorMessage:       "only gauge and sum metrics are supported",
+		}
+	}
+	return resp, nil
+}
+
+func unmarshal(contentType string, body []byte, m proto.Message) error {
+	var err error
+	if contentType == jsonContentType {
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, m)
+	} else {
+		err = proto.Unmarshal(body, m)
+	}
+	if err != nil {
+		return &decodeError{err: err}
+	}
+	return nil
+}
+
+func writeMessage(w http.ResponseWriter, contentType string, statusCode int, m proto.Message) {
+	var data []byte
+	if contentType == jsonContentType {
+		data, _ = protojson.Marshal(m)
+	} else {
+		data, _ = proto.Marshal(m)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	w.Write(data)
+}
+
+// writeStatus writes the google.rpc.Status of a failed OTLP request
+func writeStatus(w http.ResponseWriter, contentType string, statusCode int, code codes.Code, err error) {
+	if statusCode == http.StatusServiceUnavailable {
+		w.Header().Set("Retry-After", "1")
+	}
+	writeMessage(w, contentType, statusCode, &status.Status{Code: int32(code), Message: err.Error()})
+}
+
+// LogsToEvents converts the log records of req into events:
+//
+//	the host.name and service.name resource attributes are the host and source of the events
+//	the body of a record is the body of its event, key-value lists being JSON objects
+//	the attributes of a record, the other resource attributes, the severity and the trace context are the
+//	attributes of its event, the attributes of the record taking precedence
+//	the time of a record, or its observed time if unset, is the timestamp and nanos of its event
+func LogsToEvents(req *collogspb.ExportLogsServiceRequest) []ingest.Event {
+	var events []ingest.Event
+	for _, rl := range req.GetResourceLogs() {
+		resource := newResourceFields(rl.GetResource())
+		for _, sl := range rl.GetScopeLogs() {
+			for _, record := range sl.GetLogRecords() {
+				event := ingest.Event{Host: resource.host, Source: resource.source, Body: anyValue(record.GetBody())}
+				if event.Body == nil {
+					event.Body = ""
+				}
+				attributes := make(map[string]interface{}, len(resource.attributes)+len(record.GetAttributes())+4)
+				for k, v := range resource.attributes {
+					attributes[k] = v
+				}
+				if record.GetSeverityText() != "" {
+					attributes["severity"] = record.GetSeverityText()
+				}
+				if record.GetSeverityNumber() != 0 {
+					attributes["severity_number"] = int32(record.GetSeverityNumber())
+				}
+				if id := formatID(record.GetTraceId(), 16); id != "" {
+					attributes["trace_id"] = id
+				}
+				if id := formatID(record.GetSpanId(), 8); id != "" {
+					attributes["span_id"] = id
+				}
+				for _, kv := range record.GetAttributes() {
+					attributes[kv.GetKey()] = anyValue(kv.GetValue())
+				}
+				if len(attributes) > 0 {
+					event.Attributes = attributes
+				}
+				unixNano := record.GetTimeUnixNano()
+				if unixNano == 0 {
+					unixNano = record.GetObservedTimeUnixNano()
+				}
+				if unixNano != 0 {
+					event.Timestamp, event.Nanos = unixNanoTimestamp(unixNano)
+				}
+				events = append(events, event)
+			}
+		}
+	}
+	return events
+}
+
+// MetricsToMetricEvents converts the gauge and sum data points of req into metric events, one per data point,
+// and returns the number of data points of other metric types, which are rejected:
+//
+//	the host.name and service.name resource attributes are the host and source of the events
+//	the other resource attributes are the default dimensions of the events
+//	the attributes of a data point are the dimensions of its metric
+//	the sums with delta temporality are counters, the other sums and the gauges are gauges since cumulative
+//	values must not be added up
+//	the time of a data point is the timestamp and nanos of its event
+//
+// Data points flagged without a recorded value are skipped.
+func MetricsToMetricEvents(req *colmetricspb.ExportMetricsServiceRequest) ([]ingest.MetricEvent, int64) {
+	var events []ingest.MetricEvent
+	var rejected int64
+	for _, rm := range req.GetResourceMetrics() {
+		resource := newResourceFields(rm.GetResource())
+		var attributes *ingest.MetricAttribute
+		if len(resource.attributes) > 0 {
+			attributes = &ingest.MetricAttribute{DefaultDimensions: make(map[string]string, len(resource.attributes))}
+			for k, v := range resource.attributes {
+				attributes.DefaultDimensions[k] = dimension(v)
+			}
+		}
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				var points []*metricspb.NumberDataPoint
+				typ := ingest.GaugeMetricType
+				switch data := m.GetData().(type) {
+				case *metricspb.Metric_Gauge:
+					points = data.Gauge.GetDataPoints()
+				case *metricspb.Metric_Sum:
+					points = data.Sum.GetDataPoints()
+					if data.Sum.GetAggregationTemporality() == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA {
+						typ = ingest.CounterMetricType
+					}
+				case *metricspb.Metric_Histogram:
+					rejected += int64(len(data.Histogram.GetDataPoints()))
+				case *metricspb.Metric_ExponentialHistogram:
+					rejected += int64(len(data.ExponentialHistogram.GetDataPoints()))
+				case *metricspb.Metric_Summary:
+					rejected += int64(len(data.Summary.GetDataPoints()))
+				}
+				for _, point := range points {
+					if point.GetFlags()&uint32(metricspb.DataPointFlags_DATA_POINT_FLAGS_NO_RECORDED_VALUE_MASK) != 0 {
+						continue
+					}
+					value := point.GetAsDouble()
+					if v, ok := point.GetValue().(*metricspb.NumberDataPoint_AsInt); ok {
+						value = float64(v.AsInt)
+					}
+					metric := ingest.Metric{Name: m.GetName(), Type: proto.String(typ), Value: &value}
+					if m.GetUnit() != "" {
+						metric.Unit = proto.String(m.GetUnit())
+					}
+					if len(point.GetAttributes()) > 0 {
+						metric.Dimensions = make(map[string]string, len(point.GetAttributes()))
+						for _, kv := range point.GetAttributes() {
+							metric.Dimensions[kv.GetKey()] = dimension(anyValue(kv.GetValue()))
+						}
+					}
+					event := ingest.MetricEvent{Body: []ingest.Metric{metric}, Attributes: attributes, Host: resource.host, Source: resource.source}
+					if point.GetTimeUnixNano() != 0 {
+						event.Timestamp, event.Nanos = unixNanoTimestamp(point.GetTimeUnixNano())
+					}
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	return events, rejected
+}
+
+// resourceFields is an OTLP resource split into the host and source of the events and their other attributes
+type resourceFields struct {
+	host       *string
+	source     *string
+	attributes map[string]interface{}
+}
+
+func newResourceFields(resource *resourcepb.Resource) resourceFields {
+	var r resourceFields
+	for _, kv := range resource.GetAttributes() {
+		value := anyValue(kv.GetValue())
+		if s, ok := value.(string); ok && s != "" {
+			switch kv.GetKey() {
+			case hostAttribute:
+				r.host = &s
+				continue
+			case serviceAttribute:
+				r.source = &s
+				continue
+			}
+		}
+		if r.attributes == nil {
+			r.attributes = make(map[string]interface{})
+		}
+		r.attributes[kv.GetKey()] = value
+	}
+	return r
+}
+
+// anyValue converts v to a value marshaled to JSON, nil if v is empty
+func anyValue(v *commonpb.AnyValue) interface{} {
+	switch value := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return value.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return value.BoolValue
+	case *commonpb.AnyValue_IntValue:
+		return value.IntValue
+	case *commonpb.AnyValue_DoubleValue:
+		return value.DoubleValue
+	case *commonpb.AnyValue_BytesValue:
+		return value.BytesValue
+	case *commonpb.AnyValue_ArrayValue:
+		values := make([]interface{}, 0, len(value.ArrayValue.GetValues()))
+		for _, item := range value.ArrayValue.GetValues() {
+			values = append(values, anyValue(item))
+		}
+		return values
+	case *commonpb.AnyValue_KvlistValue:
+		values := make(map[string]interface{}, len(value.KvlistValue.GetValues()))
+		for _, kv := range value.KvlistValue.GetValues() {
+			values[kv.GetKey()] = anyValue(kv.GetValue())
+		}
+		return values
+	}
+	return nil
+}
+
+// dimension formats a value returned by anyValue as a dimension, strings as is and other values in JSON
+func dimension(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return value
+	case int64:
+		return strconv.FormatInt(value, 10)
+	case nil:
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// formatID formats a trace or span ID of size bytes in hex. OTLP/JSON encodes IDs in hex rather than base64, the IDs
+// of JSON requests are then decoded by protojson into 1.5 times as many bytes, which are encoded back.
+func formatID(id []byte, size int) string {
+	switch len(id) {
+	case size:
+		return hex.EncodeToString(id)
+	case size * 3 / 2:
+		return base64.StdEncoding.EncodeToString(id)
+	}
+	return ""
+}
+
+// unixNanoTimestamp converts epoch nanoseconds into epoch milliseconds and the nanoseconds of the millisecond
+func unixNanoTimestamp(unixNano uint64) (*int64, *int32) {
+	timestamp := int64(unixNano / uint64(time.Millisecond))
+	nanos := int32(unixNano % uint64(time.Millisecond))
+	return &timestamp, &nanos
+}
@@ -0,0 +1,255 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/ingest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ingestRT records the events and metric events posted to it
+type ingestRT struct {
+	mux     sync.Mutex
+	posted  []ingest.Event
+	metrics [][]ingest.MetricEvent
+}
+
+func (rt *ingestRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	var err error
+	if strings.HasSuffix(req.URL.Path, "/metrics") {
+		var events []ingest.MetricEvent
+		err = json.NewDecoder(req.Body).Decode(&events)
+		rt.metrics = append(rt.metrics, events)
+	} else {
+		var events []ingest.Event
+		err = json.NewDecoder(req.Body).Decode(&events)
+		rt.posted = append(rt.posted, events...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	body := io.NopCloser(strings.NewReader(`{"code":"SUCCESS","message":"ok"}`))
+	return &http.Response{StatusCode: 200, Status: "OK", Body: body, Header: http.Header{}}, nil
+}
+
+func (rt *ingestRT) events() []ingest.Event {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	return append([]ingest.Event(nil), rt.posted...)
+}
+
+func (rt *ingestRT) metricBatches() [][]ingest.MetricEvent {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	return append([][]ingest.MetricEvent(nil), rt.metrics...)
+}
+
+// newTestSenders returns running senders of events and metric events posting to rt, they never flush on their
+// own interval
+func newTestSenders(t *testing.T, rt http.RoundTripper) (*ingest.BatchEventsSender, *ingest.BatchMetricsSender) {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt})
+	require.NoError(t, err)
+	service := ingest.NewService(client)
+	interval := int64(time.Hour / time.Millisecond)
+	events, err := service.NewBatchEventsSender(100, interval, 0)
+	require.NoError(t, err)
+	metrics, err := service.NewBatchMetricsSender(100, interval, 0, 1)
+	require.NoError(t, err)
+	events.Run()
+	metrics.Run()
+	return events, metrics
+}
+
+// fixtures are the content types of the OTLP fixtures by file extension
+var fixtures = map[string]string{"json": jsonContentType, "pb": protobufContentType}
+
+func otlpRequest(t *testing.T, h http.Handler, path, contentType string, body []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func unmarshalOTLPResponse(t *testing.T, rec *httptest.ResponseRecorder, m proto.Message) {
+	if rec.Header().Get("Content-Type") == jsonContentType {
+		require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), m))
+	} else {
+		require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), m))
+	}
+}
+
+func TestHandlerLogs(t *testing.T) {
+	for ext, contentType := range fixtures {
+		t.Run(ext, func(t *testing.T) {
+			fixture, err := os.ReadFile("testdata/logs." + ext)
+			require.NoError(t, err)
+			rt := &ingestRT{}
+			sender, _ := newTestSenders(t, rt)
+			h := NewHandler(sender, nil, HandlerConfig{})
+
+			rec := otlpRequest(t, h, "/otlp/v1/logs", contentType+"; charset=utf-8", fixture)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			assert.Equal(t, contentType, rec.Header().Get("Content-Type"))
+			unmarshalOTLPResponse(t, rec, &collogspb.ExportLogsServiceResponse{})
+			require.NoError(t, sender.Close(context.Background()))
+
+			events := rt.events()
+			require.Len(t, events, 3)
+			assert.Equal(t, "order placed", events[0].Body)
+			assert.Equal(t, "web-1", *events[0].Host)
+			assert.Equal(t, "checkout", *events[0].Source)
+			assert.Nil(t, events[0].Sourcetype)
+			assert.Equal(t, int64(1700000000123), *events[0].Timestamp)
+			assert.Equal(t, int32(456789), *events[0].Nanos)
+			assert.Equal(t, map[string]interface{}{
+				"deployment.environment": "canary",
+				"order.id":               float64(42),
+				"severity":               "INFO",
+				"severity_number":        float64(9),
+				"trace_id":               "5b8efff798038103d269b633813fc60c",
+				"span_id":                "eee19b7ec3c1b174",
+			}, events[0].Attributes, "the attributes of the record take precedence over the resource")
+
+			assert.Equal(t, map[string]interface{}{
+				"error":   "payment declined",
+				"retries": []interface{}{float64(1), 2.5, true},
+			}, events[1].Body)
+			assert.Equal(t, int64(1700000001000), *events[1].Timestamp, "the observed time is used if the time is unset")
+			assert.Equal(t, "ERROR", events[1].Attributes["severity"])
+
+			assert.Equal(t, "", events[2].Body)
+			assert.Nil(t, events[2].Host)
+			assert.Nil(t, events[2].Timestamp)
+			assert.Nil(t, events[2].Attributes)
+		})
+	}
+}
+
+func TestHandlerMetrics(t *testing.T) {
+	for ext, contentType := range fixtures {
+		t.Run(ext, func(t *testing.T) {
+			fixture, err := os.ReadFile("testdata/metrics." + ext)
+			require.NoError(t, err)
+			rt := &ingestRT{}
+			_, sender := newTestSenders(t, rt)
+			h := NewHandler(nil, sender, HandlerConfig{})
+
+			rec := otlpRequest(t, h, "/v1/metrics/", contentType, fixture)
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			resp := &colmetricspb.ExportMetricsServiceResponse{}
+			unmarshalOTLPResponse(t, rec, resp)
+			assert.Equal(t, int64(1), resp.GetPartialSuccess().GetRejectedDataPoints(), "histograms are rejected")
+			require.NoError(t, sender.Close(context.Background()))
+
+			batches := rt.metricBatches()
+			require.Len(t, batches, 1)
+			var metrics []ingest.Metric
+			for _, event := range batches[0] {
+				assert.Equal(t, "web-1", *event.Host)
+				assert.Equal(t, "checkout", *event.Source)
+				assert.Equal(t, map[string]string{"region": "us-west-1"}, event.Attributes.DefaultDimensions)
+				metrics = append(metrics, event.Body...)
+			}
+			require.Len(t, metrics, 3, "the data point without value is skipped")
+		})
+	}
+}
+
+func TestMetricsToMetricEvents(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/metrics.json")
+	require.NoError(t, err)
+	req := &colmetricspb.ExportMetricsServiceRequest{}
+	require.NoError(t, protojson.Unmarshal(fixture, req))
+
+	events, rejected := MetricsToMetricEvents(req)
+	assert.Equal(t, int64(1), rejected)
+	require.Len(t, events, 3)
+
+	gauge := events[0].Body[0]
+	assert.Equal(t, "cpu.utilization", gauge.Name)
+	assert.Equal(t, ingest.GaugeMetricType, *gauge.Type)
+	assert.Equal(t, "1", *gauge.Unit)
+	assert.Equal(t, 0.75, *gauge.Value)
+	assert.Equal(t, map[string]string{"cpu": "0"}, gauge.Dimensions)
+	assert.Equal(t, int64(1700000000123), *events[0].Timestamp)
+	assert.Equal(t, int32(456789), *events[0].Nanos)
+
+	delta := events[1].Body[0]
+	assert.Equal(t, "orders", delta.Name)
+	assert.Equal(t, ingest.CounterMetricType, *delta.Type, "delta sums are counters")
+	assert.Equal(t, 3.0, *delta.Value)
+	assert.Equal(t, map[string]string{"status": "paid"}, delta.Dimensions)
+	assert.Nil(t, delta.Unit)
+
+	cumulative := events[2].Body[0]
+	assert.Equal(t, "orders.total", cumulative.Name)
+	assert.Equal(t, ingest.GaugeMetricType, *cumulative.Type, "cumulative sums are gauges")
+	assert.Equal(t, 120.0, *cumulative.Value)
+	assert.Nil(t, cumulative.Dimensions)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	rt := &ingestRT{}
+	sender, _ := newTestSenders(t, rt)
+	h := NewHandler(sender, nil, HandlerConfig{MaxBodyBytes: 64})
+
+	rec := otlpRequest(t, h, "/v1/metrics", jsonContentType, []byte(`{}`))
+	assert.Equal(t, http.StatusNotFound, rec.Code, "metrics are not served without sender")
+	rec = otlpRequest(t, h, "/v1/logs", "text/plain", []byte(`{}`))
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/logs", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = otlpRequest(t, h, "/v1/logs", jsonContentType, []byte(`{"resourceLogs":1}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	st := &status.Status{}
+	unmarshalOTLPResponse(t, rec, st)
+	assert.Contains(t, st.GetMessage(), "otlp: invalid request")
+	rec = otlpRequest(t, h, "/v1/logs", protobufContentType, []byte{0xff, 0xff})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = otlpRequest(t, h, "/v1/logs", jsonContentType, bytes.Repeat([]byte(" "), 65))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	require.NoError(t, sender.Close(context.Background()))
+	rec = otlpRequest(t, h, "/v1/logs", jsonContentType, []byte(`{"resourceLogs":[{"scopeLogs":[{"logRecords":[{}]}]}]}`))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "closed senders are retried later")
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Empty(t, rt.events())
+}
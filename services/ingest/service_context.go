@@ -0,0 +1,354 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+DeleteAllCollectorTokensWithContext - Delete All dsphec tokens for a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteAllCollectorTokensWithContext(ctx context.Context, resp ...*http.Response) (*map[string]interface{}, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "DeleteAllCollectorTokens")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/collector/tokens`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb map[string]interface{}
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteCollectorTokenWithContext - Delete dsphec token by name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	tokenName
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteCollectorTokenWithContext(ctx context.Context, tokenName string, resp ...*http.Response) (*map[string]interface{}, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "DeleteCollectorToken")
+	pp := struct {
+		TokenName string
+	}{
+		TokenName: tokenName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/collector/tokens/{{.TokenName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb map[string]interface{}
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetCollectorTokenWithContext - Get the metadata of a dsphec token by name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	tokenName
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetCollectorTokenWithContext(ctx context.Context, tokenName string, resp ...*http.Response) (*HecTokenAccessResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "GetCollectorToken")
+	pp := struct {
+		TokenName string
+	}{
+		TokenName: tokenName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/collector/tokens/{{.TokenName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb HecTokenAccessResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListCollectorTokensWithContext - List dsphec tokens for a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListCollectorTokensWithContext(ctx context.Context, query *ListCollectorTokensQueryParams, resp ...*http.Response) ([]HecTokenAccessResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "ListCollectorTokens")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/ingest/v1beta2/collector/tokens`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []HecTokenAccessResponse
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+PostCollectorTokensWithContext - Creates dsphec tokens.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	hecTokenCreateRequest: The API request schema for the token.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PostCollectorTokensWithContext(ctx context.Context, hecTokenCreateRequest HecTokenCreateRequest, resp ...*http.Response) (*HecTokenCreateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "PostCollectorTokens")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/collector/tokens`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: hecTokenCreateRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb HecTokenCreateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PostEventsWithContext - Sends events.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	event
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PostEventsWithContext(ctx context.Context, event []Event, resp ...*http.Response) (*HttpResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "PostEvents")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/events`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: event})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb HttpResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PostMetricsWithContext - Sends metric events.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	metricEvent
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PostMetricsWithContext(ctx context.Context, metricEvent []MetricEvent, resp ...*http.Response) (*HttpResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "PostMetrics")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/metrics`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: metricEvent})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb HttpResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PutCollectorTokenWithContext - Update the metadata of a dsphec token by name.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	tokenName
+	hecTokenUpdateRequest: The API request schema for the token.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PutCollectorTokenWithContext(ctx context.Context, tokenName string, hecTokenUpdateRequest HecTokenUpdateRequest, resp ...*http.Response) (*HecTokenAccessResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "PutCollectorToken")
+	pp := struct {
+		TokenName string
+	}{
+		TokenName: tokenName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/collector/tokens/{{.TokenName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: hecTokenUpdateRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb HecTokenAccessResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UploadFilesWithContext - Upload a CSV or text file that contains events. Files larger than the file limit of 1MB are
+uploaded in chunks split at line boundaries, the header of .csv files being repeated in every chunk, see
+UploadFilesStreamWithContext. UploadFiles, whose implementation is generated, sends the file in a single request instead.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	filename
+	resp: an optional pointer to a http.Response to be populated by this method, with the response of the last chunk uploaded. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UploadFilesWithContext(ctx context.Context, filename string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "ingest", "UploadFiles")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/files`, nil)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > MaxUploadBytes {
+		opts := &UploadOptions{Filename: filepath.Base(filename), RepeatHeader: strings.EqualFold(filepath.Ext(filename), ".csv")}
+		return s.uploadChunks(ctx, file, opts, resp...)
+	}
+
+	var response *http.Response
+	if len(resp) > 0 && resp[0] != nil {
+		response = resp[0]
+	}
+
+	form := services.FormData{Filename: filepath.Base(filename), Stream: file, Key: "upfile"}
+
+	multipartResp, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: form, Headers: map[string]string{"Content-Type": "multipart/form-data"}})
+
+	if multipartResp != nil {
+		defer multipartResp.Body.Close()
+
+		// populate input *http.Response if provided
+		if response != nil {
+			*response = *multipartResp
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,446 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyncPolicy controls when the spool flushes appended events to stable storage
+type SyncPolicy int
+
+const (
+	// SyncAlways syncs the segment after every event, and the directory after every segment is created or deleted,
+	// an acknowledged event survives a machine crash
+	SyncAlways SyncPolicy = iota
+	// SyncInterval syncs the segment and the directory periodically, events acknowledged within the interval before a
+	// machine crash may be lost
+	SyncInterval
+	// SyncNever leaves syncing to the operating system, an acknowledged event survives a crash of the process only
+	SyncNever
+)
+
+const (
+	defaultSegmentBytes = 8 << 20
+	defaultSyncInterval = time.Second
+	segmentPrefix       = "segment-"
+	segmentSuffix       = ".log"
+	// every record is the length and the CRC-32 of its payload, followed by the payload
+	recordHeaderSize = 8
+)
+
+// SpoolConfig configures a Spool
+type SpoolConfig struct {
+	// Dir is the directory holding the segments, it's created if needed
+	Dir string
+	// SegmentBytes is the size after which a new segment is started, 8MiB if zero
+	SegmentBytes int64
+	// MaxBytes is the maximum size of all the segments, once exceeded the oldest segments are deleted along with
+	// the events they hold whether they were sent or not. Unlimited if zero, otherwise it must be at least SegmentBytes.
+	MaxBytes int64
+	// Sync is when appended events are synced to stable storage
+	Sync SyncPolicy
+	// SyncInterval is the interval between syncs of the SyncInterval policy, 1 second if zero
+	SyncInterval time.Duration
+}
+
+// Spool is a write-ahead log of the events added to a BatchEventsSender. It's made of append-only segment files,
+// a segment is deleted once all of its events were sent. Events still in the spool when it's opened, because the
+// process stopped or the batches holding them failed, are sent again by the BatchEventsSender: delivery is at-least-once.
+type Spool struct {
+	config SpoolConfig
+
+	mux      sync.Mutex
+	segments []*spoolSegment // oldest first, the last one is the active segment
+	active   *os.File
+	size     int64
+	// evicted is the number of events of every evicted segment which weren't sent yet
+	evicted map[uint64]int
+	dirty   bool
+	// dirDirty is whether segments were created or deleted since the directory was last synced
+	dirDirty bool
+	replay   []queuedEvent
+	closed   bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type spoolSegment struct {
+	id     uint64
+	path   string
+	size   int64
+	count  int
+	acked  int
+	sealed bool
+}
+
+// OpenSpool opens the spool in config.Dir, the events it holds are replayed by the BatchEventsSender it's set on
+func OpenSpool(config SpoolConfig) (*Spool, error) {
+	if config.Dir == "" {
+		return nil, errors.New("ingest: spool directory is required")
+	}
+	if config.SegmentBytes <= 0 {
+		config.SegmentBytes = defaultSegmentBytes
+	}
+	if config.MaxBytes > 0 && config.MaxBytes < config.SegmentBytes {
+		return nil, fmt.Errorf("ingest: spool MaxBytes (%d) must be at least SegmentBytes (%d)", config.MaxBytes, config.SegmentBytes)
+	}
+	if config.SyncInterval <= 0 {
+		config.SyncInterval = defaultSyncInterval
+	}
+	if err := os.MkdirAll(config.Dir, 0700); err != nil {
+		return nil, err
+	}
+	s := &Spool{config: config, evicted: map[uint64]int{}}
+	ids, err := s.segmentIDs()
+	if err != nil {
+		return nil, err
+	}
+	next := uint64(1)
+	for _, id := range ids {
+		seg, events, err := s.readSegment(id)
+		if err != nil {
+			return nil, err
+		}
+		next = id + 1
+		if seg.count == 0 {
+			if err := os.Remove(seg.path); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		s.segments = append(s.segments, seg)
+		s.size += seg.size
+		s.replay = append(s.replay, events...)
+	}
+	if err := s.startSegment(next); err != nil {
+		return nil, err
+	}
+	if config.Sync == SyncInterval {
+		s.stop, s.done = make(chan struct{}), make(chan struct{})
+		go s.syncLoop()
+	}
+	return s, nil
+}
+
+func (s *Spool) segmentPath(id uint64) string {
+	return filepath.Join(s.config.Dir, fmt.Sprintf("%s%020d%s", segmentPrefix, id, segmentSuffix))
+}
+
+// segmentIDs returns the IDs of the segments in the spool directory in ascending order
+func (s *Spool) segmentIDs() ([]uint64, error) {
+	entries, err := os.ReadDir(s.config.Dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// readSegment reads the events of a segment, stopping at the first incomplete or corrupted record which
+// is the tail of a write interrupted by a crash
+func (s *Spool) readSegment(id uint64) (*spoolSegment, []queuedEvent, error) {
+	seg := &spoolSegment{id: id, path: s.segmentPath(id), sealed: true}
+	data, err := os.ReadFile(seg.path)
+	if err != nil {
+		return nil, nil, err
+	}
+	seg.size = int64(len(data))
+	var events []queuedEvent
+	for len(data) >= recordHeaderSize {
+		n := binary.BigEndian.Uint32(data[0:4])
+		sum := binary.BigEndian.Uint32(data[4:8])
+		if uint64(len(data)-recordHeaderSize) < uint64(n) {
+			break
+		}
+		payload := data[recordHeaderSize : recordHeaderSize+int(n)]
+		var event Event
+		if crc32.ChecksumIEEE(payload) != sum || json.Unmarshal(payload, &event) != nil {
+			break
+		}
+		events = append(events, queuedEvent{event: event, segment: id})
+		data = data[recordHeaderSize+int(n):]
+	}
+	seg.count = len(events)
+	return seg, events, nil
+}
+
+// startSegment creates the segment id and makes it the active segment, it must be called with s.mux held
+func (s *Spool) startSegment(id uint64) error {
+	path := s.segmentPath(id)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	s.active = f
+	s.segments = append(s.segments, &spoolSegment{id: id, path: path})
+	return s.dirChanged()
+}
+
+// dirChanged syncs the directory once a segment was created or deleted, according to the sync policy, so that
+// the change itself survives a machine crash. It must be called with s.mux held.
+func (s *Spool) dirChanged() error {
+	switch s.config.Sync {
+	case SyncAlways:
+		return s.syncDir()
+	case SyncInterval:
+		s.dirDirty = true
+	}
+	return nil
+}
+
+// syncDir syncs the directory holding the segments, it must be called with s.mux held
+func (s *Spool) syncDir() error {
+	dir, err := os.Open(s.config.Dir)
+	if err != nil {
+		return err
+	}
+	err = dir.Sync()
+	if closeErr := dir.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		s.dirDirty = false
+	}
+	return err
+}
+
+// rotate seals the active segment and starts a new one, it must be called with s.mux held
+func (s *Spool) rotate() error {
+	if err := s.active.Sync(); err != nil {
+		return err
+	}
+	if err := s.active.Close(); err != nil {
+		return err
+	}
+	s.dirty = false
+	seg := s.segments[len(s.segments)-1]
+	seg.sealed = true
+	if err := s.startSegment(seg.id + 1); err != nil {
+		return err
+	}
+	return s.removeIfAcked(seg)
+}
+
+// append persists event, according to the sync policy, and returns the ID of the segment holding it
+func (s *Spool) append(event Event) (uint64, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return 0, errors.New("can't read event:" + err.Error())
+	}
+	record := make([]byte, recordHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	copy(record[recordHeaderSize:], payload)
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.closed {
+		return 0, errors.New("ingest: spool is closed")
+	}
+	seg := s.segments[len(s.segments)-1]
+	if seg.size > 0 && seg.size+int64(len(record)) > s.config.SegmentBytes {
+		if err := s.rotate(); err != nil {
+			return 0, err
+		}
+		seg = s.segments[len(s.segments)-1]
+	}
+	if _, err := s.active.Write(record); err != nil {
+		return 0, err
+	}
+	if s.config.Sync == SyncAlways {
+		if err := s.active.Sync(); err != nil {
+			return 0, err
+		}
+	} else {
+		s.dirty = true
+	}
+	seg.size += int64(len(record))
+	seg.count++
+	s.size += int64(len(record))
+	if err := s.evict(); err != nil {
+		return 0, err
+	}
+	return seg.id, nil
+}
+
+// evict deletes the oldest sealed segments until the spool is within its size limit, it must be called with s.mux held
+func (s *Spool) evict() error {
+	for s.config.MaxBytes > 0 && s.size > s.config.MaxBytes && s.segments[0].sealed {
+		seg := s.segments[0]
+		if err := os.Remove(seg.path); err != nil {
+			return err
+		}
+		s.segments = s.segments[1:]
+		s.size -= seg.size
+		if pending := seg.count - seg.acked; pending > 0 {
+			s.evicted[seg.id] = pending
+		}
+		if err := s.dirChanged(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ack records that n events of the segment id were sent, the segment is deleted once sealed and fully acknowledged
+func (s *Spool) ack(id uint64, n int) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, seg := range s.segments {
+		if seg.id == id {
+			seg.acked += n
+			return s.removeIfAcked(seg)
+		}
+	}
+	// The segment was evicted, its events were sent nonetheless
+	if pending, ok := s.evicted[id]; ok {
+		if pending <= n {
+			delete(s.evicted, id)
+		} else {
+			s.evicted[id] = pending - n
+		}
+	}
+	return nil
+}
+
+// removeIfAcked deletes seg if it's sealed and all its events were sent, it must be called with s.mux held
+func (s *Spool) removeIfAcked(seg *spoolSegment) error {
+	if !seg.sealed || seg.acked < seg.count {
+		return nil
+	}
+	if err := os.Remove(seg.path); err != nil {
+		return err
+	}
+	for i, other := range s.segments {
+		if other == seg {
+			s.segments = append(s.segments[:i], s.segments[i+1:]...)
+			break
+		}
+	}
+	s.size -= seg.size
+	return s.dirChanged()
+}
+
+// takeReplay returns the events found in the spool when it was opened, only once
+func (s *Spool) takeReplay() []queuedEvent {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	replay := s.replay
+	s.replay = nil
+	return replay
+}
+
+func (s *Spool) syncLoop() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.config.SyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.mux.Lock()
+			if s.dirty && !s.closed {
+				if err := s.active.Sync(); err == nil {
+					s.dirty = false
+				}
+			}
+			if s.dirDirty && !s.closed {
+				s.syncDir()
+			}
+			s.mux.Unlock()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Size returns the size in bytes of the segments of the spool
+func (s *Spool) Size() int64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.size
+}
+
+// Evicted returns the number of events deleted from the spool since it was opened, because it was full, which
+// weren't sent yet. They're still sent by the BatchEventsSender but are lost if the process stops first.
+func (s *Spool) Evicted() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	evicted := 0
+	for _, pending := range s.evicted {
+		evicted += pending
+	}
+	return evicted
+}
+
+// Close syncs and closes the active segment, it's deleted if all of its events were sent. The events left are
+// replayed when the spool is opened again.
+func (s *Spool) Close() error {
+	s.mux.Lock()
+	if s.closed {
+		s.mux.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.mux.Unlock()
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	err := s.active.Sync()
+	if closeErr := s.active.Close(); err == nil {
+		err = closeErr
+	}
+	seg := s.segments[len(s.segments)-1]
+	seg.sealed = true
+	if removeErr := s.removeIfAcked(seg); err == nil {
+		err = removeErr
+	}
+	if s.dirDirty {
+		if syncErr := s.syncDir(); err == nil {
+			err = syncErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func segmentFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, segmentPrefix+"*"+segmentSuffix))
+	require.NoError(t, err)
+	return files
+}
+
+func replayedBodies(events []queuedEvent) []string {
+	bodies := make([]string, len(events))
+	for i, qe := range events {
+		bodies[i] = fmt.Sprint(qe.event.Body)
+	}
+	return bodies
+}
+
+func TestSpoolReplayAndAck(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := OpenSpool(SpoolConfig{Dir: dir, SegmentBytes: 100})
+	require.NoError(t, err)
+	var segments []uint64
+	for i := 0; i < 6; i++ {
+		segment, err := spool.append(Event{Body: fmt.Sprintf("event-%d", i)})
+		require.NoError(t, err)
+		segments = append(segments, segment)
+	}
+	// Each record is 30 bytes so segments hold 3 events
+	assert.Equal(t, []uint64{1, 1, 1, 2, 2, 2}, segments)
+	assert.Len(t, segmentFiles(t, dir), 2)
+
+	// A sealed segment is deleted once all its events were sent
+	require.NoError(t, spool.ack(1, 3))
+	assert.Len(t, segmentFiles(t, dir), 1)
+	require.NoError(t, spool.ack(2, 1))
+	require.NoError(t, spool.Close())
+
+	// Events left are replayed, including the ones of a partially sent segment
+	spool, err = OpenSpool(SpoolConfig{Dir: dir, SegmentBytes: 100})
+	require.NoError(t, err)
+	replay := spool.takeReplay()
+	assert.Equal(t, []string{"event-3", "event-4", "event-5"}, replayedBodies(replay))
+	assert.Nil(t, spool.takeReplay())
+	segment, err := spool.append(Event{Body: "event-6"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), segment)
+
+	require.NoError(t, spool.ack(2, 3))
+	require.NoError(t, spool.ack(3, 1))
+	require.NoError(t, spool.Close())
+	assert.Empty(t, segmentFiles(t, dir))
+	assert.Equal(t, int64(0), spool.Size())
+}
+
+func TestSpoolTornWrite(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := OpenSpool(SpoolConfig{Dir: dir, Sync: SyncNever})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := spool.append(Event{Body: fmt.Sprintf("event-%d", i)})
+		require.NoError(t, err)
+	}
+	require.NoError(t, spool.Close())
+
+	// Simulate a crash in the middle of writing a record
+	files := segmentFiles(t, dir)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 50, 1, 2, 3, 4, '{'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	spool, err = OpenSpool(SpoolConfig{Dir: dir, Sync: SyncInterval})
+	require.NoError(t, err)
+	defer spool.Close()
+	assert.Equal(t, []string{"event-0", "event-1"}, replayedBodies(spool.takeReplay()))
+}
+
+func TestSpoolEviction(t *testing.T) {
+	dir := t.TempDir()
+	_, err := OpenSpool(SpoolConfig{Dir: dir, SegmentBytes: 100, MaxBytes: 50})
+	assert.Error(t, err)
+
+	spool, err := OpenSpool(SpoolConfig{Dir: dir, SegmentBytes: 90, MaxBytes: 200})
+	require.NoError(t, err)
+	defer spool.Close()
+	for i := 0; i < 10; i++ {
+		_, err := spool.append(Event{Body: fmt.Sprintf("event-%d", i)})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, spool.Size(), int64(200))
+	}
+	// The oldest segments were evicted, their events are only counted until they're sent
+	assert.Equal(t, 3, spool.Evicted())
+	assert.NoError(t, spool.ack(1, 2))
+	assert.Equal(t, 1, spool.Evicted())
+	assert.NoError(t, spool.ack(1, 1))
+	assert.Equal(t, 0, spool.Evicted())
+	assert.Len(t, segmentFiles(t, dir), 3)
+}
+
+func TestBatchEventsSenderSpool(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+
+	// The endpoint is down, the events stay in the spool
+	failing := &ingestRT{status: 503}
+	sender, _, _ := newTestSender(t, failing, 2, 0, 1, spool)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, sender.AddEvent(ctx, Event{Body: fmt.Sprintf("e%d", i)}))
+	}
+	require.NoError(t, sender.Close(ctx))
+	require.NoError(t, spool.Close())
+	assert.Len(t, sender.Errors(), 1)
+	assert.Len(t, segmentFiles(t, dir), 1)
+
+	// Once restarted, the events are sent again along with new ones and removed from the spool
+	spool, err = OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	rt := &ingestRT{}
+	sender, _, _ = newTestSender(t, rt, 3, 0, 1, spool)
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "e2"}))
+	require.NoError(t, sender.Close(ctx))
+	require.NoError(t, spool.Close())
+	assert.Equal(t, [][]string{{"e0", "e1", "e2"}}, rt.posted())
+	assert.Empty(t, segmentFiles(t, dir))
+}
+
+// flakyRT fails the first failures requests with a 503 then sends them to ingestRT
+type flakyRT struct {
+	ingestRT
+	failures atomic.Int32
+}
+
+func (rt *flakyRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.failures.Add(-1) >= 0 {
+		return &http.Response{StatusCode: 503, Status: "Service Unavailable", Body: ioutil.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+	}
+	return rt.ingestRT.RoundTrip(req)
+}
+
+func TestBatchEventsSenderSpoolRequeue(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	rt := &flakyRT{}
+	rt.failures.Store(2)
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSenderWithMaxAllowedError(2, 10, 0, 10)
+	require.NoError(t, err)
+	sender.SetSpool(spool)
+	sender.Run()
+
+	// The events of the failed batch are sent again in-process, and removed from the spool once sent
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		require.NoError(t, sender.AddEvent(ctx, Event{Body: fmt.Sprintf("e%d", i)}))
+	}
+	assert.Eventually(t, func() bool { return len(rt.posted()) == 1 }, 5*time.Second, 5*time.Millisecond)
+	require.NoError(t, sender.Close(ctx))
+	require.NoError(t, spool.Close())
+	assert.Equal(t, [][]string{{"e0", "e1"}}, rt.posted())
+	assert.Len(t, sender.Errors(), 2)
+	assert.Empty(t, segmentFiles(t, dir))
+	assert.Equal(t, 0, spool.Evicted())
+}
+
+func TestBatchEventsSenderSpoolCanceledEvent(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: &ingestRT{}})
+	require.NoError(t, err)
+	sender, err := NewService(client).NewBatchEventsSender(2, 1000, 0)
+	require.NoError(t, err)
+	sender.SetSpool(spool)
+
+	// The sender accepts events without sending them, its queue holds 2 events
+	sender.running = true
+	ctx := context.Background()
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "e0"}))
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "e1"}))
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, context.Canceled, sender.AddEvent(canceled, Event{Body: "e2"}))
+	assert.Equal(t, errQueueFull, sender.addEvent(ctx, Event{Body: "e3"}, false))
+	require.NoError(t, spool.Close())
+
+	// Once restarted, only the events accepted are sent again
+	spool, err = OpenSpool(SpoolConfig{Dir: dir})
+	require.NoError(t, err)
+	defer spool.Close()
+	assert.Equal(t, []string{"e0", "e1"}, replayedBodies(spool.takeReplay()))
+}
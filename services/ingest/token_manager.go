@@ -0,0 +1,321 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// listCollectorTokensPageSize is the number of tokens listed per request
+const listCollectorTokensPageSize = 100
+
+// TokenRotation is a HEC token being rotated, both tokens are valid until the old one is deleted
+type TokenRotation struct {
+	// Old is the token being replaced
+	Old HecTokenAccessResponse
+	// New is the replacement token, with its value
+	New HecTokenCreateResponse
+}
+
+// TokenRotationHook is called once the replacement token is created, to redeploy the dependants of the old token
+// with the new one. The old token is kept if it returns an error.
+type TokenRotationHook func(ctx context.Context, rotation TokenRotation) error
+
+// TokenManager rotates HEC collector tokens and reconciles them with a declared set of tokens
+type TokenManager struct {
+	// Service is the service managing the tokens
+	Service *Service
+	// Overlap is how long both tokens stay valid during a rotation, once OnRotate returned
+	Overlap time.Duration
+	// OnRotate is called during a rotation once the replacement token is created, if set
+	OnRotate TokenRotationHook
+}
+
+// TokenSpec is the declaration of a HEC token, the settings which are nil are not managed:
+//
+//	tokens:
+//	  - name: web
+//	    description: access logs of the web servers
+//	    index: main
+//	    indexes: [main, web]
+//	    source: nginx
+//	    sourcetype: access_combined
+//	    ack_enabled: false
+//	    allow_query_string_auth: false
+//	    disabled: false
+type TokenSpec struct {
+	Name                 string   `yaml:"name"`
+	Description          *string  `yaml:"description"`
+	Index                *string  `yaml:"index"`
+	Indexes              []string `yaml:"indexes"`
+	Source               *string  `yaml:"source"`
+	Sourcetype           *string  `yaml:"sourcetype"`
+	AckEnabled           *bool    `yaml:"ack_enabled"`
+	AllowQueryStringAuth *bool    `yaml:"allow_query_string_auth"`
+	Disabled             *bool    `yaml:"disabled"`
+}
+
+// ReconcileOptions configures TokenManager.Reconcile
+type ReconcileOptions struct {
+	// Prune deletes the tokens which are not declared
+	Prune bool
+	// DryRun only computes the changes, without applying them
+	DryRun bool
+}
+
+// ReconcileResult lists the names of the tokens changed by TokenManager.Reconcile, in the order of the
+// declarations, or that would be changed for a dry run
+type ReconcileResult struct {
+	Created   []string
+	Updated   []string
+	Deleted   []string
+	Unchanged []string
+	// Tokens are the values of the tokens created
+	Tokens map[string]string
+}
+
+/*
+NewTokenManager initializes a TokenManager to rotate HEC collector tokens and reconcile them with a declared set of tokens.
+Parameters:
+
+	overlap: how long both tokens stay valid during a rotation once onRotate returned, before the old token is deleted
+	onRotate: an optional function called during a rotation once the replacement token is created
+*/
+func (s *Service) NewTokenManager(overlap time.Duration, onRotate TokenRotationHook) *TokenManager {
+	return &TokenManager{Service: s, Overlap: overlap, OnRotate: onRotate}
+}
+
+// ParseTokenSpecs parses a YAML document declaring HEC tokens under its tokens key, see TokenSpec
+func ParseTokenSpecs(data []byte) ([]TokenSpec, error) {
+	var doc struct {
+		Tokens []TokenSpec `yaml:"tokens"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("ingest: invalid token declarations: %w", err)
+	}
+	names := make(map[string]bool, len(doc.Tokens))
+	for i, spec := range doc.Tokens {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("ingest: token declaration %d has no name", i)
+		}
+		if names[spec.Name] {
+			return nil, fmt.Errorf("ingest: token %q is declared twice", spec.Name)
+		}
+		names[spec.Name] = true
+	}
+	return doc.Tokens, nil
+}
+
+// Rotate replaces the HEC token named name with a new token named replacement, with the same settings:
+//
+//  1. the replacement token is created
+//  2. OnRotate is called, to redeploy the dependants of the old token with the new one
+//  3. both tokens stay valid for the Overlap
+//  4. the old token is deleted
+//
+// The old token is kept if OnRotate returns an error or ctx is done before it's deleted, both tokens are then
+// valid and the replacement token is returned along with the error.
+func (m *TokenManager) Rotate(ctx context.Context, name, replacement string) (*HecTokenCreateResponse, error) {
+	if replacement == "" || replacement == name {
+		return nil, errors.New("ingest: the replacement token needs a new name")
+	}
+	old, err := m.Service.GetCollectorTokenWithContext(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("ingest: can't get token %q: %w", name, err)
+	}
+	created, err := m.Service.PostCollectorTokensWithContext(ctx, HecTokenCreateRequest{
+		Name:                 replacement,
+		AckEnabled:           old.AckEnabled,
+		AllowQueryStringAuth: old.AllowQueryStringAuth,
+		Description:          old.Description,
+		Index:                old.Index,
+		Indexes:              old.Indexes,
+		Source:               old.Source,
+		Sourcetype:           old.Sourcetype,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ingest: can't create token %q replacing %q: %w", replacement, name, err)
+	}
+	if m.OnRotate != nil {
+		if err := m.OnRotate(ctx, TokenRotation{Old: *old, New: *created}); err != nil {
+			return created, fmt.Errorf("ingest: rotation of token %q aborted, both tokens are valid: %w", name, err)
+		}
+	}
+	if m.Overlap > 0 {
+		timer := time.NewTimer(m.Overlap)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return created, fmt.Errorf("ingest: rotation of token %q aborted, both tokens are valid: %w", name, ctx.Err())
+		}
+	}
+	if _, err := m.Service.DeleteCollectorTokenWithContext(ctx, name); err != nil {
+		return created, fmt.Errorf("ingest: can't delete token %q once replaced: %w", name, err)
+	}
+	return created, nil
+}
+
+// Reconcile creates the declared tokens which don't exist and updates the existing ones whose settings differ
+// from their declaration. The tokens which are not declared are deleted with opts.Prune, they are kept otherwise.
+// The result lists the changes made before an error, if any.
+func (m *TokenManager) Reconcile(ctx context.Context, desired []TokenSpec, opts *ReconcileOptions) (*ReconcileResult, error) {
+	var o ReconcileOptions
+	if opts != nil {
+		o = *opts
+	}
+	existing, err := m.listTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &ReconcileResult{Tokens: map[string]string{}}
+	declared := make(map[string]bool, len(desired))
+	for _, spec := range desired {
+		declared[spec.Name] = true
+		current, ok := existing[spec.Name]
+		switch {
+		case !ok:
+			if !o.DryRun {
+				created, err := m.Service.PostCollectorTokensWithContext(ctx, spec.createRequest())
+				if err != nil {
+					return result, fmt.Errorf("ingest: can't create token %q: %w", spec.Name, err)
+				}
+				if created.Token != nil {
+					result.Tokens[spec.Name] = *created.Token
+				}
+			}
+			result.Created = append(result.Created, spec.Name)
+		case !spec.matches(current):
+			if !o.DryRun {
+				if _, err := m.Service.PutCollectorTokenWithContext(ctx, spec.Name, spec.updateRequest()); err != nil {
+					return result, fmt.Errorf("ingest: can't update token %q: %w", spec.Name, err)
+				}
+			}
+			result.Updated = append(result.Updated, spec.Name)
+		default:
+			result.Unchanged = append(result.Unchanged, spec.Name)
+		}
+	}
+	if !o.Prune {
+		return result, nil
+	}
+	var undeclared []string
+	for name := range existing {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		if !o.DryRun {
+			if _, err := m.Service.DeleteCollectorTokenWithContext(ctx, name); err != nil {
+				return result, fmt.Errorf("ingest: can't delete token %q: %w", name, err)
+			}
+		}
+		result.Deleted = append(result.Deleted, name)
+	}
+	return result, nil
+}
+
+// listTokens lists all the tokens of the tenant by name
+func (m *TokenManager) listTokens(ctx context.Context) (map[string]HecTokenAccessResponse, error) {
+	tokens := map[string]HecTokenAccessResponse{}
+	query := ListCollectorTokensQueryParams{}.SetLimit(listCollectorTokensPageSize)
+	for token, err := range m.Service.AllCollectorTokens(ctx, &query) {
+		if err != nil {
+			return nil, fmt.Errorf("ingest: can't list tokens: %w", err)
+		}
+		if token.Name != nil {
+			tokens[*token.Name] = token
+		}
+	}
+	return tokens, nil
+}
+
+func (spec TokenSpec) createRequest() HecTokenCreateRequest {
+	return HecTokenCreateRequest{
+		Name:                 spec.Name,
+		AckEnabled:           spec.AckEnabled,
+		AllowQueryStringAuth: spec.AllowQueryStringAuth,
+		Description:          spec.Description,
+		Disabled:             spec.Disabled,
+		Index:                spec.Index,
+		Indexes:              spec.Indexes,
+		Source:               spec.Source,
+		Sourcetype:           spec.Sourcetype,
+	}
+}
+
+func (spec TokenSpec) updateRequest() HecTokenUpdateRequest {
+	return HecTokenUpdateRequest{
+		AckEnabled:           spec.AckEnabled,
+		AllowQueryStringAuth: spec.AllowQueryStringAuth,
+		Description:          spec.Description,
+		Disabled:             spec.Disabled,
+		Index:                spec.Index,
+		Indexes:              spec.Indexes,
+		Source:               spec.Source,
+		Sourcetype:           spec.Sourcetype,
+	}
+}
+
+// matches returns whether the settings of token are the ones declared, the order of the indexes doesn't matter
+func (spec TokenSpec) matches(token HecTokenAccessResponse) bool {
+	return matchesString(spec.Description, token.Description) && matchesString(spec.Index, token.Index) &&
+		matchesString(spec.Source, token.Source) && matchesString(spec.Sourcetype, token.Sourcetype) &&
+		matchesBool(spec.AckEnabled, token.AckEnabled) && matchesBool(spec.AllowQueryStringAuth, token.AllowQueryStringAuth) &&
+		matchesBool(spec.Disabled, token.Disabled) && matchesIndexes(spec.Indexes, token.Indexes)
+}
+
+func matchesString(declared, actual *string) bool {
+	if declared == nil {
+		return true
+	}
+	return *declared == "" && actual == nil || actual != nil && *declared == *actual
+}
+
+func matchesBool(declared, actual *bool) bool {
+	if declared == nil {
+		return true
+	}
+	return !*declared && actual == nil || actual != nil && *declared == *actual
+}
+
+func matchesIndexes(declared, actual []string) bool {
+	if declared == nil {
+		return true
+	}
+	if len(declared) != len(actual) {
+		return false
+	}
+	d := append([]string(nil), declared...)
+	a := append([]string(nil), actual...)
+	sort.Strings(d)
+	sort.Strings(a)
+	for i := range d {
+		if d[i] != a[i] {
+			return false
+		}
+	}
+	return true
+}
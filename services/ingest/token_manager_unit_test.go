@@ -0,0 +1,240 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokensRT is an in-memory implementation of the collector tokens endpoints
+type tokensRT struct {
+	mux    sync.Mutex
+	tokens map[string]HecTokenAccessResponse
+}
+
+func (rt *tokensRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	rec := httptest.NewRecorder()
+	name := req.URL.Path[strings.LastIndex(req.URL.Path, "/tokens")+len("/tokens"):]
+	name = strings.TrimPrefix(name, "/")
+	reply := func(status int, v interface{}) {
+		rec.Header().Set("Content-Type", "application/json")
+		rec.WriteHeader(status)
+		json.NewEncoder(rec).Encode(v)
+	}
+	switch {
+	case req.Method == http.MethodGet && name == "":
+		var names []string
+		for n := range rt.tokens {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+		page := []HecTokenAccessResponse{}
+		for i := offset; i < len(names) && i < offset+limit; i++ {
+			page = append(page, rt.tokens[names[i]])
+		}
+		reply(http.StatusOK, page)
+	case req.Method == http.MethodPost:
+		var create HecTokenCreateRequest
+		json.NewDecoder(req.Body).Decode(&create)
+		if _, ok := rt.tokens[create.Name]; ok {
+			reply(http.StatusConflict, map[string]string{"message": "exists"})
+			break
+		}
+		value := "value-" + create.Name
+		token := HecTokenAccessResponse{Name: &create.Name, AckEnabled: create.AckEnabled, AllowQueryStringAuth: create.AllowQueryStringAuth,
+			Description: create.Description, Disabled: create.Disabled, Index: create.Index, Indexes: create.Indexes,
+			Source: create.Source, Sourcetype: create.Sourcetype}
+		rt.tokens[create.Name] = token
+		reply(http.StatusOK, HecTokenCreateResponse{Name: &create.Name, Index: create.Index, Indexes: create.Indexes,
+			Source: create.Source, Sourcetype: create.Sourcetype, Description: create.Description, Token: &value})
+	case req.Method == http.MethodGet, req.Method == http.MethodPut, req.Method == http.MethodDelete:
+		token, ok := rt.tokens[name]
+		if !ok {
+			reply(http.StatusNotFound, map[string]string{"message": "not found"})
+			break
+		}
+		switch req.Method {
+		case http.MethodPut:
+			var update HecTokenUpdateRequest
+			json.NewDecoder(req.Body).Decode(&update)
+			token.Description, token.Index, token.Indexes = update.Description, update.Index, update.Indexes
+			token.Source, token.Sourcetype, token.Disabled = update.Source, update.Sourcetype, update.Disabled
+			rt.tokens[name] = token
+		case http.MethodDelete:
+			delete(rt.tokens, name)
+			reply(http.StatusOK, map[string]interface{}{})
+			return rec.Result(), nil
+		}
+		reply(http.StatusOK, token)
+	}
+	return rec.Result(), nil
+}
+
+func (rt *tokensRT) names() []string {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	var names []string
+	for name := range rt.tokens {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newTestTokenService(t *testing.T, rt *tokensRT) *Service {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, TypedErrors: true})
+	require.NoError(t, err)
+	return NewService(client)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestTokenManagerRotate(t *testing.T) {
+	rt := &tokensRT{tokens: map[string]HecTokenAccessResponse{
+		"web": {Name: strPtr("web"), Index: strPtr("main"), Indexes: []string{"main", "web"}, Source: strPtr("nginx"), Sourcetype: strPtr("access")},
+	}}
+	var rotations []TokenRotation
+	manager := newTestTokenService(t, rt).NewTokenManager(10*time.Millisecond, func(ctx context.Context, rotation TokenRotation) error {
+		assert.Equal(t, []string{"web", "web-2"}, rt.names(), "both tokens are valid while dependants are redeployed")
+		rotations = append(rotations, rotation)
+		return nil
+	})
+
+	start := time.Now()
+	created, err := manager.Rotate(context.Background(), "web", "web-2")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond, "both tokens are valid for the overlap")
+	assert.Equal(t, "value-web-2", *created.Token)
+	assert.Equal(t, []string{"web-2"}, rt.names())
+
+	require.Len(t, rotations, 1)
+	assert.Equal(t, "web", *rotations[0].Old.Name)
+	assert.Equal(t, "value-web-2", *rotations[0].New.Token)
+	replacement := rt.tokens["web-2"]
+	assert.Equal(t, "main", *replacement.Index)
+	assert.Equal(t, []string{"main", "web"}, replacement.Indexes)
+	assert.Equal(t, "nginx", *replacement.Source)
+	assert.Equal(t, "access", *replacement.Sourcetype)
+}
+
+func TestTokenManagerRotateAborted(t *testing.T) {
+	rt := &tokensRT{tokens: map[string]HecTokenAccessResponse{"web": {Name: strPtr("web")}}}
+	manager := newTestTokenService(t, rt).NewTokenManager(0, func(ctx context.Context, rotation TokenRotation) error {
+		return errors.New("redeploy failed")
+	})
+	created, err := manager.Rotate(context.Background(), "web", "web-2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "redeploy failed")
+	assert.Equal(t, "web-2", *created.Name)
+	assert.Equal(t, []string{"web", "web-2"}, rt.names(), "the old token is kept")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	manager = newTestTokenService(t, rt).NewTokenManager(time.Hour, nil)
+	_, err = manager.Rotate(ctx, "web", "web-3")
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = manager.Rotate(context.Background(), "missing", "missing-2")
+	assert.True(t, errors.Is(err, services.ErrNotFound))
+	_, err = manager.Rotate(context.Background(), "web", "web")
+	assert.Error(t, err)
+}
+
+func TestTokenManagerReconcile(t *testing.T) {
+	rt := &tokensRT{tokens: map[string]HecTokenAccessResponse{
+		"same":    {Name: strPtr("same"), Index: strPtr("main"), Indexes: []string{"b", "a"}},
+		"drifted": {Name: strPtr("drifted"), Index: strPtr("old")},
+	}}
+	for i := 0; i < listCollectorTokensPageSize; i++ {
+		name := "extra-" + strconv.Itoa(i)
+		rt.tokens[name] = HecTokenAccessResponse{Name: &name}
+	}
+	specs, err := ParseTokenSpecs([]byte(`
+tokens:
+  - name: same
+    index: main
+    indexes: [a, b]
+  - name: drifted
+    index: new
+    source: app
+  - name: missing
+    sourcetype: json
+    ack_enabled: true
+`))
+	require.NoError(t, err)
+	manager := newTestTokenService(t, rt).NewTokenManager(0, nil)
+
+	plan, err := manager.Reconcile(context.Background(), specs, &ReconcileOptions{Prune: true, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, plan.Created)
+	assert.Equal(t, []string{"drifted"}, plan.Updated)
+	assert.Equal(t, []string{"same"}, plan.Unchanged)
+	assert.Len(t, plan.Deleted, listCollectorTokensPageSize, "tokens are listed across pages")
+	assert.Len(t, rt.names(), listCollectorTokensPageSize+2, "nothing is changed by a dry run")
+
+	result, err := manager.Reconcile(context.Background(), specs, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"missing"}, result.Created)
+	assert.Equal(t, map[string]string{"missing": "value-missing"}, result.Tokens)
+	assert.Empty(t, result.Deleted, "undeclared tokens are kept without prune")
+	assert.Equal(t, "new", *rt.tokens["drifted"].Index)
+	assert.Equal(t, "app", *rt.tokens["drifted"].Source)
+	assert.True(t, *rt.tokens["missing"].AckEnabled)
+
+	result, err = manager.Reconcile(context.Background(), specs, &ReconcileOptions{Prune: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"drifted", "missing", "same"}, sortedStrings(result.Unchanged))
+	assert.Equal(t, []string{"drifted", "missing", "same"}, rt.names())
+}
+
+func sortedStrings(s []string) []string {
+	sorted := append([]string(nil), s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestParseTokenSpecs(t *testing.T) {
+	_, err := ParseTokenSpecs([]byte("tokens:\n  - index: main\n"))
+	assert.Error(t, err)
+	_, err = ParseTokenSpecs([]byte("tokens:\n  - name: a\n  - name: a\n"))
+	assert.Error(t, err)
+	_, err = ParseTokenSpecs([]byte("tokens: {"))
+	assert.Error(t, err)
+	specs, err := ParseTokenSpecs([]byte("tokens:\n  - name: a\n    disabled: true\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []TokenSpec{{Name: "a", Disabled: func() *bool { b := true; return &b }()}}, specs)
+}
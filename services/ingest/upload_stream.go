@@ -0,0 +1,267 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/util"
+)
+
+const (
+	// MaxUploadBytes is the maximum size of a file accepted by the files endpoint
+	MaxUploadBytes = 1 << 20
+	// DefaultUploadFilename is the name of the file uploaded by UploadFilesStream unless configured otherwise
+	DefaultUploadFilename = "stream"
+
+	defaultUploadRetries       = 3
+	defaultUploadRetryInterval = 500 * time.Millisecond
+)
+
+// UploadOptions configures UploadFilesStreamWithContext
+type UploadOptions struct {
+	// Filename is the name of the uploaded file, DefaultUploadFilename if empty
+	Filename string
+	// ChunkBytes is the maximum size of a single upload, MaxUploadBytes if zero. Larger streams are split into
+	// chunks at line boundaries so that no event is split, only lines longer than ChunkBytes are.
+	ChunkBytes int
+	// RepeatHeader repeats the first line of the stream, e.g. the header of a CSV file, at the beginning of every chunk
+	RepeatHeader bool
+	// Gzip compresses the requests
+	Gzip bool
+	// MaxRetries is the number of times the upload of a chunk is retried when it fails with a connection error or a
+	// throttling or gateway error response, 3 if zero and none if negative. A chunk rejected with a 401 response is
+	// sent once more, with the token renewed by the client, regardless.
+	MaxRetries int
+	// RetryInterval is the wait before the first retry of a chunk, doubled for every retry after it, 500ms if zero
+	RetryInterval time.Duration
+	// Progress is called as the stream is uploaded, from the goroutine writing the requests
+	Progress func(UploadProgress)
+}
+
+// UploadProgress reports the progress of an upload
+type UploadProgress struct {
+	// Bytes is the number of bytes of the stream uploaded so far, including the ones of the chunk being uploaded
+	Bytes int64
+	// Chunks is the number of chunks successfully uploaded so far
+	Chunks int
+	// Attempt is the attempt of the chunk being uploaded, starting at 1
+	Attempt int
+}
+
+/*
+UploadFilesStream - Upload stream of io.Reader.
+Parameters:
+
+	stream
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UploadFilesStream(stream io.Reader, resp ...*http.Response) error {
+	return s.UploadFilesStreamWithContext(context.Background(), stream, nil, resp...)
+}
+
+/*
+UploadFilesStreamWithContext - Upload stream of io.Reader. The multipart request body is streamed as it's sent,
+only the chunk being uploaded is held in memory so that it can be retried. Streams larger than the file limit are
+uploaded in several chunks, in order, see UploadOptions.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	stream
+	opts: the upload options, the defaults are used if nil
+	resp: an optional pointer to a http.Response to be populated by this method, with the response of the last chunk uploaded. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UploadFilesStreamWithContext(ctx context.Context, stream io.Reader, opts *UploadOptions, resp ...*http.Response) error {
+	return s.uploadChunks(sdkservices.WithOperation(ctx, "ingest", "UploadFilesStream"), stream, opts, resp...)
+}
+
+// uploadChunks uploads stream in chunks, see UploadFilesStreamWithContext
+func (s *Service) uploadChunks(ctx context.Context, stream io.Reader, opts *UploadOptions, resp ...*http.Response) error {
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ingest/v1beta2/files`, nil)
+	if err != nil {
+		return err
+	}
+	var o UploadOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Filename == "" {
+		o.Filename = DefaultUploadFilename
+	}
+	if o.ChunkBytes <= 0 {
+		o.ChunkBytes = MaxUploadBytes
+	}
+	if o.MaxRetries == 0 {
+		o.MaxRetries = defaultUploadRetries
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = defaultUploadRetryInterval
+	}
+	headers := map[string]string{"Content-Type": "multipart/form-data"}
+	if o.Gzip {
+		headers["Content-Encoding"] = "gzip"
+	}
+
+	chunks := &chunker{r: stream, size: o.ChunkBytes, repeatHeader: o.RepeatHeader}
+	var progress UploadProgress
+	for {
+		chunk, data, err := chunks.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		reauthenticated := false
+		for attempt := 1; ; attempt++ {
+			progress.Attempt = attempt
+			body := &progressReader{r: bytes.NewReader(chunk), skip: len(chunk) - data, progress: progress, report: o.Progress}
+			form := services.FormData{Filename: o.Filename, Stream: body, Key: "upfile"}
+			multipartResp, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: form, Headers: headers})
+			if multipartResp != nil {
+				multipartResp.Body.Close()
+				// populate input *http.Response if provided
+				if len(resp) > 0 && resp[0] != nil {
+					*resp[0] = *multipartResp
+				}
+			}
+			if err == nil {
+				break
+			}
+			// The client renews a rejected token but can't send the chunk again itself, it's sent once more right away
+			if !reauthenticated && unauthorizedUpload(err) {
+				reauthenticated = true
+				continue
+			}
+			if attempt > o.MaxRetries || !retryableUpload(err) {
+				return fmt.Errorf("ingest: upload of chunk %d failed after %d attempts: %w", progress.Chunks+1, attempt, err)
+			}
+			timer := time.NewTimer(o.RetryInterval << (attempt - 1))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+		progress.Bytes += int64(data)
+		progress.Chunks++
+		if o.Progress != nil {
+			o.Progress(progress)
+		}
+	}
+}
+
+// retryableUpload returns whether the upload failed with a connection error or a throttling or gateway error response
+func retryableUpload(err error) bool {
+	var httpErr *util.HTTPError
+	if errors.As(err, &httpErr) {
+		return sdkservices.ClassifyRetry(nil, &http.Response{StatusCode: httpErr.HTTPStatusCode}) != sdkservices.NotRetryable
+	}
+	return sdkservices.ClassifyRetry(err, nil) != sdkservices.NotRetryable
+}
+
+// unauthorizedUpload returns whether the upload failed with a 401 response
+func unauthorizedUpload(err error) bool {
+	var httpErr *util.HTTPError
+	return errors.As(err, &httpErr) && httpErr.HTTPStatusCode == http.StatusUnauthorized
+}
+
+// chunker splits a stream into chunks of at most size bytes at line boundaries
+type chunker struct {
+	r            io.Reader
+	size         int
+	repeatHeader bool
+
+	header     []byte
+	headerRead bool
+	// pending holds the bytes read past the end of the previous chunk
+	pending []byte
+	eof     bool
+}
+
+// next returns the next chunk, starting with the repeated header if any, and the number of bytes of the stream
+// it holds. It returns io.EOF once the stream was read entirely.
+func (c *chunker) next() ([]byte, int, error) {
+	if c.eof && len(c.pending) == 0 {
+		return nil, 0, io.EOF
+	}
+	limit := c.size - len(c.header)
+	if limit <= 0 {
+		return nil, 0, errors.New("ingest: the header of the stream is larger than the chunk size")
+	}
+	buf := make([]byte, limit)
+	n := copy(buf, c.pending)
+	c.pending = c.pending[n:]
+	if !c.eof && n < limit {
+		read, err := io.ReadFull(c.r, buf[n:])
+		n += read
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			c.eof = true
+		} else if err != nil {
+			return nil, 0, err
+		}
+	}
+	buf = buf[:n]
+	if n == 0 {
+		return nil, 0, io.EOF
+	}
+	if n == limit && (!c.eof || len(c.pending) > 0) {
+		// Cut after the last complete line, the rest goes to the next chunk
+		if i := bytes.LastIndexByte(buf, '\n'); i >= 0 {
+			c.pending = append(append([]byte{}, buf[i+1:]...), c.pending...)
+			buf = buf[:i+1]
+		}
+	}
+	data := len(buf)
+	if c.repeatHeader && !c.headerRead {
+		c.headerRead = true
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			c.header = append([]byte{}, buf[:i+1]...)
+		}
+		return buf, data, nil
+	}
+	return append(append([]byte{}, c.header...), buf...), data, nil
+}
+
+// progressReader reports the bytes read past the first skip ones, added to progress
+type progressReader struct {
+	r        io.Reader
+	skip     int
+	read     int
+	progress UploadProgress
+	report   func(UploadProgress)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += n
+	if n > 0 && p.report != nil && p.read > p.skip {
+		progress := p.progress
+		progress.Bytes += int64(p.read - p.skip)
+		p.report(progress)
+	}
+	return n, err
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadRT records the files uploaded to it, responding with the next status of statuses or 200
+type uploadRT struct {
+	mux      sync.Mutex
+	statuses []int
+	files    []string
+	gzipped  int
+}
+
+func (rt *uploadRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	status := 200
+	if len(rt.statuses) > 0 {
+		status, rt.statuses = rt.statuses[0], rt.statuses[1:]
+	}
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = gz
+		rt.gzipped++
+	}
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	part, err := multipart.NewReader(body, params["boundary"]).NextPart()
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadAll(part)
+	if err != nil {
+		return nil, err
+	}
+	if status == 200 {
+		rt.files = append(rt.files, part.FileName()+":"+string(content))
+	}
+	respBody := ioutil.NopCloser(bytes.NewReader([]byte(`{"code":"SUCCESS"}`)))
+	return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: respBody, Header: http.Header{}}, nil
+}
+
+func newUploadService(t *testing.T, rt http.RoundTripper) *Service {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, TypedErrors: true})
+	require.NoError(t, err)
+	return NewService(client)
+}
+
+func TestUploadFilesStream(t *testing.T) {
+	rt := &uploadRT{}
+	var resp http.Response
+	require.NoError(t, newUploadService(t, rt).UploadFilesStream(strings.NewReader("a\nb\n"), &resp))
+	assert.Equal(t, []string{"stream:a\nb\n"}, rt.files)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestUploadFilesStreamChunks(t *testing.T) {
+	rt := &uploadRT{}
+	stream := "h1,h2\n1,a\n2,b\n3,c\n4,dddddddddddddd\n5,e"
+	var progress []UploadProgress
+	opts := &UploadOptions{
+		Filename:     "events.csv",
+		ChunkBytes:   16,
+		RepeatHeader: true,
+		Gzip:         true,
+		Progress:     func(p UploadProgress) { progress = append(progress, p) },
+	}
+	require.NoError(t, newUploadService(t, rt).UploadFilesStreamWithContext(context.Background(), strings.NewReader(stream), opts))
+	assert.Equal(t, []string{
+		"events.csv:h1,h2\n1,a\n2,b\n",
+		"events.csv:h1,h2\n3,c\n",
+		// the line is longer than the chunk, it's split
+		"events.csv:h1,h2\n4,dddddddd",
+		"events.csv:h1,h2\ndddddd\n",
+		"events.csv:h1,h2\n5,e",
+	}, rt.files)
+	assert.Equal(t, 5, rt.gzipped)
+	require.NotEmpty(t, progress)
+	last := progress[len(progress)-1]
+	assert.Equal(t, UploadProgress{Bytes: int64(len(stream)), Chunks: 5, Attempt: 1}, last)
+	for i := 1; i < len(progress); i++ {
+		assert.GreaterOrEqual(t, progress[i].Bytes, progress[i-1].Bytes)
+	}
+
+	// The header doesn't leave room for any data
+	chunks := &chunker{r: strings.NewReader("h\nabc\n"), size: 2, repeatHeader: true}
+	_, _, err := chunks.next()
+	require.NoError(t, err)
+	_, _, err = chunks.next()
+	assert.EqualError(t, err, "ingest: the header of the stream is larger than the chunk size")
+}
+
+func TestUploadFilesStreamRetries(t *testing.T) {
+	rt := &uploadRT{statuses: []int{200, 503, 429}}
+	opts := &UploadOptions{ChunkBytes: 4, RetryInterval: time.Millisecond}
+	var attempts []int
+	opts.Progress = func(p UploadProgress) { attempts = append(attempts, p.Attempt) }
+	require.NoError(t, newUploadService(t, rt).UploadFilesStreamWithContext(context.Background(), strings.NewReader("a\nb\nc\n"), opts))
+	assert.Equal(t, []string{"stream:a\nb\n", "stream:c\n"}, rt.files)
+	assert.Equal(t, 3, attempts[len(attempts)-1])
+
+	// Client errors are not retried
+	rt = &uploadRT{statuses: []int{400}}
+	err := newUploadService(t, rt).UploadFilesStreamWithContext(context.Background(), strings.NewReader("a\n"), opts)
+	assert.True(t, errors.Is(err, services.ErrValidation))
+	assert.Contains(t, err.Error(), "failed after 1 attempts")
+
+	// Nor when retries are disabled
+	rt = &uploadRT{statuses: []int{503}}
+	opts.MaxRetries = -1
+	err = newUploadService(t, rt).UploadFilesStreamWithContext(context.Background(), strings.NewReader("a\n"), opts)
+	assert.Error(t, err)
+	assert.Empty(t, rt.statuses)
+
+	// A chunk rejected with a 401 response is sent once more regardless, after the client renewed the token
+	rt = &uploadRT{statuses: []int{401}}
+	require.NoError(t, newUploadService(t, rt).UploadFilesStreamWithContext(context.Background(), strings.NewReader("a\n"), opts))
+	assert.Equal(t, []string{"stream:a\n"}, rt.files)
+	rt = &uploadRT{statuses: []int{401, 401, 401}}
+	err = newUploadService(t, rt).UploadFilesStreamWithContext(context.Background(), strings.NewReader("a\n"), opts)
+	assert.True(t, errors.Is(err, services.ErrUnauthorized))
+	assert.Equal(t, []int{401}, rt.statuses)
+}
+
+func TestUploadFilesRetries(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.log")
+	require.NoError(t, os.WriteFile(filename, []byte("a\nb\n"), 0600))
+	rt := &uploadRT{statuses: []int{429}}
+	retryConfig := services.RetryStrategyConfig{ConfigurableRetryConfig: &services.ConfigurableRetryConfig{RetryNum: 1, Interval: 1}}
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, RetryRequests: true, RetryConfig: retryConfig})
+	require.NoError(t, err)
+	// The file is sent again from its beginning
+	require.NoError(t, NewService(client).UploadFiles(filename))
+	assert.Equal(t, []string{"events.log:a\nb\n"}, rt.files)
+
+	// Logging the bodies of the requests doesn't read the file being sent
+	var logs bytes.Buffer
+	rt = &uploadRT{statuses: []int{429}}
+	client, err = services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, RetryRequests: true, RetryConfig: retryConfig,
+		Logger: slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})), LogBodies: true})
+	require.NoError(t, err)
+	require.NoError(t, NewService(client).UploadFiles(filename))
+	assert.Equal(t, []string{"events.log:a\nb\n"}, rt.files)
+	assert.Equal(t, 2, strings.Count(logs.String(), `"body":"`+services.Streamed+`"`), "both attempts are logged")
+}
+
+func TestUploadFilesChunks(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.csv")
+	line := strings.Repeat("x", 99) + "\n"
+	rows := MaxUploadBytes/len(line) + 100
+	require.NoError(t, os.WriteFile(filename, []byte("h1\n"+strings.Repeat(line, rows)), 0600))
+	rt := &uploadRT{}
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt})
+	require.NoError(t, err)
+	require.NoError(t, NewService(client).UploadFilesWithContext(context.Background(), filename))
+	require.Len(t, rt.files, 2, "the file is larger than the file limit")
+	sent := 0
+	for _, file := range rt.files {
+		assert.True(t, strings.HasPrefix(file, "events.csv:h1\n"+line), "the header is repeated in every chunk")
+		assert.LessOrEqual(t, len(file)-len("events.csv:"), MaxUploadBytes)
+		sent += strings.Count(file, line)
+	}
+	assert.Equal(t, rows, sent)
+}
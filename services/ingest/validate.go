@@ -0,0 +1,452 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+// MaxPayloadBytes is the maximum size of the JSON body of a request to the events and metrics endpoints, the
+// default payload size of the batch senders which stays under the 1MiB limit of the service
+const MaxPayloadBytes = payLoadSize
+
+// Attributes added to the events changed by the TruncateOversize and FragmentOversize policies
+const (
+	TruncatedAttribute     = "truncated"
+	FragmentIDAttribute    = "fragment_id"
+	FragmentIndexAttribute = "fragment_index"
+	FragmentCountAttribute = "fragment_count"
+)
+
+// Limits are the limits enforced by a Validator, zero values are not enforced
+type Limits struct {
+	// MaxPayloadBytes is the maximum size of a request body, a JSON array of events
+	MaxPayloadBytes int
+	// MaxEventBytes is the maximum size of an event encoded in JSON, it must leave room for the brackets of the
+	// payload
+	MaxEventBytes int
+	// MaxFieldBytes is the maximum size of the host, source, sourcetype and ID of an event
+	MaxFieldBytes int
+	// MaxAttributeKeyBytes is the maximum size of the keys of attributes and dimensions
+	MaxAttributeKeyBytes int
+	// MaxTimestampAge is how far in the past timestamps can be
+	MaxTimestampAge time.Duration
+	// MaxTimestampSkew is how far in the future timestamps can be
+	MaxTimestampSkew time.Duration
+}
+
+// DefaultLimits are the limits used by Validate and ValidateMetricEvent. The payload size is the one of the batch
+// senders, the other limits are heuristics of the SDK rather than documented limits of the ingest service, meant
+// to catch the events it would likely reject or mis-index: fields of up to 1024 bytes, attribute keys of up to 256
+// bytes and timestamps up to 2000 days in the past and 2 days in the future. Use a Validator with other Limits if
+// they don't suit your events.
+var DefaultLimits = Limits{
+	MaxPayloadBytes:      MaxPayloadBytes,
+	MaxEventBytes:        MaxPayloadBytes - len("[]"),
+	MaxFieldBytes:        1024,
+	MaxAttributeKeyBytes: 256,
+	MaxTimestampAge:      2000 * 24 * time.Hour,
+	MaxTimestampSkew:     2 * 24 * time.Hour,
+}
+
+// OversizePolicy is what a Validator does with events larger than MaxEventBytes
+type OversizePolicy int
+
+const (
+	// RejectOversize rejects oversized events
+	RejectOversize OversizePolicy = iota
+	// TruncateOversize truncates the body of oversized events, as a string, and sets their TruncatedAttribute
+	TruncateOversize
+	// FragmentOversize splits the body of oversized events, as a string, into several events which can be
+	// reassembled with their FragmentIDAttribute, FragmentIndexAttribute and FragmentCountAttribute
+	FragmentOversize
+)
+
+// ValidationError is returned for events which are rejected, it matches services.ErrValidation like the
+// responses of the ingest service rejecting events
+type ValidationError struct {
+	// Field is the JSON path of the invalid field e.g. attributes.key
+	Field string
+	// Reason is why the field is invalid
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("ingest: invalid %s: %s", e.Field, e.Reason)
+}
+
+// Is returns whether target is services.ErrValidation
+func (e *ValidationError) Is(target error) bool {
+	return target == sdkservices.ErrValidation
+}
+
+// Validator checks events against the limits of the ingest service before they are sent, so that a single invalid
+// event doesn't fail a whole batch, and splits events into batches of the exact payload size:
+//
+//	v := ingest.NewValidator(ingest.DefaultLimits, ingest.FragmentOversize)
+//	events, err := v.Events(events)
+//	...
+//	batches, err := v.Batches(events)
+//	for _, batch := range batches {
+//		_, err := client.IngestService.PostEvents(batch)
+//		...
+//	}
+//
+// The bodies of oversized events are encoded as JSON strings when they are truncated or fragmented, unless they
+// already are strings.
+type Validator struct {
+	Limits Limits
+	Policy OversizePolicy
+
+	// now returns the current time, time.Now if nil
+	now func() time.Time
+}
+
+// NewValidator creates a Validator enforcing limits, and policy for oversized events
+func NewValidator(limits Limits, policy OversizePolicy) *Validator {
+	return &Validator{Limits: limits, Policy: policy}
+}
+
+// Validate checks event against DefaultLimits, it's rejected if oversized
+func Validate(event Event) error {
+	_, err := NewValidator(DefaultLimits, RejectOversize).Event(event)
+	return err
+}
+
+// ValidateMetricEvent checks event against DefaultLimits
+func ValidateMetricEvent(event MetricEvent) error {
+	return NewValidator(DefaultLimits, RejectOversize).MetricEvent(event)
+}
+
+// Event checks event and returns the events to send in its place according to the policy: event itself, a
+// truncated copy or its fragments. It returns a *ValidationError if event is rejected.
+func (v *Validator) Event(event Event) ([]Event, error) {
+	if event.Body == nil {
+		return nil, &ValidationError{Field: "body", Reason: "is required"}
+	}
+	if _, err := json.Marshal(event.Body); err != nil {
+		return nil, &ValidationError{Field: "body", Reason: err.Error()}
+	}
+	for field, value := range map[string]*string{"host": event.Host, "source": event.Source, "sourcetype": event.Sourcetype, "id": event.Id} {
+		if err := v.checkField(field, value); err != nil {
+			return nil, err
+		}
+	}
+	for key, value := range event.Attributes {
+		if err := v.checkKey("attributes", key); err != nil {
+			return nil, err
+		}
+		if _, err := json.Marshal(value); err != nil {
+			return nil, &ValidationError{Field: "attributes." + key, Reason: err.Error()}
+		}
+	}
+	if err := v.checkTimestamp(event.Timestamp, event.Nanos); err != nil {
+		return nil, err
+	}
+	size, err := encodedSize(event)
+	if err != nil {
+		return nil, &ValidationError{Field: "event", Reason: err.Error()}
+	}
+	if v.Limits.MaxEventBytes <= 0 || size <= v.Limits.MaxEventBytes {
+		return []Event{event}, nil
+	}
+	switch v.Policy {
+	case TruncateOversize:
+		return v.truncate(event, size)
+	case FragmentOversize:
+		return v.fragment(event, size)
+	}
+	return nil, v.oversized(size)
+}
+
+// MetricEvent checks event, metric events are never truncated or fragmented. It returns a *ValidationError if
+// event is rejected.
+func (v *Validator) MetricEvent(event MetricEvent) error {
+	if len(event.Body) == 0 {
+		return &ValidationError{Field: "body", Reason: "is required"}
+	}
+	for field, value := range map[string]*string{"host": event.Host, "source": event.Source, "sourcetype": event.Sourcetype, "id": event.Id} {
+		if err := v.checkField(field, value); err != nil {
+			return err
+		}
+	}
+	if event.Attributes != nil {
+		for key := range event.Attributes.DefaultDimensions {
+			if err := v.checkKey("attributes.defaultDimensions", key); err != nil {
+				return err
+			}
+		}
+	}
+	for i, metric := range event.Body {
+		if metric.Name == "" {
+			return &ValidationError{Field: fmt.Sprintf("body[%d].name", i), Reason: "is required"}
+		}
+		for key := range metric.Dimensions {
+			if err := v.checkKey(fmt.Sprintf("body[%d].dimensions", i), key); err != nil {
+				return err
+			}
+		}
+	}
+	if err := v.checkTimestamp(event.Timestamp, event.Nanos); err != nil {
+		return err
+	}
+	size, err := encodedSize(event)
+	if err != nil {
+		return &ValidationError{Field: "event", Reason: err.Error()}
+	}
+	if v.Limits.MaxEventBytes > 0 && size > v.Limits.MaxEventBytes {
+		return v.oversized(size)
+	}
+	return nil
+}
+
+// Events checks events and returns the events to send in their place, see Event. It returns the error of the
+// first event rejected, if any.
+func (v *Validator) Events(events []Event) ([]Event, error) {
+	valid := make([]Event, 0, len(events))
+	for i, event := range events {
+		checked, err := v.Event(event)
+		if err != nil {
+			return nil, fmt.Errorf("event %d: %w", i, err)
+		}
+		valid = append(valid, checked...)
+	}
+	return valid, nil
+}
+
+// Batches splits events, in order, into batches whose JSON encoding doesn't exceed MaxPayloadBytes. The events
+// must have been validated, an error is returned for events which don't fit in a batch on their own.
+func (v *Validator) Batches(events []Event) ([][]Event, error) {
+	var batches [][]Event
+	var current []Event
+	currentSize := 0
+	for i, event := range events {
+		size, err := encodedSize(event)
+		if err != nil {
+			return nil, &ValidationError{Field: fmt.Sprintf("events[%d]", i), Reason: err.Error()}
+		}
+		if v.Limits.MaxPayloadBytes > 0 && batchSize(currentSize, len(current), size) > v.Limits.MaxPayloadBytes {
+			if len(current) == 0 {
+				return nil, &ValidationError{Field: fmt.Sprintf("events[%d]", i), Reason: fmt.Sprintf("is %d bytes, larger than the %d bytes payload limit", size, v.Limits.MaxPayloadBytes)}
+			}
+			batches = append(batches, current)
+			current, currentSize = nil, 0
+		}
+		currentSize = batchSize(currentSize, len(current), size)
+		current = append(current, event)
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches, nil
+}
+
+// batchSize returns the size of the JSON array of n events of size total once an event of size is added to it
+func batchSize(total, n, size int) int {
+	if n == 0 {
+		return size + len("[]")
+	}
+	return total + size + len(",")
+}
+
+// encodedSize returns the size of the JSON encoding of v, the way it's sent
+func encodedSize(v interface{}) (int, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (v *Validator) oversized(size int) error {
+	return &ValidationError{Field: "event", Reason: fmt.Sprintf("is %d bytes, larger than the %d bytes limit", size, v.Limits.MaxEventBytes)}
+}
+
+func (v *Validator) checkField(field string, value *string) error {
+	if value != nil && v.Limits.MaxFieldBytes > 0 && len(*value) > v.Limits.MaxFieldBytes {
+		return &ValidationError{Field: field, Reason: fmt.Sprintf("is %d bytes, larger than the %d bytes limit", len(*value), v.Limits.MaxFieldBytes)}
+	}
+	return nil
+}
+
+// checkKey checks the key of an attribute or a dimension: it must be valid UTF-8 without spaces or control
+// characters, and must not start with an underscore. Like the limits of DefaultLimits, these are heuristics of the
+// SDK: leading underscores are used by internal fields such as _time and _raw, which such keys would clash with.
+func (v *Validator) checkKey(field, key string) error {
+	invalid := func(reason string) error {
+		return &ValidationError{Field: field, Reason: fmt.Sprintf("key %q %s", key, reason)}
+	}
+	switch {
+	case key == "":
+		return &ValidationError{Field: field, Reason: "keys can't be empty"}
+	case v.Limits.MaxAttributeKeyBytes > 0 && len(key) > v.Limits.MaxAttributeKeyBytes:
+		return invalid(fmt.Sprintf("is larger than the %d bytes limit", v.Limits.MaxAttributeKeyBytes))
+	case !utf8.ValidString(key):
+		return invalid("is not valid UTF-8")
+	case strings.HasPrefix(key, "_"):
+		return invalid("starts with an underscore, which is reserved")
+	case strings.IndexFunc(key, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0:
+		return invalid("contains spaces or control characters")
+	}
+	return nil
+}
+
+func (v *Validator) checkTimestamp(timestamp *int64, nanos *int32) error {
+	if nanos != nil && (*nanos < 0 || *nanos >= int32(time.Millisecond)) {
+		return &ValidationError{Field: "nanos", Reason: fmt.Sprintf("%d is not between 0 and 999999", *nanos)}
+	}
+	if timestamp == nil {
+		return nil
+	}
+	if *timestamp < 0 {
+		return &ValidationError{Field: "timestamp", Reason: fmt.Sprintf("%d is negative", *timestamp)}
+	}
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	t, current := time.UnixMilli(*timestamp), now()
+	if v.Limits.MaxTimestampAge > 0 && current.Sub(t) > v.Limits.MaxTimestampAge {
+		return &ValidationError{Field: "timestamp", Reason: fmt.Sprintf("%s is more than %s in the past", t.UTC().Format(time.RFC3339), v.Limits.MaxTimestampAge)}
+	}
+	if v.Limits.MaxTimestampSkew > 0 && t.Sub(current) > v.Limits.MaxTimestampSkew {
+		return &ValidationError{Field: "timestamp", Reason: fmt.Sprintf("%s is more than %s in the future", t.UTC().Format(time.RFC3339), v.Limits.MaxTimestampSkew)}
+	}
+	return nil
+}
+
+// bodyText returns the body of event as a string, its JSON encoding unless it's a string
+func bodyText(event Event) string {
+	if s, ok := event.Body.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(event.Body)
+	return string(data)
+}
+
+// truncate returns event with its body truncated to fit in MaxEventBytes
+func (v *Validator) truncate(event Event, size int) ([]Event, error) {
+	truncated := withAttributes(event, map[string]interface{}{TruncatedAttribute: true})
+	truncated.Body = ""
+	room, err := v.bodyRoom(truncated)
+	if err != nil {
+		return nil, v.oversized(size)
+	}
+	truncated.Body, _ = cutJSONString(bodyText(event), room)
+	return []Event{truncated}, nil
+}
+
+// fragment returns the fragments of event, each fitting in MaxEventBytes
+func (v *Validator) fragment(event Event, size int) ([]Event, error) {
+	text := bodyText(event)
+	sum := sha256.Sum256([]byte(text))
+	id := hex.EncodeToString(sum[:16])
+	// The room for the body is computed with the largest index and count possible, the count being unknown yet
+	probe := withAttributes(event, map[string]interface{}{FragmentIDAttribute: id, FragmentIndexAttribute: len(text), FragmentCountAttribute: len(text)})
+	probe.Body = ""
+	if probe.Id != nil {
+		probeID := fmt.Sprintf("%s-%d", *probe.Id, len(text))
+		probe.Id = &probeID
+	}
+	room, err := v.bodyRoom(probe)
+	if err != nil {
+		return nil, v.oversized(size)
+	}
+	var parts []string
+	for rest := text; rest != ""; {
+		var part string
+		part, rest = cutJSONString(rest, room)
+		parts = append(parts, part)
+	}
+	fragments := make([]Event, len(parts))
+	for i, part := range parts {
+		fragment := withAttributes(event, map[string]interface{}{FragmentIDAttribute: id, FragmentIndexAttribute: i, FragmentCountAttribute: len(parts)})
+		fragment.Body = part
+		if event.Id != nil {
+			fragmentID := fmt.Sprintf("%s-%d", *event.Id, i)
+			fragment.Id = &fragmentID
+		}
+		fragments[i] = fragment
+	}
+	return fragments, nil
+}
+
+// bodyRoom returns the size of the encoded body which can be added to event, whose body is empty, within
+// MaxEventBytes. The encoded body includes its quotes.
+func (v *Validator) bodyRoom(event Event) (int, error) {
+	size, err := encodedSize(event)
+	if err != nil {
+		return 0, err
+	}
+	// The empty body is 2 bytes, and at least one character must fit
+	room := v.Limits.MaxEventBytes - size + len(`""`)
+	if room < len(`"\u0000"`) {
+		return 0, errors.New("no room left for the body")
+	}
+	return room, nil
+}
+
+// withAttributes returns a copy of event with attributes added to its own
+func withAttributes(event Event, attributes map[string]interface{}) Event {
+	merged := make(map[string]interface{}, len(event.Attributes)+len(attributes))
+	for k, v := range event.Attributes {
+		merged[k] = v
+	}
+	for k, v := range attributes {
+		merged[k] = v
+	}
+	event.Attributes = merged
+	return event
+}
+
+// cutJSONString returns the longest prefix of s whose JSON encoding, quotes included, doesn't exceed size bytes,
+// and the rest of s. s is cut at rune boundaries.
+func cutJSONString(s string, size int) (string, string) {
+	n := len(`""`)
+	for i := 0; i < len(s); {
+		r, width := utf8.DecodeRuneInString(s[i:])
+		if n+jsonRuneSize(r, width) > size {
+			return s[:i], s[i:]
+		}
+		n += jsonRuneSize(r, width)
+		i += width
+	}
+	return s, ""
+}
+
+// jsonRuneSize returns the size of r, of width bytes in UTF-8, once encoded in a JSON string by encoding/json
+func jsonRuneSize(r rune, width int) int {
+	if r < utf8.RuneSelf && r >= ' ' && r != '"' && r != '\\' && r != '<' && r != '>' && r != '&' {
+		return 1
+	}
+	if r == utf8.RuneError && width == 1 {
+		// Invalid bytes are replaced with \ufffd
+		return len(`\ufffd`)
+	}
+	data, _ := json.Marshal(string(r))
+	return len(data) - len(`""`)
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEvent(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	v := NewValidator(DefaultLimits, RejectOversize)
+	v.now = func() time.Time { return now }
+	ms := func(t time.Time) *int64 {
+		timestamp := t.UnixMilli()
+		return &timestamp
+	}
+	nanos := func(n int32) *int32 { return &n }
+	long := strings.Repeat("h", 1025)
+
+	valid := Event{Body: map[string]interface{}{"a": 1}, Attributes: map[string]interface{}{"service.name": "x"}, Timestamp: ms(now), Nanos: nanos(999999)}
+	events, err := v.Event(valid)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{valid}, events)
+
+	for field, event := range map[string]Event{
+		"body":         {},
+		"body ":        {Body: make(chan int)},
+		"host":         {Body: "a", Host: &long},
+		"attributes":   {Body: "a", Attributes: map[string]interface{}{"_internal": 1}},
+		"attributes.a": {Body: "a", Attributes: map[string]interface{}{"a": func() {}}},
+		"timestamp":    {Body: "a", Timestamp: ms(now.Add(-2001 * 24 * time.Hour))},
+		"timestamp ":   {Body: "a", Timestamp: ms(now.Add(49 * time.Hour))},
+		"nanos":        {Body: "a", Nanos: nanos(1000000)},
+	} {
+		_, err := v.Event(event)
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr), field)
+		assert.Equal(t, strings.TrimSpace(field), validationErr.Field)
+		assert.True(t, errors.Is(err, services.ErrValidation), "validation errors match the server-side ones")
+	}
+	for _, key := range []string{"", "a b", "a\tb", strings.Repeat("k", 257), "\xff"} {
+		_, err := v.Event(Event{Body: "a", Attributes: map[string]interface{}{key: 1}})
+		assert.Error(t, err, "key %q", key)
+	}
+	assert.NoError(t, Validate(Event{Body: "a"}))
+}
+
+func TestValidateOversizePolicies(t *testing.T) {
+	limits := Limits{MaxEventBytes: 200}
+	id := "id"
+	event := Event{Body: strings.Repeat("é<", 100), Id: &id, Attributes: map[string]interface{}{"k": "v"}}
+	size, err := encodedSize(event)
+	require.NoError(t, err)
+	require.Greater(t, size, 200)
+
+	_, err = NewValidator(limits, RejectOversize).Event(event)
+	assert.True(t, errors.Is(err, services.ErrValidation))
+
+	truncated, err := NewValidator(limits, TruncateOversize).Event(event)
+	require.NoError(t, err)
+	require.Len(t, truncated, 1)
+	assert.Equal(t, true, truncated[0].Attributes[TruncatedAttribute])
+	assert.Equal(t, "v", truncated[0].Attributes["k"])
+	assert.True(t, strings.HasPrefix(event.Body.(string), truncated[0].Body.(string)))
+	size, err = encodedSize(truncated[0])
+	require.NoError(t, err)
+	assert.LessOrEqual(t, size, 200)
+	assert.Nil(t, event.Attributes[TruncatedAttribute], "the event is not modified")
+
+	fragments, err := NewValidator(limits, FragmentOversize).Event(event)
+	require.NoError(t, err)
+	require.Greater(t, len(fragments), 1)
+	var body strings.Builder
+	for i, fragment := range fragments {
+		size, err := encodedSize(fragment)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, size, 200)
+		assert.Equal(t, fragments[0].Attributes[FragmentIDAttribute], fragment.Attributes[FragmentIDAttribute])
+		assert.Equal(t, i, fragment.Attributes[FragmentIndexAttribute])
+		assert.Equal(t, len(fragments), fragment.Attributes[FragmentCountAttribute])
+		assert.NotEqual(t, id, *fragment.Id, "fragments are not deduplicated")
+		body.WriteString(fragment.Body.(string))
+	}
+	assert.Equal(t, event.Body, body.String())
+
+	// Bodies which are not strings are fragmented as JSON
+	fragments, err = NewValidator(limits, FragmentOversize).Event(Event{Body: map[string]interface{}{"a": strings.Repeat("x", 300)}})
+	require.NoError(t, err)
+	body.Reset()
+	for _, fragment := range fragments {
+		body.WriteString(fragment.Body.(string))
+	}
+	assert.JSONEq(t, `{"a":"`+strings.Repeat("x", 300)+`"}`, body.String())
+
+	// Events are rejected when their envelope alone is too large
+	_, err = NewValidator(limits, FragmentOversize).Event(Event{Body: "a", Attributes: map[string]interface{}{"k": strings.Repeat("v", 200)}})
+	assert.Error(t, err)
+}
+
+func TestValidateBatches(t *testing.T) {
+	events := []Event{{Body: "e0"}, {Body: "e1"}, {Body: "e2"}}
+	one, err := json.Marshal(events[:1])
+	require.NoError(t, err)
+	two, err := json.Marshal(events[:2])
+	require.NoError(t, err)
+
+	batches, err := NewValidator(Limits{MaxPayloadBytes: len(two)}, RejectOversize).Batches(events)
+	require.NoError(t, err)
+	assert.Equal(t, [][]Event{events[:2], events[2:]}, batches)
+	batches, err = NewValidator(Limits{MaxPayloadBytes: len(two) - 1}, RejectOversize).Batches(events)
+	require.NoError(t, err)
+	assert.Len(t, batches, 3)
+	_, err = NewValidator(Limits{MaxPayloadBytes: len(one) - 1}, RejectOversize).Batches(events)
+	assert.Error(t, err)
+}
+
+func TestValidateMetricEvent(t *testing.T) {
+	value := 1.0
+	assert.NoError(t, ValidateMetricEvent(MetricEvent{Body: []Metric{{Name: "cpu", Value: &value, Dimensions: map[string]string{"host": "a"}}}}))
+	assert.Error(t, ValidateMetricEvent(MetricEvent{}))
+	assert.Error(t, ValidateMetricEvent(MetricEvent{Body: []Metric{{Value: &value}}}))
+	assert.Error(t, ValidateMetricEvent(MetricEvent{Body: []Metric{{Name: "cpu", Dimensions: map[string]string{"a b": "c"}}}}))
+	assert.Error(t, ValidateMetricEvent(MetricEvent{Body: []Metric{{Name: "cpu"}}, Attributes: &MetricAttribute{DefaultDimensions: map[string]string{"_a": "b"}}}))
+}
+
+func TestBatchEventsSenderValidator(t *testing.T) {
+	rt := &ingestRT{}
+	sender, results, mux := newTestSender(t, rt, 100, 0, 1, nil)
+	sender.SetValidator(NewValidator(Limits{MaxEventBytes: 150}, FragmentOversize))
+	ctx := context.Background()
+
+	err := sender.AddEvent(ctx, Event{Body: make(chan int)})
+	assert.True(t, errors.Is(err, services.ErrValidation), "invalid events are rejected when added")
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: "e0"}))
+	require.NoError(t, sender.AddEvent(ctx, Event{Body: strings.Repeat("x", 300)}))
+	require.NoError(t, sender.Close(ctx))
+
+	posted := rt.posted()
+	require.Len(t, posted, 1)
+	assert.Equal(t, "e0", posted[0][0])
+	assert.Greater(t, len(posted[0]), 2)
+	assert.Equal(t, strings.Repeat("x", 300), strings.Join(posted[0][1:], ""))
+	mux.Lock()
+	defer mux.Unlock()
+	for _, r := range *results {
+		assert.NoError(t, r.Err)
+	}
+}
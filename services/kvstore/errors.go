@@ -0,0 +1,30 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kvstore
+
+import (
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+func init() {
+	sdkservices.RegisterErrorPayload("kvstore", func(int) error { return &ErrorResponse{} })
+}
+
+// Error implements the error interface so that the ErrorResponse of a failed call can be retrieved using errors.As
+func (e *ErrorResponse) Error() string {
+	return e.Code + ": " + e.Message
+}
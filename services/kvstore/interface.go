@@ -18,8 +18,21 @@
 
 package kvstore
 
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
 // Servicer represents the interface for implementing all endpoints for this service
 type Servicer interface {
+	//interfaces that cannot be auto-generated from codegen
+	// AllRecords returns an iterator over every record of the collection matching query, requesting pages as they are needed
+	AllRecords(ctx context.Context, collection string, query *ListRecordsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[map[string]interface{}, error]
+
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
@@ -0,0 +1,41 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package kvstore
+
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+// AllRecords returns an iterator over every record of the collection matching query, requesting pages as they
+// are needed. query.Count is used as the page size and query.Offset as the offset of the first record, the page
+// size and concurrency can be overridden by opts.
+func (s *Service) AllRecords(ctx context.Context, collection string, query *ListRecordsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[map[string]interface{}, error] {
+	var q ListRecordsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.Count))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]map[string]interface{}, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetCount(int32(size))
+		records, err := s.ListRecordsWithContext(ctx, collection, &page)
+		return records, -1, err
+	}, opts...).All(ctx)
+}
@@ -0,0 +1,496 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package kvstore
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateIndexWithContext - Creates an index on a collection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	indexDefinition
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateIndexWithContext(ctx context.Context, collection string, indexDefinition IndexDefinition, resp ...*http.Response) (*IndexDescription, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "CreateIndex")
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/indexes`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: indexDefinition})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb IndexDescription
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteIndexWithContext - Removes an index from a collection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	index: The name of the index.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteIndexWithContext(ctx context.Context, collection string, index string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "DeleteIndex")
+	pp := struct {
+		Collection string
+		Index      string
+	}{
+		Collection: collection,
+		Index:      index,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/indexes/{{.Index}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteRecordByKeyWithContext - Deletes a record with a given key.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	key: The key of the record.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteRecordByKeyWithContext(ctx context.Context, collection string, key string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "DeleteRecordByKey")
+	pp := struct {
+		Collection string
+		Key        string
+	}{
+		Collection: collection,
+		Key:        key,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/records/{{.Key}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteRecordsWithContext - Removes records in a collection that match the query.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteRecordsWithContext(ctx context.Context, collection string, query *DeleteRecordsQueryParams, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "DeleteRecords")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/query`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetRecordByKeyWithContext - Returns a record with a given key.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	key: The key of the record.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetRecordByKeyWithContext(ctx context.Context, collection string, key string, resp ...*http.Response) (*map[string]interface{}, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "GetRecordByKey")
+	pp := struct {
+		Collection string
+		Key        string
+	}{
+		Collection: collection,
+		Key:        key,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/records/{{.Key}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb map[string]interface{}
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+InsertRecordWithContext - Inserts a record into a collection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	body: Record to add to the collection, formatted as a JSON object.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) InsertRecordWithContext(ctx context.Context, collection string, body map[string]interface{}, resp ...*http.Response) (*Record, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "InsertRecord")
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: body})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Record
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+InsertRecordsWithContext - Writes multiple records in a single request. If records have duplicate primary keys, only the last duplicate record will be written. If no errors occur, the response array will contain the keys of the written records, in no particular order.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	requestBody: Array of records to insert.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) InsertRecordsWithContext(ctx context.Context, collection string, requestBody []map[string]interface{}, query *InsertRecordsQueryParams, resp ...*http.Response) ([]string, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "InsertRecords")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/batch`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: requestBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []string
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListIndexesWithContext - Returns a list of all indexes on a collection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListIndexesWithContext(ctx context.Context, collection string, resp ...*http.Response) ([]IndexDefinition, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "ListIndexes")
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/indexes`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []IndexDefinition
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListRecordsWithContext - Returns a list of records in a collection with basic filtering, sorting, pagination and field projection.
+Use key-value query parameters to filter fields. Fields are implicitly ANDed and values for the same field are implicitly ORed.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListRecordsWithContext(ctx context.Context, collection string, query *ListRecordsQueryParams, resp ...*http.Response) ([]map[string]interface{}, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "ListRecords")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []map[string]interface{}
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+PingWithContext - Returns the health status from the database.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PingWithContext(ctx context.Context, resp ...*http.Response) (*PingResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "Ping")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/ping`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PingResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PutRecordWithContext - Updates the record with a given key, either by inserting or replacing the record.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	key: The key of the record.
+	body: Record to add to the collection, formatted as a JSON object.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PutRecordWithContext(ctx context.Context, collection string, key string, body map[string]interface{}, resp ...*http.Response) (*Record, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "PutRecord")
+	pp := struct {
+		Collection string
+		Key        string
+	}{
+		Collection: collection,
+		Key:        key,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/records/{{.Key}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: body})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Record
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+QueryRecordsWithContext - Returns a list of query records in a collection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) QueryRecordsWithContext(ctx context.Context, collection string, query *QueryRecordsQueryParams, resp ...*http.Response) ([]map[string]interface{}, error) {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "QueryRecords")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/query`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []map[string]interface{}
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+TruncateRecordsWithContext - Deletes all the records in a collection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	collection: The name of the collection.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) TruncateRecordsWithContext(ctx context.Context, collection string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "kvstore", "TruncateRecords")
+	pp := struct {
+		Collection string
+	}{
+		Collection: collection,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/kvstore/v1beta1/collections/{{.Collection}}/truncate`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
@@ -0,0 +1,317 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Redacted replaces redacted header values, body fields and bodies in log records
+const Redacted = "[REDACTED]"
+
+// Streamed replaces the request bodies which are streamed, such as multipart forms, in log records
+const Streamed = "[STREAMED]"
+
+// maxLoggedBodyBytes is the number of bytes of a request or response body logged, longer bodies are truncated
+// and can't have their fields redacted (they're redacted entirely if there are fields to redact)
+const maxLoggedBodyBytes = 64 << 10
+
+// RedactionRule describes values to remove from the requests and responses logged by BaseClient
+type RedactionRule struct {
+	// Operation restricts the rule to the requests of a single SDK call e.g. Operation{Service: "ingest", Name: "PostCollectorTokens"},
+	// the rule applies to every request if empty
+	Operation Operation
+	// Headers are the names of request and response headers whose values are redacted
+	Headers []string
+	// RequestFields are the names of JSON object fields redacted from request bodies, at any depth
+	RequestFields []string
+	// ResponseFields are the names of JSON object fields redacted from response bodies, at any depth
+	ResponseFields []string
+	// RequestBody redacts request bodies entirely
+	RequestBody bool
+	// ResponseBody redacts response bodies entirely
+	ResponseBody bool
+}
+
+func (r RedactionRule) appliesTo(op Operation, hasOp bool) bool {
+	if r.Operation == (Operation{}) {
+		return true
+	}
+	return hasOp && r.Operation == op
+}
+
+// DefaultRedactionRules returns the rules always applied by BaseClient before logging requests and responses:
+// Authorization headers, HEC tokens, app secrets and passwords are never logged
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{
+		{Headers: []string{"Authorization"}},
+		{Operation: Operation{Service: "ingest", Name: "PostCollectorTokens"}, ResponseFields: []string{"token"}},
+		{Operation: Operation{Service: "appregistry", Name: "CreateApp"}, ResponseFields: []string{"clientSecret"}},
+		{Operation: Operation{Service: "appregistry", Name: "RotateSecret"}, ResponseFields: []string{"clientSecret"}},
+		{Operation: Operation{Service: "identity", Name: "ResetPassword"}, RequestBody: true},
+		{Operation: Operation{Service: "identity", Name: "UpdatePassword"}, RequestBody: true},
+	}
+}
+
+// redaction is the union of the rules applying to a request
+type redaction struct {
+	headers        map[string]bool
+	requestFields  map[string]bool
+	responseFields map[string]bool
+	requestBody    bool
+	responseBody   bool
+}
+
+// requestLogger logs the requests, responses, retries and token refreshes of a BaseClient, a nil *requestLogger logs nothing
+type requestLogger struct {
+	logger    *slog.Logger
+	rules     []RedactionRule
+	logBodies bool
+}
+
+func newRequestLogger(logger *slog.Logger, rules []RedactionRule, logBodies bool) *requestLogger {
+	return &requestLogger{logger: logger, rules: append(DefaultRedactionRules(), rules...), logBodies: logBodies}
+}
+
+func (l *requestLogger) redaction(ctx context.Context) redaction {
+	op, hasOp := OperationFromContext(ctx)
+	r := redaction{headers: map[string]bool{}, requestFields: map[string]bool{}, responseFields: map[string]bool{}}
+	for _, rule := range l.rules {
+		if !rule.appliesTo(op, hasOp) {
+			continue
+		}
+		for _, h := range rule.Headers {
+			r.headers[http.CanonicalHeaderKey(h)] = true
+		}
+		for _, f := range rule.RequestFields {
+			r.requestFields[f] = true
+		}
+		for _, f := range rule.ResponseFields {
+			r.responseFields[f] = true
+		}
+		r.requestBody = r.requestBody || rule.RequestBody
+		r.responseBody = r.responseBody || rule.ResponseBody
+	}
+	return r
+}
+
+// requestAttrs returns the attributes common to every record logged about request
+func requestAttrs(request *Request) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 6)
+	if op, ok := OperationFromContext(request.Context()); ok {
+		attrs = append(attrs, slog.String("operation", op.String()))
+	}
+	attrs = append(attrs,
+		slog.String("method", request.Method),
+		slog.String("url", request.URL.String()),
+		slog.Uint64("attempt", uint64(request.NumAttempts)),
+	)
+	if len(request.NumErrorsByType) > 0 {
+		errs := make(map[string]uint, len(request.NumErrorsByType))
+		for k, v := range request.NumErrorsByType {
+			errs[k] = v
+		}
+		attrs = append(attrs, slog.Any("errors_by_type", errs))
+	}
+	return attrs
+}
+
+func redactHeaders(header http.Header, redact map[string]bool) http.Header {
+	out := make(http.Header, len(header))
+	for k, v := range header {
+		if redact[http.CanonicalHeaderKey(k)] {
+			out[k] = []string{Redacted}
+		} else {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// redactBody returns body with the values of fields replaced, bodies which can't be parsed as JSON
+// are redacted entirely if there are fields to redact
+func redactBody(body []byte, fields map[string]bool, all bool) string {
+	if all {
+		return Redacted
+	}
+	if len(fields) == 0 || len(body) == 0 {
+		return string(body)
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return Redacted
+	}
+	out, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return Redacted
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if fields[k] {
+				t[k] = Redacted
+			} else {
+				t[k] = redactValue(fv, fields)
+			}
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactValue(e, fields)
+		}
+	}
+	return v
+}
+
+func (l *requestLogger) enabled(ctx context.Context, level slog.Level) bool {
+	return l != nil && l.logger.Enabled(ctx, level)
+}
+
+// logRequest logs an attempt of request about to be sent
+func (l *requestLogger) logRequest(request *Request) {
+	ctx := request.Context()
+	if !l.enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	r := l.redaction(ctx)
+	attrs := append(requestAttrs(request), slog.Any("headers", redactHeaders(request.Header, r.headers)))
+	if l.logBodies && streamedBody(request) {
+		// Getting a copy of the body would read the stream of the body being sent
+		attrs = append(attrs, slog.String("body", Streamed))
+	} else if l.logBodies && request.GetBody != nil {
+		body, err := request.GetBody()
+		if err == nil {
+			var b []byte
+			b, err = io.ReadAll(io.LimitReader(body, maxLoggedBodyBytes+1))
+			body.Close()
+			attrs = append(attrs, bodyAttrs(b, r.requestFields, r.requestBody)...)
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("body_error", err.Error()))
+		}
+	}
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "sending request", attrs...)
+}
+
+// streamedBody returns true if the body of request is streamed rather than held in memory: multipart forms and
+// bodies of unknown length
+func streamedBody(request *Request) bool {
+	if request.Body == nil || request.Body == http.NoBody {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	return request.ContentLength <= 0 || strings.HasPrefix(mediaType, "multipart/")
+}
+
+// logResponse logs the outcome of an attempt of request, the start of the response body is buffered if bodies are logged
+func (l *requestLogger) logResponse(request *Request, response *http.Response, err error, elapsed time.Duration) {
+	ctx := request.Context()
+	level := slog.LevelDebug
+	if err != nil || (response != nil && response.StatusCode >= 400) {
+		level = slog.LevelWarn
+	}
+	if !l.enabled(ctx, level) {
+		return
+	}
+	attrs := append(requestAttrs(request), slog.Duration("elapsed", elapsed))
+	if err != nil {
+		l.logger.LogAttrs(ctx, level, "request failed", append(attrs, slog.String("error", err.Error()))...)
+		return
+	}
+	r := l.redaction(ctx)
+	attrs = append(attrs, slog.Int("status", response.StatusCode), slog.Any("headers", redactHeaders(response.Header, r.headers)))
+	if l.logBodies && response.Body != nil {
+		// Only the logged bytes are buffered, the rest of the body is still streamed to the caller
+		b, readErr := io.ReadAll(io.LimitReader(response.Body, maxLoggedBodyBytes+1))
+		rest := response.Body
+		if readErr != nil {
+			// The caller reading the body gets the error once it has read the bytes received before it
+			rest = io.NopCloser(errReader{readErr})
+			attrs = append(attrs, slog.String("body_error", readErr.Error()))
+		}
+		response.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(b), rest), Closer: response.Body}
+		attrs = append(attrs, bodyAttrs(b, r.responseFields, r.responseBody)...)
+	}
+	l.logger.LogAttrs(ctx, level, "received response", attrs...)
+}
+
+// bodyAttrs returns the attributes logging body, read up to maxLoggedBodyBytes+1 bytes
+func bodyAttrs(body []byte, fields map[string]bool, all bool) []slog.Attr {
+	if len(body) <= maxLoggedBodyBytes {
+		return []slog.Attr{slog.String("body", redactBody(body, fields, all))}
+	}
+	return []slog.Attr{slog.String("body", redactBody(body[:maxLoggedBodyBytes], fields, all)), slog.Bool("body_truncated", true)}
+}
+
+// readCloser reads from Reader and closes Closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// errReader fails every read with err
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
+
+// logRetry logs that request is about to be retried after wait
+func (l *requestLogger) logRetry(request *Request, wait time.Duration, reqErr error, response *http.Response) {
+	ctx := request.Context()
+	if !l.enabled(ctx, slog.LevelInfo) {
+		return
+	}
+	attrs := append(requestAttrs(request),
+		slog.Duration("wait", wait),
+		slog.String("retry_class", ClassifyRetry(reqErr, response).String()),
+	)
+	if response != nil {
+		attrs = append(attrs, slog.Int("status", response.StatusCode))
+	}
+	if reqErr != nil {
+		attrs = append(attrs, slog.String("error", reqErr.Error()))
+	}
+	l.logger.LogAttrs(ctx, slog.LevelInfo, "retrying request", attrs...)
+}
+
+// logTokenRefresh logs the outcome of retrieving a new access token, reason is why it was retrieved. Refreshes are
+// routine so successes are logged at debug level, failures at error level
+func (l *requestLogger) logTokenRefresh(ctx context.Context, reason string, err error) {
+	if err != nil {
+		if l.enabled(ctx, slog.LevelError) {
+			l.logger.LogAttrs(ctx, slog.LevelError, "access token refresh failed", slog.String("reason", reason), slog.String("error", err.Error()))
+		}
+		return
+	}
+	if l.enabled(ctx, slog.LevelDebug) {
+		l.logger.LogAttrs(ctx, slog.LevelDebug, "access token refreshed", slog.String("reason", reason))
+	}
+}
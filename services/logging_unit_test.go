@@ -0,0 +1,215 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bodyRT returns the given body with a 200 OK
+type bodyRT struct {
+	body string
+}
+
+func (rt bodyRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := ioutil.NopCloser(bytes.NewReader([]byte(rt.body)))
+	return &http.Response{Status: "200 OK", StatusCode: 200, Body: b, Header: http.Header{"Content-Type": {"application/json"}}}, nil
+}
+
+func newLoggingClient(t *testing.T, rt http.RoundTripper, rules ...RedactionRule) (*BaseClient, *bytes.Buffer) {
+	var buf bytes.Buffer
+	retryConfig := ConfigurableRetryConfig{RetryNum: 3, Interval: 10, clock: &fakeClock{now: time.Unix(1000, 0)}}
+	client, err := NewClient(&Config{
+		Token:          "supersecrettoken",
+		Tenant:         "mytenant",
+		RoundTripper:   rt,
+		RetryRequests:  true,
+		RetryConfig:    RetryStrategyConfig{ConfigurableRetryConfig: &retryConfig},
+		Logger:         slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		RedactionRules: rules,
+		LogBodies:      true,
+	})
+	require.NoError(t, err)
+	return client, &buf
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func testURL() url.URL {
+	return url.URL{Scheme: "https", Host: "api.scp.splunk.com", Path: "/mytenant/widgets"}
+}
+
+func TestLoggingRequestsRetriesAndRedaction(t *testing.T) {
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, "")}}
+	client, buf := newLoggingClient(t, rt)
+
+	ctx := WithOperation(context.Background(), "kvstore", "InsertRecords")
+	_, err := client.PostWithContext(ctx, services.RequestParams{URL: testURL(), Body: []byte(`{"a":1}`)})
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "supersecrettoken")
+
+	var records []map[string]interface{}
+	var msgs []string
+	for _, r := range logRecords(t, buf) {
+		assert.Equal(t, "mytenant", r["tenant"])
+		if r["msg"] == "access token refreshed" {
+			assert.Equal(t, "expiring", r["reason"])
+			continue
+		}
+		records = append(records, r)
+		msgs = append(msgs, r["msg"].(string))
+		assert.Equal(t, "kvstore.InsertRecords", r["operation"])
+	}
+	assert.Equal(t, []string{"sending request", "received response", "retrying request", "sending request", "received response"}, msgs)
+
+	first := records[0]
+	assert.Equal(t, float64(1), first["attempt"])
+	assert.Equal(t, `{"a":1}`, first["body"])
+	headers := first["headers"].(map[string]interface{})
+	assert.Equal(t, []interface{}{Redacted}, headers["Authorization"])
+
+	assert.Equal(t, "WARN", records[1]["level"])
+	assert.Equal(t, float64(429), records[1]["status"])
+
+	retry := records[2]
+	assert.Equal(t, "INFO", retry["level"])
+	assert.Equal(t, "throttled", retry["retry_class"])
+	assert.Equal(t, map[string]interface{}{"429": float64(1)}, retry["errors_by_type"])
+
+	assert.Equal(t, float64(2), records[3]["attempt"])
+}
+
+func TestLoggingDefaultRedactionRules(t *testing.T) {
+	client, buf := newLoggingClient(t, bodyRT{body: `{"name":"mytoken","token":"hec-secret"}`})
+	ctx := WithOperation(context.Background(), "ingest", "PostCollectorTokens")
+	resp, err := client.PostWithContext(ctx, services.RequestParams{URL: testURL(), Body: []byte(`{"name":"mytoken"}`)})
+	require.NoError(t, err)
+	// The response body is still readable by the caller
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "hec-secret")
+	assert.NotContains(t, buf.String(), "hec-secret")
+	assert.Contains(t, buf.String(), "mytoken")
+
+	client, buf = newLoggingClient(t, bodyRT{})
+	ctx = WithOperation(context.Background(), "identity", "UpdatePassword")
+	_, err = client.PutWithContext(ctx, services.RequestParams{URL: testURL(), Body: []byte(`{"password":"hunter2","current_password":"hunter1"}`)})
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "hunter")
+
+	client, buf = newLoggingClient(t, bodyRT{body: `{"clientId":"id","clientSecret":"app-secret"}`})
+	ctx = WithOperation(context.Background(), "appregistry", "RotateSecret")
+	_, err = client.PostWithContext(ctx, services.RequestParams{URL: testURL()})
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "app-secret")
+}
+
+func TestLoggingCustomRedactionRules(t *testing.T) {
+	rules := []RedactionRule{
+		{Headers: []string{"x-api-key"}, RequestFields: []string{"apiKey"}},
+		{Operation: Operation{Service: "kvstore", Name: "QueryRecords"}, ResponseBody: true},
+	}
+	client, buf := newLoggingClient(t, bodyRT{body: `[{"ssn":"123-45-6789"}]`}, rules...)
+
+	ctx := WithOperation(context.Background(), "kvstore", "QueryRecords")
+	_, err := client.PostWithContext(ctx, services.RequestParams{
+		URL:     testURL(),
+		Body:    []byte(`{"nested":[{"apiKey":"key-secret","keep":"visible"}]}`),
+		Headers: map[string]string{"X-Api-Key": "header-secret"},
+	})
+	require.NoError(t, err)
+	out := buf.String()
+	assert.NotContains(t, out, "key-secret")
+	assert.NotContains(t, out, "header-secret")
+	assert.NotContains(t, out, "123-45-6789")
+	assert.Contains(t, out, "visible")
+}
+
+func TestRedactBody(t *testing.T) {
+	fields := map[string]bool{"password": true}
+	assert.Equal(t, `{"password":"[REDACTED]","user":"bob"}`, redactBody([]byte(`{"user":"bob","password":"x"}`), fields, false))
+	assert.Equal(t, Redacted, redactBody([]byte(`password=x`), fields, false), "bodies which aren't JSON can't be partially redacted")
+	assert.Equal(t, `password=x`, redactBody([]byte(`password=x`), nil, false))
+	assert.Equal(t, Redacted, redactBody([]byte(`{}`), nil, true))
+}
+
+// brokenBodyRT returns a 200 OK whose body fails after its first bytes
+type brokenBodyRT struct{}
+
+func (brokenBodyRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := ioutil.NopCloser(io.MultiReader(strings.NewReader(`{"partial":`), errReader{io.ErrUnexpectedEOF}))
+	return &http.Response{Status: "200 OK", StatusCode: 200, Body: b}, nil
+}
+
+func TestLoggingLargeAndBrokenBodies(t *testing.T) {
+	large := `"` + strings.Repeat("a", 2*maxLoggedBodyBytes) + `"`
+	client, buf := newLoggingClient(t, bodyRT{body: large})
+	resp, err := client.Get(services.RequestParams{URL: testURL()})
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, large, string(body), "the body is read in full by the caller")
+	records := logRecords(t, buf)
+	received := records[len(records)-1]
+	assert.Equal(t, "received response", received["msg"])
+	assert.Len(t, received["body"], maxLoggedBodyBytes)
+	assert.Equal(t, true, received["body_truncated"])
+
+	client, buf = newLoggingClient(t, brokenBodyRT{})
+	resp, err = client.Get(services.RequestParams{URL: testURL()})
+	require.NoError(t, err)
+	body, err = ioutil.ReadAll(resp.Body)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), "the read error is returned to the caller")
+	assert.Equal(t, `{"partial":`, string(body))
+	records = logRecords(t, buf)
+	assert.Equal(t, io.ErrUnexpectedEOF.Error(), records[len(records)-1]["body_error"])
+}
+
+func TestLoggingDisabled(t *testing.T) {
+	client, err := NewClient(&Config{Token: "testtoken", RoundTripper: &retryAfterRT{}})
+	require.NoError(t, err)
+	assert.Nil(t, client.logger)
+	_, err = client.Get(services.RequestParams{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,269 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ml
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateWorkflowWithContext - Creates a workflow configuration.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			workflow: Workflow configuration to be created.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateWorkflowWithContext(ctx context.Context, workflow Workflow, resp ...*http.Response) (*Workflow, error)
+	/*
+		CreateWorkflowBuildWithContext - Creates a workflow build.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			workflowBuild: Input data used to build the workflow.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateWorkflowBuildWithContext(ctx context.Context, id string, workflowBuild WorkflowBuild, resp ...*http.Response) (*WorkflowBuild, error)
+	/*
+		CreateWorkflowDeploymentWithContext - Creates a workflow deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			workflowDeployment: Input data used to build the workflow deployment.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateWorkflowDeploymentWithContext(ctx context.Context, id string, buildId string, workflowDeployment WorkflowDeployment, resp ...*http.Response) (*WorkflowDeployment, error)
+	/*
+		CreateWorkflowInferenceWithContext - Creates a workflow inference request.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			deploymentId: The workflow deployment ID.
+			workflowInference: Input data to the inference request.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateWorkflowInferenceWithContext(ctx context.Context, id string, buildId string, deploymentId string, workflowInference WorkflowInference, resp ...*http.Response) (*WorkflowInference, error)
+	/*
+		CreateWorkflowRunWithContext - Creates a workflow run.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			workflowRun: Input data used to build the workflow.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateWorkflowRunWithContext(ctx context.Context, id string, buildId string, workflowRun WorkflowRun, resp ...*http.Response) (*WorkflowRun, error)
+	/*
+		CreateWorkflowStreamDeploymentWithContext - Creates a workflow streaming deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			workflowStreamDeployment: Configuration for the workflow streaming deployment.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateWorkflowStreamDeploymentWithContext(ctx context.Context, id string, buildId string, workflowStreamDeployment WorkflowStreamDeployment, resp ...*http.Response) (*WorkflowStreamDeployment, error)
+	/*
+		DeleteWorkflowWithContext - Removes a workflow configuration.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteWorkflowWithContext(ctx context.Context, id string, resp ...*http.Response) error
+	/*
+		DeleteWorkflowBuildWithContext - Removes a workflow build.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteWorkflowBuildWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) error
+	/*
+		DeleteWorkflowDeploymentWithContext - Removes a workflow deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			deploymentId: The workflow deployment ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteWorkflowDeploymentWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) error
+	/*
+		DeleteWorkflowRunWithContext - Removes a workflow run.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			runId: The workflow run ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteWorkflowRunWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) error
+	/*
+		DeleteWorkflowStreamDeploymentWithContext - Removes a workflow streaming deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			streamDeploymentId: The workflow streaming deployment ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteWorkflowStreamDeploymentWithContext(ctx context.Context, id string, buildId string, streamDeploymentId string, resp ...*http.Response) error
+	/*
+		GetWorkflowWithContext - Returns a workflow configuration.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowWithContext(ctx context.Context, id string, resp ...*http.Response) (*Workflow, error)
+	/*
+		GetWorkflowBuildWithContext - Returns the status of a workflow build.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowBuildWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) (*WorkflowBuild, error)
+	/*
+		GetWorkflowBuildErrorWithContext - Returns a list of workflow errors.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowBuildErrorWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) (*WorkflowBuildError, error)
+	/*
+		GetWorkflowBuildLogWithContext - Returns the logs from a workflow build.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowBuildLogWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) (*WorkflowBuildLog, error)
+	/*
+		GetWorkflowDeploymentWithContext - Returns the status of a workflow deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			deploymentId: The workflow deployment ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowDeploymentWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) (*WorkflowDeployment, error)
+	/*
+		GetWorkflowDeploymentErrorWithContext - Returns a list of workflow deployment errors.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			deploymentId: The workflow deployment ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowDeploymentErrorWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) (*WorkflowDeploymentError, error)
+	/*
+		GetWorkflowDeploymentLogWithContext - Returns the logs from a workflow deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			deploymentId: The workflow deployment ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowDeploymentLogWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) (*WorkflowDeploymentLog, error)
+	/*
+		GetWorkflowRunWithContext - Returns the status of a workflow run.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			runId: The workflow run ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowRunWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) (*WorkflowRun, error)
+	/*
+		GetWorkflowRunErrorWithContext - Returns the errors for a workflow run.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			runId: The workflow run ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowRunErrorWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) (*WorkflowRunError, error)
+	/*
+		GetWorkflowRunLogWithContext - Returns the logs for a workflow run.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			runId: The workflow run ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowRunLogWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) (*WorkflowRunLog, error)
+	/*
+		GetWorkflowStreamDeploymentWithContext - Returns the status of a workflow streaming deployment.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			streamDeploymentId: The workflow streaming deployment ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetWorkflowStreamDeploymentWithContext(ctx context.Context, id string, buildId string, streamDeploymentId string, resp ...*http.Response) (*WorkflowStreamDeployment, error)
+	/*
+		ListWorkflowBuildsWithContext - Returns a list of workflow builds.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListWorkflowBuildsWithContext(ctx context.Context, id string, resp ...*http.Response) ([]WorkflowBuild, error)
+	/*
+		ListWorkflowDeploymentsWithContext - Returns a list of workflow deployments.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListWorkflowDeploymentsWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) ([]WorkflowDeployment, error)
+	/*
+		ListWorkflowRunsWithContext - Returns a list of workflow runs.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: The workflow ID.
+			buildId: The workflow build ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListWorkflowRunsWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) ([]WorkflowRun, error)
+	/*
+		ListWorkflowsWithContext - Returns a list of workflow configurations.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListWorkflowsWithContext(ctx context.Context, resp ...*http.Response) ([]WorkflowsGetResponse, error)
+}
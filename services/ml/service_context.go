@@ -0,0 +1,1027 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package ml
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateWorkflowWithContext - Creates a workflow configuration.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	workflow: Workflow configuration to be created.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateWorkflowWithContext(ctx context.Context, workflow Workflow, resp ...*http.Response) (*Workflow, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "CreateWorkflow")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: workflow})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Workflow
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateWorkflowBuildWithContext - Creates a workflow build.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	workflowBuild: Input data used to build the workflow.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateWorkflowBuildWithContext(ctx context.Context, id string, workflowBuild WorkflowBuild, resp ...*http.Response) (*WorkflowBuild, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "CreateWorkflowBuild")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: workflowBuild})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowBuild
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateWorkflowDeploymentWithContext - Creates a workflow deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	workflowDeployment: Input data used to build the workflow deployment.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateWorkflowDeploymentWithContext(ctx context.Context, id string, buildId string, workflowDeployment WorkflowDeployment, resp ...*http.Response) (*WorkflowDeployment, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "CreateWorkflowDeployment")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: workflowDeployment})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowDeployment
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateWorkflowInferenceWithContext - Creates a workflow inference request.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	deploymentId: The workflow deployment ID.
+	workflowInference: Input data to the inference request.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateWorkflowInferenceWithContext(ctx context.Context, id string, buildId string, deploymentId string, workflowInference WorkflowInference, resp ...*http.Response) (*WorkflowInference, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "CreateWorkflowInference")
+	pp := struct {
+		Id           string
+		BuildId      string
+		DeploymentId string
+	}{
+		Id:           id,
+		BuildId:      buildId,
+		DeploymentId: deploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments/{{.DeploymentId}}/inference`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: workflowInference})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowInference
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateWorkflowRunWithContext - Creates a workflow run.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	workflowRun: Input data used to build the workflow.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateWorkflowRunWithContext(ctx context.Context, id string, buildId string, workflowRun WorkflowRun, resp ...*http.Response) (*WorkflowRun, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "CreateWorkflowRun")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/runs`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: workflowRun})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowRun
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateWorkflowStreamDeploymentWithContext - Creates a workflow streaming deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	workflowStreamDeployment: Configuration for the workflow streaming deployment.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateWorkflowStreamDeploymentWithContext(ctx context.Context, id string, buildId string, workflowStreamDeployment WorkflowStreamDeployment, resp ...*http.Response) (*WorkflowStreamDeployment, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "CreateWorkflowStreamDeployment")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/stream-deployments`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: workflowStreamDeployment})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowStreamDeployment
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteWorkflowWithContext - Removes a workflow configuration.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteWorkflowWithContext(ctx context.Context, id string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "ml", "DeleteWorkflow")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteWorkflowBuildWithContext - Removes a workflow build.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteWorkflowBuildWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "ml", "DeleteWorkflowBuild")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteWorkflowDeploymentWithContext - Removes a workflow deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	deploymentId: The workflow deployment ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteWorkflowDeploymentWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "ml", "DeleteWorkflowDeployment")
+	pp := struct {
+		Id           string
+		BuildId      string
+		DeploymentId string
+	}{
+		Id:           id,
+		BuildId:      buildId,
+		DeploymentId: deploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments/{{.DeploymentId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteWorkflowRunWithContext - Removes a workflow run.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	runId: The workflow run ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteWorkflowRunWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "ml", "DeleteWorkflowRun")
+	pp := struct {
+		Id      string
+		BuildId string
+		RunId   string
+	}{
+		Id:      id,
+		BuildId: buildId,
+		RunId:   runId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/runs/{{.RunId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteWorkflowStreamDeploymentWithContext - Removes a workflow streaming deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	streamDeploymentId: The workflow streaming deployment ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteWorkflowStreamDeploymentWithContext(ctx context.Context, id string, buildId string, streamDeploymentId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "ml", "DeleteWorkflowStreamDeployment")
+	pp := struct {
+		Id                 string
+		BuildId            string
+		StreamDeploymentId string
+	}{
+		Id:                 id,
+		BuildId:            buildId,
+		StreamDeploymentId: streamDeploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/stream-deployments/{{.StreamDeploymentId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetWorkflowWithContext - Returns a workflow configuration.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowWithContext(ctx context.Context, id string, resp ...*http.Response) (*Workflow, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflow")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Workflow
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowBuildWithContext - Returns the status of a workflow build.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowBuildWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) (*WorkflowBuild, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowBuild")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowBuild
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowBuildErrorWithContext - Returns a list of workflow errors.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowBuildErrorWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) (*WorkflowBuildError, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowBuildError")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/errors`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowBuildError
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowBuildLogWithContext - Returns the logs from a workflow build.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowBuildLogWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) (*WorkflowBuildLog, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowBuildLog")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/logs`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowBuildLog
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowDeploymentWithContext - Returns the status of a workflow deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	deploymentId: The workflow deployment ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowDeploymentWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) (*WorkflowDeployment, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowDeployment")
+	pp := struct {
+		Id           string
+		BuildId      string
+		DeploymentId string
+	}{
+		Id:           id,
+		BuildId:      buildId,
+		DeploymentId: deploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments/{{.DeploymentId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowDeployment
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowDeploymentErrorWithContext - Returns a list of workflow deployment errors.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	deploymentId: The workflow deployment ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowDeploymentErrorWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) (*WorkflowDeploymentError, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowDeploymentError")
+	pp := struct {
+		Id           string
+		BuildId      string
+		DeploymentId string
+	}{
+		Id:           id,
+		BuildId:      buildId,
+		DeploymentId: deploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments/{{.DeploymentId}}/errors`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowDeploymentError
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowDeploymentLogWithContext - Returns the logs from a workflow deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	deploymentId: The workflow deployment ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowDeploymentLogWithContext(ctx context.Context, id string, buildId string, deploymentId string, resp ...*http.Response) (*WorkflowDeploymentLog, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowDeploymentLog")
+	pp := struct {
+		Id           string
+		BuildId      string
+		DeploymentId string
+	}{
+		Id:           id,
+		BuildId:      buildId,
+		DeploymentId: deploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments/{{.DeploymentId}}/logs`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowDeploymentLog
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowRunWithContext - Returns the status of a workflow run.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	runId: The workflow run ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowRunWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) (*WorkflowRun, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowRun")
+	pp := struct {
+		Id      string
+		BuildId string
+		RunId   string
+	}{
+		Id:      id,
+		BuildId: buildId,
+		RunId:   runId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/runs/{{.RunId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowRun
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowRunErrorWithContext - Returns the errors for a workflow run.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	runId: The workflow run ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowRunErrorWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) (*WorkflowRunError, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowRunError")
+	pp := struct {
+		Id      string
+		BuildId string
+		RunId   string
+	}{
+		Id:      id,
+		BuildId: buildId,
+		RunId:   runId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/runs/{{.RunId}}/errors`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowRunError
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowRunLogWithContext - Returns the logs for a workflow run.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	runId: The workflow run ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowRunLogWithContext(ctx context.Context, id string, buildId string, runId string, resp ...*http.Response) (*WorkflowRunLog, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowRunLog")
+	pp := struct {
+		Id      string
+		BuildId string
+		RunId   string
+	}{
+		Id:      id,
+		BuildId: buildId,
+		RunId:   runId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/runs/{{.RunId}}/logs`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowRunLog
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetWorkflowStreamDeploymentWithContext - Returns the status of a workflow streaming deployment.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	streamDeploymentId: The workflow streaming deployment ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetWorkflowStreamDeploymentWithContext(ctx context.Context, id string, buildId string, streamDeploymentId string, resp ...*http.Response) (*WorkflowStreamDeployment, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "GetWorkflowStreamDeployment")
+	pp := struct {
+		Id                 string
+		BuildId            string
+		StreamDeploymentId string
+	}{
+		Id:                 id,
+		BuildId:            buildId,
+		StreamDeploymentId: streamDeploymentId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/stream-deployments/{{.StreamDeploymentId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb WorkflowStreamDeployment
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListWorkflowBuildsWithContext - Returns a list of workflow builds.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListWorkflowBuildsWithContext(ctx context.Context, id string, resp ...*http.Response) ([]WorkflowBuild, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "ListWorkflowBuilds")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []WorkflowBuild
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListWorkflowDeploymentsWithContext - Returns a list of workflow deployments.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListWorkflowDeploymentsWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) ([]WorkflowDeployment, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "ListWorkflowDeployments")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/deployments`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []WorkflowDeployment
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListWorkflowRunsWithContext - Returns a list of workflow runs.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: The workflow ID.
+	buildId: The workflow build ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListWorkflowRunsWithContext(ctx context.Context, id string, buildId string, resp ...*http.Response) ([]WorkflowRun, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "ListWorkflowRuns")
+	pp := struct {
+		Id      string
+		BuildId string
+	}{
+		Id:      id,
+		BuildId: buildId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows/{{.Id}}/builds/{{.BuildId}}/runs`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []WorkflowRun
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListWorkflowsWithContext - Returns a list of workflow configurations.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListWorkflowsWithContext(ctx context.Context, resp ...*http.Response) ([]WorkflowsGetResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "ml", "ListWorkflows")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/ml/v2beta1/workflows`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []WorkflowsGetResponse
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
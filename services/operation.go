@@ -0,0 +1,50 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import "context"
+
+// Operation identifies the SDK service method a request is made on behalf of
+type Operation struct {
+	// Service is the name of the service package e.g. "ingest"
+	Service string
+	// Name is the name of the service method e.g. "PostEvents"
+	Name string
+}
+
+// String returns the operation as <service>.<name>
+func (o Operation) String() string {
+	return o.Service + "." + o.Name
+}
+
+type operationKey struct{}
+
+// WithOperation returns a copy of ctx carrying the operation, it is called by the context variants of the service
+// methods, in their service_context.go files, so that instrumentation, logging and errors can refer to the logical
+// SDK call behind each request. The requests of the generated methods without a context carry no operation.
+func WithOperation(ctx context.Context, service string, name string) context.Context {
+	return context.WithValue(ctx, operationKey{}, Operation{Service: service, Name: name})
+}
+
+// OperationFromContext returns the operation carried by ctx, if any
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	if ctx == nil {
+		return Operation{}, false
+	}
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the number of items requested per page by a Pager unless configured otherwise
+const DefaultPageSize = 100
+
+// PagerOption configures a Pager
+type PagerOption func(*pagerConfig)
+
+type pagerConfig struct {
+	pageSize    int
+	concurrency int
+}
+
+// WithPageSize sets the number of items requested per page, values below 1 are ignored
+func WithPageSize(n int) PagerOption {
+	return func(c *pagerConfig) {
+		if n > 0 {
+			c.pageSize = n
+		}
+	}
+}
+
+// WithConcurrency sets the maximum number of pages requested at the same time, 1 by default, values below 1
+// are ignored. Offset-based pagination requests up to n pages ahead of the one being iterated over, whereas
+// token-based pagination can only request the next page ahead, as soon as its token is known.
+func WithConcurrency(n int) PagerOption {
+	return func(c *pagerConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// IntValue returns the value of the optional integer query parameter v, 0 if it's nil. It's used by the All
+// helpers of the services to get the page size and offset of the query they're given.
+func IntValue[T ~int32 | ~int64](v *T) int {
+	if v == nil {
+		return 0
+	}
+	return int(*v)
+}
+
+// OffsetPageFunc fetches the page of at most size items starting at offset. total is the total number of items,
+// -1 if the endpoint doesn't return it.
+type OffsetPageFunc[T any] func(ctx context.Context, offset, size int) (items []T, total int64, err error)
+
+// TokenPageFunc fetches the page of at most size items identified by token, empty for the first page.
+// next is the token of the following page, empty if this is the last page.
+type TokenPageFunc[T any] func(ctx context.Context, token string, size int) (items []T, next string, err error)
+
+// Pager iterates over all the items of a paginated list endpoint, requesting pages as they are needed:
+//
+//	for dataset, err := range client.CatalogService.AllDatasets(ctx, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Iteration stops after the first error, which is yielded along with the zero value of T. Breaking out of
+// the loop cancels the requests in flight.
+type Pager[T any] struct {
+	config pagerConfig
+	pages  func(ctx context.Context, yield func([]T, error) bool)
+}
+
+func newPagerConfig(opts []PagerOption) pagerConfig {
+	config := pagerConfig{pageSize: DefaultPageSize, concurrency: 1}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return config
+}
+
+// NewOffsetPager creates a Pager for endpoints paginated with an offset and a page size. The last page is
+// the first one with fewer items than requested or reaching the total number of items, if known.
+func NewOffsetPager[T any](fetch OffsetPageFunc[T], opts ...PagerOption) *Pager[T] {
+	p := &Pager[T]{config: newPagerConfig(opts)}
+	p.pages = func(ctx context.Context, yield func([]T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		size := p.config.pageSize
+		type page struct {
+			items []T
+			total int64
+			err   error
+		}
+		var pending []chan page
+		offset, next := 0, 0
+		total := int64(-1)
+		for {
+			// Keep up to concurrency pages in flight, without requesting pages past the known total
+			for len(pending) < p.config.concurrency && (total < 0 || int64(next) < total) {
+				ch := make(chan page, 1)
+				go func(offset int) {
+					items, total, err := fetch(ctx, offset, size)
+					ch <- page{items: items, total: total, err: err}
+				}(next)
+				pending = append(pending, ch)
+				next += size
+			}
+			if len(pending) == 0 {
+				return
+			}
+			pg := <-pending[0]
+			pending = pending[1:]
+			if pg.err != nil {
+				yield(nil, pg.err)
+				return
+			}
+			if pg.total >= 0 {
+				total = pg.total
+			}
+			if len(pg.items) > 0 && !yield(pg.items, nil) {
+				return
+			}
+			offset += size
+			if len(pg.items) < size || (total >= 0 && int64(offset) >= total) {
+				return
+			}
+		}
+	}
+	return p
+}
+
+// NewTokenPager creates a Pager for endpoints paginated with a page token returned along with every page
+func NewTokenPager[T any](fetch TokenPageFunc[T], opts ...PagerOption) *Pager[T] {
+	p := &Pager[T]{config: newPagerConfig(opts)}
+	p.pages = func(ctx context.Context, yield func([]T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		type page struct {
+			items []T
+			next  string
+			err   error
+		}
+		request := func(token string) chan page {
+			ch := make(chan page, 1)
+			go func() {
+				items, next, err := fetch(ctx, token, p.config.pageSize)
+				ch <- page{items: items, next: next, err: err}
+			}()
+			return ch
+		}
+		pending := request("")
+		for {
+			pg := <-pending
+			if pg.err != nil {
+				yield(nil, pg.err)
+				return
+			}
+			pending = nil
+			if pg.next != "" && p.config.concurrency > 1 {
+				pending = request(pg.next)
+			}
+			if len(pg.items) > 0 && !yield(pg.items, nil) {
+				return
+			}
+			if pg.next == "" {
+				return
+			}
+			if pending == nil {
+				pending = request(pg.next)
+			}
+		}
+	}
+	return p
+}
+
+// Pages returns an iterator over the pages of items
+func (p *Pager[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		p.pages(ctx, yield)
+	}
+}
+
+// All returns an iterator over the items of every page
+func (p *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		p.pages(ctx, func(items []T, err error) bool {
+			if err != nil {
+				var zero T
+				return yield(zero, err)
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// offsetList serves n items by offset, with random latency so that concurrent pages complete out of order
+type offsetList struct {
+	n         int
+	withTotal bool
+	failAt    int
+
+	mux         sync.Mutex
+	offsets     []int
+	inFlight    int32
+	maxInFlight int32
+}
+
+func (l *offsetList) fetch(ctx context.Context, offset, size int) ([]int, int64, error) {
+	n := atomic.AddInt32(&l.inFlight, 1)
+	defer atomic.AddInt32(&l.inFlight, -1)
+	l.mux.Lock()
+	l.offsets = append(l.offsets, offset)
+	if n > l.maxInFlight {
+		l.maxInFlight = n
+	}
+	l.mux.Unlock()
+	time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+	if l.failAt > 0 && offset >= l.failAt {
+		return nil, 0, errors.New("page failed")
+	}
+	var items []int
+	for i := offset; i < offset+size && i < l.n; i++ {
+		items = append(items, i)
+	}
+	total := int64(-1)
+	if l.withTotal {
+		total = int64(l.n)
+	}
+	return items, total, nil
+}
+
+func (l *offsetList) stats() (offsets []int, maxInFlight int) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return append([]int{}, l.offsets...), int(l.maxInFlight)
+}
+
+func collect[T any](seq func(func(T, error) bool)) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func sequence(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func TestOffsetPager(t *testing.T) {
+	for _, tc := range []struct {
+		n, size, concurrency int
+		withTotal            bool
+	}{
+		{n: 0, size: 10, concurrency: 1},
+		{n: 25, size: 10, concurrency: 1},
+		{n: 30, size: 10, concurrency: 1},
+		{n: 95, size: 10, concurrency: 4},
+		{n: 95, size: 10, concurrency: 4, withTotal: true},
+		{n: 100, size: 10, concurrency: 4, withTotal: true},
+	} {
+		name := strconv.Itoa(tc.n) + "/" + strconv.Itoa(tc.size) + "/" + strconv.Itoa(tc.concurrency) + "/" + strconv.FormatBool(tc.withTotal)
+		t.Run(name, func(t *testing.T) {
+			l := &offsetList{n: tc.n, withTotal: tc.withTotal}
+			pager := NewOffsetPager(l.fetch, WithPageSize(tc.size), WithConcurrency(tc.concurrency))
+			items, err := collect[int](pager.All(context.Background()))
+			require.NoError(t, err)
+			assert.Equal(t, sequence(tc.n), append([]int{}, items...), "items are yielded in order")
+			offsets, maxInFlight := l.stats()
+			assert.LessOrEqual(t, maxInFlight, tc.concurrency)
+			if tc.withTotal {
+				assert.Len(t, offsets, (tc.n+tc.size-1)/tc.size, "no page past the total is requested")
+			}
+		})
+	}
+}
+
+func TestOffsetPagerBreakAndError(t *testing.T) {
+	l := &offsetList{n: 1000}
+	var items []int
+	for item, err := range NewOffsetPager(l.fetch, WithPageSize(10), WithConcurrency(3)).All(context.Background()) {
+		require.NoError(t, err)
+		items = append(items, item)
+		if len(items) == 15 {
+			break
+		}
+	}
+	assert.Equal(t, sequence(15), items)
+	offsets, _ := l.stats()
+	assert.LessOrEqual(t, len(offsets), 5)
+
+	l = &offsetList{n: 1000, failAt: 30}
+	items, err := collect[int](NewOffsetPager(l.fetch, WithPageSize(10), WithConcurrency(2)).All(context.Background()))
+	assert.EqualError(t, err, "page failed")
+	assert.Equal(t, sequence(30), items)
+}
+
+func TestTokenPager(t *testing.T) {
+	pages := map[string][]string{"": {"a", "b"}, "t1": {"c", "d"}, "t2": {"e"}}
+	next := map[string]string{"": "t1", "t1": "t2"}
+	var mux sync.Mutex
+	var tokens []string
+	var sizes []int
+	fetch := func(ctx context.Context, token string, size int) ([]string, string, error) {
+		mux.Lock()
+		defer mux.Unlock()
+		tokens = append(tokens, token)
+		sizes = append(sizes, size)
+		return pages[token], next[token], nil
+	}
+
+	items, err := collect[string](NewTokenPager(fetch, WithPageSize(2)).All(context.Background()))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, items)
+	assert.Equal(t, []string{"", "t1", "t2"}, tokens)
+	assert.Equal(t, []int{2, 2, 2}, sizes)
+
+	// The next page is requested before the current one is consumed when concurrency allows it
+	tokens = nil
+	seen := 0
+	for _, err := range NewTokenPager(fetch, WithConcurrency(2)).Pages(context.Background()) {
+		require.NoError(t, err)
+		seen++
+		requested := seen + 1
+		if seen == len(pages) {
+			requested = seen
+		}
+		assert.Eventually(t, func() bool {
+			mux.Lock()
+			defer mux.Unlock()
+			return len(tokens) == requested
+		}, time.Second, time.Millisecond)
+	}
+	assert.Equal(t, []string{"", "t1", "t2"}, tokens)
+}
+
+func TestIntValue(t *testing.T) {
+	count, offset := int32(50), int64(1000)
+	assert.Equal(t, 50, IntValue(&count))
+	assert.Equal(t, 1000, IntValue(&offset))
+	assert.Equal(t, 0, IntValue[int32](nil))
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package provisioner
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateInviteWithContext - provisioner service endpoint
+		Creates an invitation for a person to join the tenant using their email address.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			inviteBody
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateInviteWithContext(ctx context.Context, inviteBody InviteBody, resp ...*http.Response) (*InviteInfo, error)
+	/*
+		DeleteInviteWithContext - provisioner service endpoint
+		Removes an invitation in the given tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			inviteId
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteInviteWithContext(ctx context.Context, inviteId string, resp ...*http.Response) error
+	/*
+		GetInviteWithContext - provisioner service endpoint
+		Returns an invitation in the given tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			inviteId
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetInviteWithContext(ctx context.Context, inviteId string, resp ...*http.Response) (*InviteInfo, error)
+	/*
+		GetTenantWithContext - provisioner service endpoint
+		Returns a specific tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			tenantName
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetTenantWithContext(ctx context.Context, tenantName string, resp ...*http.Response) (*TenantInfo, error)
+	/*
+		ListInvitesWithContext - provisioner service endpoint
+		Returns a list of invitations in a given tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListInvitesWithContext(ctx context.Context, resp ...*http.Response) (*Invites, error)
+	/*
+		ListTenantsWithContext - provisioner service endpoint
+		Returns all tenants that the user can read.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListTenantsWithContext(ctx context.Context, resp ...*http.Response) (*Tenants, error)
+	/*
+		UpdateInviteWithContext - provisioner service endpoint
+		Modifies an invitation in the given tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			inviteId
+			updateInviteBody
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateInviteWithContext(ctx context.Context, inviteId string, updateInviteBody UpdateInviteBody, resp ...*http.Response) (*InviteInfo, error)
+}
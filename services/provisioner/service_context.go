@@ -0,0 +1,267 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package provisioner
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateInviteWithContext - provisioner service endpoint
+Creates an invitation for a person to join the tenant using their email address.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	inviteBody
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateInviteWithContext(ctx context.Context, inviteBody InviteBody, resp ...*http.Response) (*InviteInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "CreateInvite")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/provisioner/v1beta1/invites`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: inviteBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb InviteInfo
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteInviteWithContext - provisioner service endpoint
+Removes an invitation in the given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	inviteId
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteInviteWithContext(ctx context.Context, inviteId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "DeleteInvite")
+	pp := struct {
+		InviteId string
+	}{
+		InviteId: inviteId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/provisioner/v1beta1/invites/{{.InviteId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetInviteWithContext - provisioner service endpoint
+Returns an invitation in the given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	inviteId
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetInviteWithContext(ctx context.Context, inviteId string, resp ...*http.Response) (*InviteInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "GetInvite")
+	pp := struct {
+		InviteId string
+	}{
+		InviteId: inviteId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/provisioner/v1beta1/invites/{{.InviteId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb InviteInfo
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetTenantWithContext - provisioner service endpoint
+Returns a specific tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	tenantName
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetTenantWithContext(ctx context.Context, tenantName string, resp ...*http.Response) (*TenantInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "GetTenant")
+	pp := struct {
+		TenantName string
+	}{
+		TenantName: tenantName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/provisioner/v1beta1/tenants/{{.TenantName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb TenantInfo
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListInvitesWithContext - provisioner service endpoint
+Returns a list of invitations in a given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListInvitesWithContext(ctx context.Context, resp ...*http.Response) (*Invites, error) {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "ListInvites")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/provisioner/v1beta1/invites`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Invites
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListTenantsWithContext - provisioner service endpoint
+Returns all tenants that the user can read.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListTenantsWithContext(ctx context.Context, resp ...*http.Response) (*Tenants, error) {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "ListTenants")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/system/provisioner/v1beta1/tenants`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Tenants
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateInviteWithContext - provisioner service endpoint
+Modifies an invitation in the given tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	inviteId
+	updateInviteBody
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateInviteWithContext(ctx context.Context, inviteId string, updateInviteBody UpdateInviteBody, resp ...*http.Response) (*InviteInfo, error) {
+	ctx = sdkservices.WithOperation(ctx, "provisioner", "UpdateInvite")
+	pp := struct {
+		InviteId string
+	}{
+		InviteId: inviteId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/provisioner/v1beta1/invites/{{.InviteId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updateInviteBody})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb InviteInfo
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
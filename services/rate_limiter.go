@@ -0,0 +1,305 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rateDecreaseFactor is applied to the current rate of a limit upon receiving a 429 response
+	rateDecreaseFactor = 0.5
+	// rateIncreaseFraction of the configured rate is added back to the current rate for every successful response
+	rateIncreaseFraction = 0.05
+	// defaultMinRateFraction of the configured rate is the lowest a limit adapts down to unless MinRate is set
+	defaultMinRateFraction = 0.01
+)
+
+// RateLimit is a token bucket limiting the rate of requests sent to a service cluster, an endpoint or both
+type RateLimit struct {
+	// Host is the (optional) host, as formed by BuildHost, the limit applies to e.g. "api.scp.splunk.com", all hosts if empty
+	Host string
+	// Endpoint is the (optional) path template the limit applies to, without the tenant e.g. "/ingest/v1beta2/events" or
+	// "/kvstore/v1beta1/collections/{{.Collection}}", segments of the form {{...}} match any value, all endpoints if empty
+	Endpoint string
+	// Rate is the number of requests per second allowed
+	Rate float64
+	// Burst is the (optional) number of requests which may be sent at once, the ceiling of Rate (at least 1) by default
+	Burst int
+	// MinRate is the (optional) lowest rate the limit adapts down to in response to throttling, 1% of Rate by default
+	MinRate float64
+}
+
+// RateLimiter is a client-side rate limiter made of token buckets which is consulted before every attempt of a
+// request, including retries. A request matching several limits must be allowed by all of them. The rate of each
+// limit adapts to 429 responses, Retry-After headers and RateLimit-Remaining/RateLimit-Reset (or X-RateLimit-*)
+// headers returned by the server. A single RateLimiter may be shared by the Config of several clients.
+type RateLimiter struct {
+	buckets []*bucket
+	// clock is used to refill buckets and wait for tokens, time package is used if nil
+	clock clock
+}
+
+// bucket is the state of a single RateLimit
+type bucket struct {
+	mux          sync.Mutex
+	limit        RateLimit
+	endpoint     []string
+	rate         float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// NewRateLimiter creates a RateLimiter with the limits passed in, limits with a non-positive Rate are ignored
+func NewRateLimiter(limits ...RateLimit) *RateLimiter {
+	limiter := &RateLimiter{}
+	for _, limit := range limits {
+		if limit.Rate <= 0 {
+			continue
+		}
+		if limit.Burst <= 0 {
+			limit.Burst = int(math.Ceil(limit.Rate))
+		}
+		if limit.MinRate <= 0 || limit.MinRate > limit.Rate {
+			limit.MinRate = limit.Rate * defaultMinRateFraction
+		}
+		b := &bucket{limit: limit, rate: limit.Rate, tokens: float64(limit.Burst)}
+		if limit.Endpoint != "" {
+			b.endpoint = strings.Split(strings.Trim(limit.Endpoint, "/"), "/")
+		}
+		limiter.buckets = append(limiter.buckets, b)
+	}
+	return limiter
+}
+
+// Rate returns the current, possibly adapted, rate of the limit for the given host and endpoint template
+func (l *RateLimiter) Rate(host string, endpoint string) (float64, bool) {
+	for _, b := range l.buckets {
+		if b.limit.Host == host && b.limit.Endpoint == endpoint {
+			b.mux.Lock()
+			defer b.mux.Unlock()
+			return b.rate, true
+		}
+	}
+	return 0, false
+}
+
+func (l *RateLimiter) getClock() clock {
+	if l.clock != nil {
+		return l.clock
+	}
+	return realClock{}
+}
+
+// matching returns the buckets whose limit applies to the request
+func (l *RateLimiter) matching(request *Request) []*bucket {
+	var segments []string
+	var matched []*bucket
+	for _, b := range l.buckets {
+		if b.limit.Host != "" && b.limit.Host != request.URL.Host {
+			continue
+		}
+		if b.endpoint != nil {
+			if segments == nil {
+				segments = endpointSegments(request.URL.Path)
+			}
+			if !matchEndpoint(b.endpoint, segments) {
+				continue
+			}
+		}
+		matched = append(matched, b)
+	}
+	return matched
+}
+
+// endpointSegments returns the segments of path without the tenant namespace
+func endpointSegments(path string) []string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && segments[0] != "system" {
+		segments = segments[1:]
+	}
+	return segments
+}
+
+func matchEndpoint(template []string, segments []string) bool {
+	if len(template) != len(segments) {
+		return false
+	}
+	for i, t := range template {
+		if strings.HasPrefix(t, "{{") && strings.HasSuffix(t, "}}") {
+			continue
+		}
+		if t != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Wait blocks until every limit matching the request allows it to be sent, it fails immediately if the request's
+// context would expire before then and returns the context's error if it expires while waiting
+func (l *RateLimiter) Wait(request *Request) error {
+	buckets := l.matching(request)
+	if len(buckets) == 0 {
+		return nil
+	}
+	ctx := request.Context()
+	clk := l.getClock()
+	now := clk.Now()
+	var wait time.Duration
+	for _, b := range buckets {
+		if w := b.reserve(now); w > wait {
+			wait = w
+		}
+	}
+	if wait <= 0 {
+		return nil
+	}
+	cancel := func() {
+		for _, b := range buckets {
+			b.cancel()
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(wait)) {
+		cancel()
+		return fmt.Errorf("services: rate limit wait of %s for %s exceeds context deadline: %w", wait, request.URL.Host, context.DeadlineExceeded)
+	}
+	timer, stop := clk.NewTimer(wait)
+	select {
+	case <-timer:
+		return nil
+	case <-ctx.Done():
+		stop()
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token from the bucket, possibly going into debt, and returns how long to wait before using it
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.refill(now)
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// cancel gives back a token taken by reserve
+func (b *bucket) cancel() {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.tokens = math.Min(b.tokens+1, float64(b.limit.Burst))
+}
+
+// refill must be called with b.mux held
+func (b *bucket) refill(now time.Time) {
+	if !b.last.IsZero() && now.After(b.last) {
+		b.tokens = math.Min(b.tokens+now.Sub(b.last).Seconds()*b.rate, float64(b.limit.Burst))
+	}
+	if b.last.IsZero() || now.After(b.last) {
+		b.last = now
+	}
+}
+
+// setRate must be called with b.mux held
+func (b *bucket) setRate(now time.Time, rate float64) {
+	b.refill(now)
+	b.rate = math.Max(b.limit.MinRate, math.Min(rate, b.limit.Rate))
+}
+
+// adapt updates the bucket from the response to a request it allowed
+func (b *bucket) adapt(now time.Time, response *http.Response) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if response.StatusCode == http.StatusTooManyRequests {
+		b.setRate(now, b.rate*rateDecreaseFactor)
+		if wait, ok := parseRetryAfter(response, now); ok && now.Add(wait).After(b.blockedUntil) {
+			b.blockedUntil = now.Add(wait)
+		}
+	}
+	if remaining, reset, ok := parseRateLimitHeaders(response.Header, now); ok {
+		if remaining <= 0 {
+			if now.Add(reset).After(b.blockedUntil) {
+				b.blockedUntil = now.Add(reset)
+			}
+		} else if reset > 0 {
+			b.setRate(now, remaining/reset.Seconds())
+		}
+		return
+	}
+	if response.StatusCode < 400 && b.rate < b.limit.Rate {
+		b.setRate(now, b.rate+b.limit.Rate*rateIncreaseFraction)
+	}
+}
+
+// parseRateLimitHeaders returns the number of requests remaining in the current window and the time until the
+// window resets from RateLimit-Remaining/RateLimit-Reset or X-RateLimit-Remaining/X-RateLimit-Reset headers,
+// the reset is either a number of seconds or, if large enough to be one, a unix timestamp
+func parseRateLimitHeaders(header http.Header, now time.Time) (float64, time.Duration, bool) {
+	for _, prefix := range []string{"RateLimit-", "X-RateLimit-"} {
+		remaining, err := strconv.ParseFloat(strings.TrimSpace(header.Get(prefix+"Remaining")), 64)
+		if err != nil {
+			continue
+		}
+		reset, err := strconv.ParseInt(strings.TrimSpace(header.Get(prefix+"Reset")), 10, 64)
+		if err != nil || reset < 0 {
+			continue
+		}
+		var resetIn time.Duration
+		if reset > 1e9 {
+			resetIn = time.Unix(reset, 0).Sub(now)
+		} else {
+			resetIn = time.Duration(reset) * time.Second
+		}
+		if resetIn < 0 {
+			resetIn = 0
+		}
+		return remaining, resetIn, true
+	}
+	return 0, 0, false
+}
+
+// BeforeAttempt waits until the request is allowed by the rate limiter
+func (l *RateLimiter) BeforeAttempt(client *BaseClient, request *Request) error {
+	return l.Wait(request)
+}
+
+// AfterAttempt adapts the rate of the limits matching the request from the response received
+func (l *RateLimiter) AfterAttempt(client *BaseClient, request *Request, response *http.Response, err error) {
+	if err != nil || response == nil {
+		return
+	}
+	now := l.getClock().Now()
+	for _, b := range l.matching(request) {
+		b.adapt(now, response)
+	}
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateLimitedClient(t *testing.T, limiter *RateLimiter, rt http.RoundTripper) *BaseClient {
+	client, err := NewClient(&Config{Token: "testtoken", RoundTripper: rt, RateLimiter: limiter})
+	require.NoError(t, err)
+	return client
+}
+
+func eventsURL(host string) url.URL {
+	return url.URL{Scheme: "https", Host: host, Path: "/tenant/ingest/v1beta2/events"}
+}
+
+func TestRateLimiterBlocksBeforeSending(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	limiter := NewRateLimiter(RateLimit{Host: "api.scp.splunk.com", Rate: 2, Burst: 2})
+	limiter.clock = clk
+	rt := &retryAfterRT{}
+	client := newRateLimitedClient(t, limiter, rt)
+
+	for i := 0; i < 4; i++ {
+		_, err := client.Post(services.RequestParams{URL: eventsURL("api.scp.splunk.com"), Body: []byte("[]")})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 4, rt.N)
+	// The burst of 2 goes through immediately, then one request every 500ms
+	assert.Equal(t, []time.Duration{500 * time.Millisecond, 500 * time.Millisecond}, clk.waits)
+
+	// Other hosts are not limited
+	_, err := client.Get(services.RequestParams{URL: eventsURL("tenant.api.scp.splunk.com")})
+	require.NoError(t, err)
+	assert.Len(t, clk.waits, 2)
+}
+
+func TestRateLimiterEndpointTemplate(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	limiter := NewRateLimiter(
+		RateLimit{Endpoint: "/kvstore/v1beta1/collections/{{.Collection}}/batch", Rate: 1},
+		RateLimit{Endpoint: "/ingest/v1beta2/events", Rate: 10},
+	)
+	limiter.clock = clk
+	client := newRateLimitedClient(t, limiter, &retryAfterRT{})
+
+	batch := url.URL{Scheme: "https", Host: "api.scp.splunk.com", Path: "/tenant/kvstore/v1beta1/collections/mycollection/batch"}
+	for i := 0; i < 2; i++ {
+		_, err := client.Post(services.RequestParams{URL: batch, Body: []byte("[]")})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []time.Duration{time.Second}, clk.waits)
+
+	// Endpoints matching no template are not limited
+	other := url.URL{Scheme: "https", Host: "api.scp.splunk.com", Path: "/tenant/kvstore/v1beta1/collections/mycollection/query"}
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(services.RequestParams{URL: other})
+		require.NoError(t, err)
+	}
+	assert.Len(t, clk.waits, 1)
+
+	rate, ok := limiter.Rate("", "/ingest/v1beta2/events")
+	require.True(t, ok)
+	assert.Equal(t, float64(10), rate)
+}
+
+func TestRateLimiterContextDeadline(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 0.1, Burst: 1})
+	rt := &retryAfterRT{}
+	client := newRateLimitedClient(t, limiter, rt)
+
+	_, err := client.Get(services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.NoError(t, err)
+
+	// The next token is 10s away, a context with a shorter deadline fails without waiting
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err = client.GetWithContext(ctx, services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, 1, rt.N)
+
+	// A canceled context stops the wait
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	_, err = client.GetWithContext(ctx, services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, rt.N)
+}
+
+func TestRateLimiterAdaptsToThrottling(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	limiter := NewRateLimiter(RateLimit{Rate: 10})
+	limiter.clock = clk
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, "2")}}
+	client := newRateLimitedClient(t, limiter, rt)
+
+	_, err := client.Get(services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.Error(t, err)
+	rate, _ := limiter.Rate("", "")
+	assert.Equal(t, float64(5), rate)
+
+	// The next request waits for the Retry-After duration, then succeeds and slowly increases the rate
+	_, err = client.Get(services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{2 * time.Second}, clk.waits)
+	rate, _ = limiter.Rate("", "")
+	assert.Equal(t, 5.5, rate)
+}
+
+func TestRateLimiterAdaptsToHeaders(t *testing.T) {
+	clk := &fakeClock{now: time.Unix(1000, 0)}
+	limiter := NewRateLimiter(RateLimit{Rate: 100})
+	limiter.clock = clk
+	ok := func(headers map[string]string) *http.Response {
+		resp := &http.Response{StatusCode: 200, Header: http.Header{}}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		return resp
+	}
+	rt := &retryAfterRT{responses: []*http.Response{
+		ok(map[string]string{"X-RateLimit-Remaining": "20", "X-RateLimit-Reset": "10"}),
+		ok(map[string]string{"RateLimit-Remaining": "0", "RateLimit-Reset": "3"}),
+	}}
+	client := newRateLimitedClient(t, limiter, rt)
+
+	_, err := client.Get(services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.NoError(t, err)
+	rate, _ := limiter.Rate("", "")
+	assert.Equal(t, float64(2), rate)
+
+	_, err = client.Get(services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.NoError(t, err)
+	// No requests remain in the window, the next request waits for the reset
+	_, err = client.Get(services.RequestParams{URL: eventsURL("api.scp.splunk.com")})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{3 * time.Second}, clk.waits)
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "5")
+	header.Set("X-RateLimit-Reset", "1700000030")
+	remaining, reset, ok := parseRateLimitHeaders(header, now)
+	require.True(t, ok)
+	assert.Equal(t, float64(5), remaining)
+	assert.Equal(t, 30*time.Second, reset)
+
+	_, _, ok = parseRateLimitHeaders(http.Header{}, now)
+	assert.False(t, ok)
+}
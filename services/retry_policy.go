@@ -0,0 +1,203 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// DefaultIdempotencyKeyHeader is the request header which marks a POST or PATCH request as safe to retry
+const DefaultIdempotencyKeyHeader = "Idempotency-Key"
+
+// RetryClass classifies the outcome of a request attempt for the purpose of retrying it
+type RetryClass int
+
+const (
+	// NotRetryable is an outcome that should never be retried: a success, a client error or an unknown error
+	NotRetryable RetryClass = iota
+	// RetryThrottled is a 429 response, the request was rejected before being processed
+	RetryThrottled
+	// RetryGateway is a 502, 503 or 504 response
+	RetryGateway
+	// RetryTimeout is a request error for which net.Error.Timeout() is true
+	RetryTimeout
+	// RetryConnectionReset is a connection reset, broken pipe or unexpected EOF while the request was in flight
+	RetryConnectionReset
+	// RetryConnectionRefused is a connection refused error, the request was never sent
+	RetryConnectionRefused
+	// RetryDNS is a temporary DNS failure, the request was never sent
+	RetryDNS
+)
+
+// String returns the name of the retry class
+func (c RetryClass) String() string {
+	switch c {
+	case RetryThrottled:
+		return "throttled"
+	case RetryGateway:
+		return "gateway"
+	case RetryTimeout:
+		return "timeout"
+	case RetryConnectionReset:
+		return "connection_reset"
+	case RetryConnectionRefused:
+		return "connection_refused"
+	case RetryDNS:
+		return "dns"
+	default:
+		return "not_retryable"
+	}
+}
+
+// notProcessed returns true for classes where the server is known not to have acted upon the request,
+// these are safe to retry regardless of the HTTP method
+func (c RetryClass) notProcessed() bool {
+	return c == RetryThrottled || c == RetryConnectionRefused || c == RetryDNS
+}
+
+// ClassifyRetry returns the RetryClass of a request attempt from the request error or response received
+func ClassifyRetry(reqErr error, response *http.Response) RetryClass {
+	if reqErr != nil {
+		var dnsErr *net.DNSError
+		switch {
+		case errors.As(reqErr, &dnsErr):
+			if dnsErr.IsTemporary || dnsErr.IsTimeout {
+				return RetryDNS
+			}
+			return NotRetryable
+		case errors.Is(reqErr, syscall.ECONNREFUSED):
+			return RetryConnectionRefused
+		case errors.Is(reqErr, syscall.ECONNRESET), errors.Is(reqErr, syscall.ECONNABORTED),
+			errors.Is(reqErr, syscall.EPIPE), errors.Is(reqErr, io.ErrUnexpectedEOF):
+			return RetryConnectionReset
+		}
+		var netErr net.Error
+		if errors.As(reqErr, &netErr) && netErr.Timeout() {
+			return RetryTimeout
+		}
+		return NotRetryable
+	}
+	if response == nil {
+		return NotRetryable
+	}
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return RetryThrottled
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return RetryGateway
+	}
+	return NotRetryable
+}
+
+// IsIdempotent returns true if the request can safely be sent more than once: GET, HEAD, OPTIONS, TRACE,
+// PUT and DELETE requests always are, other requests only if idempotencyKeyHeader is set on them
+func (r *Request) IsIdempotent(idempotencyKeyHeader string) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if idempotencyKeyHeader == "" {
+		idempotencyKeyHeader = DefaultIdempotencyKeyHeader
+	}
+	return r.Header.Get(idempotencyKeyHeader) != ""
+}
+
+// RetryPolicy decides whether to retry a request from the class of its failure and the idempotency of
+// its HTTP method. Failures where the request was not processed (throttling, refused connections and
+// temporary DNS failures) are retried for every method, other failures are only retried for idempotent
+// requests - POST and PATCH requests must opt in by setting the IdempotencyKeyHeader.
+type RetryPolicy struct {
+	// Classes restricts the classes of failures to retry, all retryable classes by default
+	Classes []RetryClass
+	// IdempotencyKeyHeader is the (optional) request header that marks a POST or PATCH request as safe to retry,
+	// DefaultIdempotencyKeyHeader by default
+	IdempotencyKeyHeader string
+}
+
+// ShouldRetry implements ShouldRetry for the policy and can be used as ConfigurableRetryConfig.ShouldRetryFn
+func (p RetryPolicy) ShouldRetry(request *Request, reqErr error, response *http.Response, maxRetries uint) bool {
+	if request == nil || request.NumAttempts > maxRetries {
+		return false
+	}
+	class := ClassifyRetry(reqErr, response)
+	if class == NotRetryable || !p.allows(class) {
+		return false
+	}
+	return class.notProcessed() || request.IsIdempotent(p.IdempotencyKeyHeader)
+}
+
+func (p RetryPolicy) allows(class RetryClass) bool {
+	if len(p.Classes) == 0 {
+		return true
+	}
+	for _, c := range p.Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// NewStandardRetryStrategyConfig returns a RetryStrategyConfig suited to interactive callers: every
+// retryable failure is retried using the default RetryPolicy, up to 4 times within 30 seconds
+func NewStandardRetryStrategyConfig() RetryStrategyConfig {
+	return RetryStrategyConfig{
+		ConfigurableRetryConfig: &ConfigurableRetryConfig{
+			RetryNum:         4,
+			Interval:         250,
+			ShouldRetryFn:    RetryPolicy{}.ShouldRetry,
+			Jitter:           EqualJitter,
+			MaxBackoff:       8 * time.Second,
+			MaxRetryDuration: 30 * time.Second,
+		},
+	}
+}
+
+// NewThrottlingRetryStrategyConfig returns a RetryStrategyConfig which only retries throttled (429) requests,
+// for every method, using full jitter so that throttled clients don't retry in lockstep
+func NewThrottlingRetryStrategyConfig() RetryStrategyConfig {
+	return RetryStrategyConfig{
+		ConfigurableRetryConfig: &ConfigurableRetryConfig{
+			RetryNum:         defaultMaxRetryCount,
+			Interval:         defaultIntervalMillis,
+			ShouldRetryFn:    RetryPolicy{Classes: []RetryClass{RetryThrottled}}.ShouldRetry,
+			Jitter:           FullJitter,
+			MaxBackoff:       30 * time.Second,
+			MaxRetryDuration: 2 * time.Minute,
+		},
+	}
+}
+
+// NewBackgroundRetryStrategyConfig returns a RetryStrategyConfig suited to background workers which favor
+// eventual success over latency: every retryable failure is retried up to 10 times within 10 minutes
+func NewBackgroundRetryStrategyConfig() RetryStrategyConfig {
+	return RetryStrategyConfig{
+		ConfigurableRetryConfig: &ConfigurableRetryConfig{
+			RetryNum:         10,
+			Interval:         defaultIntervalMillis,
+			ShouldRetryFn:    RetryPolicy{}.ShouldRetry,
+			Jitter:           DecorrelatedJitter,
+			MaxBackoff:       time.Minute,
+			MaxRetryDuration: 10 * time.Minute,
+		},
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+func TestClassifyRetry(t *testing.T) {
+	urlErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://api.scp.splunk.com", Err: err}
+	}
+	cases := []struct {
+		err      error
+		status   int
+		expected RetryClass
+	}{
+		{status: 200, expected: NotRetryable},
+		{status: 400, expected: NotRetryable},
+		{status: 500, expected: NotRetryable},
+		{status: 429, expected: RetryThrottled},
+		{status: 502, expected: RetryGateway},
+		{status: 503, expected: RetryGateway},
+		{status: 504, expected: RetryGateway},
+		{err: urlErr(syscall.ECONNRESET), expected: RetryConnectionReset},
+		{err: urlErr(&net.OpError{Op: "write", Err: os.NewSyscallError("write", syscall.EPIPE)}), expected: RetryConnectionReset},
+		{err: urlErr(io.ErrUnexpectedEOF), expected: RetryConnectionReset},
+		{err: urlErr(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), expected: RetryConnectionRefused},
+		{err: urlErr(&net.DNSError{Err: "server misbehaving", IsTemporary: true}), expected: RetryDNS},
+		{err: urlErr(&net.DNSError{Err: "no such host", IsNotFound: true}), expected: NotRetryable},
+		{err: urlErr(timeoutErr{}), expected: RetryTimeout},
+		{err: errors.New("boom"), expected: NotRetryable},
+	}
+	for _, c := range cases {
+		var resp *http.Response
+		if c.err == nil {
+			resp = &http.Response{StatusCode: c.status}
+		}
+		assert.Equal(t, c.expected, ClassifyRetry(c.err, resp), fmt.Sprintf("err: %v, status: %d", c.err, c.status))
+	}
+}
+
+func newPolicyRequest(t *testing.T, method string, headers map[string]string) *Request {
+	client, err := NewClient(&Config{Token: "testtoken"})
+	require.NoError(t, err)
+	request, err := client.NewRequest(method, "https://api.scp.splunk.com/tenant/widgets", nil, headers)
+	require.NoError(t, err)
+	request.NumAttempts = 1
+	return request
+}
+
+func TestRetryPolicyIdempotency(t *testing.T) {
+	policy := RetryPolicy{}
+	gateway := &http.Response{StatusCode: 503}
+	throttled := &http.Response{StatusCode: 429}
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		assert.True(t, policy.ShouldRetry(newPolicyRequest(t, method, nil), nil, gateway, 3), method)
+	}
+	// POST and PATCH are only retried for gateway errors when the caller provides an idempotency key
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		assert.False(t, policy.ShouldRetry(newPolicyRequest(t, method, nil), nil, gateway, 3), method)
+		assert.False(t, policy.ShouldRetry(newPolicyRequest(t, method, nil), syscall.ECONNRESET, nil, 3), method)
+		keyed := newPolicyRequest(t, method, map[string]string{DefaultIdempotencyKeyHeader: "abc"})
+		assert.True(t, policy.ShouldRetry(keyed, nil, gateway, 3), method)
+		// throttled and refused requests were never processed and are always safe to retry
+		assert.True(t, policy.ShouldRetry(newPolicyRequest(t, method, nil), nil, throttled, 3), method)
+		assert.True(t, policy.ShouldRetry(newPolicyRequest(t, method, nil), syscall.ECONNREFUSED, nil, 3), method)
+	}
+
+	custom := RetryPolicy{IdempotencyKeyHeader: "X-Request-Id"}
+	assert.False(t, custom.ShouldRetry(newPolicyRequest(t, http.MethodPost, map[string]string{DefaultIdempotencyKeyHeader: "abc"}), nil, gateway, 3))
+	assert.True(t, custom.ShouldRetry(newPolicyRequest(t, http.MethodPost, map[string]string{"X-Request-Id": "abc"}), nil, gateway, 3))
+}
+
+func TestRetryPolicyClassesAndMaxRetries(t *testing.T) {
+	policy := RetryPolicy{Classes: []RetryClass{RetryThrottled}}
+	assert.True(t, policy.ShouldRetry(newPolicyRequest(t, http.MethodGet, nil), nil, &http.Response{StatusCode: 429}, 3))
+	assert.False(t, policy.ShouldRetry(newPolicyRequest(t, http.MethodGet, nil), nil, &http.Response{StatusCode: 503}, 3))
+
+	request := newPolicyRequest(t, http.MethodGet, nil)
+	request.NumAttempts = 4
+	assert.False(t, RetryPolicy{}.ShouldRetry(request, nil, &http.Response{StatusCode: 503}, 3))
+	assert.False(t, RetryPolicy{}.ShouldRetry(nil, nil, &http.Response{StatusCode: 503}, 3))
+}
+
+func TestDefaultShouldRetryFn(t *testing.T) {
+	gateway := &http.Response{StatusCode: 503}
+	assert.True(t, defaultShouldRetryFn(newPolicyRequest(t, http.MethodGet, nil), nil, gateway, 3))
+	assert.True(t, defaultShouldRetryFn(newPolicyRequest(t, http.MethodGet, nil), timeoutErr{}, nil, 3))
+	assert.True(t, defaultShouldRetryFn(newPolicyRequest(t, http.MethodPost, nil), nil, &http.Response{StatusCode: 429}, 3))
+	assert.False(t, defaultShouldRetryFn(newPolicyRequest(t, http.MethodPost, nil), nil, gateway, 3),
+		"the default policy doesn't retry non-idempotent requests which may have been processed")
+	assert.False(t, defaultShouldRetryFn(newPolicyRequest(t, http.MethodPost, nil), syscall.ECONNRESET, nil, 3))
+}
+
+// gatewayRT fails with a 502, a 504 and a timeout then succeeds
+type gatewayRT struct {
+	N int
+}
+
+func (rt *gatewayRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.N++
+	b := ioutil.NopCloser(bytes.NewReader([]byte("")))
+	switch rt.N {
+	case 1:
+		return &http.Response{Status: "502 Bad Gateway", StatusCode: 502, Body: b}, nil
+	case 2:
+		return &http.Response{Status: "504 Gateway Timeout", StatusCode: 504, Body: b}, nil
+	case 3:
+		return nil, timeoutErr{}
+	}
+	return &http.Response{Status: "200 OK", StatusCode: 200, Body: b}, nil
+}
+
+func TestClientStandardRetryStrategy(t *testing.T) {
+	strategy := NewStandardRetryStrategyConfig()
+	strategy.ConfigurableRetryConfig.clock = &fakeClock{now: time.Unix(1000, 0)}
+
+	rt := &gatewayRT{}
+	client, err := NewClient(&Config{Token: "testtoken", RetryRequests: true, RetryConfig: strategy, RoundTripper: rt})
+	require.NoError(t, err)
+	resp, err := client.Get(services.RequestParams{})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 4, rt.N)
+
+	// Without an idempotency key, a POST is not retried after a gateway error
+	rt = &gatewayRT{}
+	client, err = NewClient(&Config{Token: "testtoken", RetryRequests: true, RetryConfig: strategy, RoundTripper: rt})
+	require.NoError(t, err)
+	_, err = client.Post(services.RequestParams{Body: []byte("{}")})
+	require.Error(t, err)
+	assert.Equal(t, 1, rt.N)
+
+	rt = &gatewayRT{}
+	client, err = NewClient(&Config{Token: "testtoken", RetryRequests: true, RetryConfig: strategy, RoundTripper: rt})
+	require.NoError(t, err)
+	resp, err = client.Post(services.RequestParams{Body: []byte("{}"), Headers: map[string]string{DefaultIdempotencyKeyHeader: "key-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 4, rt.N)
+}
+
+func TestRetryStrategyPresets(t *testing.T) {
+	for _, strategy := range []RetryStrategyConfig{NewStandardRetryStrategyConfig(), NewThrottlingRetryStrategyConfig(), NewBackgroundRetryStrategyConfig()} {
+		config := strategy.ConfigurableRetryConfig
+		require.NotNil(t, config)
+		assert.NotNil(t, config.ShouldRetryFn)
+		assert.True(t, config.MaxBackoff > 0)
+		assert.True(t, config.MaxRetryDuration > config.MaxBackoff)
+	}
+}
@@ -0,0 +1,316 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+ExportResultsTo - Exports the search results for the job with the specified search ID (SID) to w, as a CSV file or JSON file.
+The body of the response is copied to w as it's received, so that exports of any size are written with constant memory.
+The number of bytes written is returned, along with the error of the request or of the copy.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	w: the writer the export is copied to, e.g. a file
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ExportResultsTo(ctx context.Context, sid string, w io.Writer, query *ExportResultsQueryParams, resp ...*http.Response) (int64, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ExportResults")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs/{{.Sid}}/export`, pp)
+	if err != nil {
+		return 0, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(w, response.Body)
+	if err != nil {
+		return n, fmt.Errorf("search: export of job %s interrupted after %d bytes: %w", sid, n, err)
+	}
+	return n, nil
+}
+
+// ExportDecoder reads the rows of an export one at a time, in either output mode. Its cursor starts before
+// the first row, use Next() to advance through the rows:
+//
+//	pr, pw := io.Pipe()
+//	go func() {
+//		_, err := client.SearchService.ExportResultsTo(ctx, sid, pw, &query)
+//		pw.CloseWithError(err)
+//	}()
+//	rows := search.NewExportDecoder(pr, search.ExportResultsoutputModeCsv)
+//	for rows.Next() {
+//		row := rows.Row()
+//		...
+//	}
+//	err := rows.Err() // get any error encountered during iteration
+//
+// The CSV exports start with a header naming the fields, the empty values are left out of the rows.
+// The JSON exports are either arrays of results, objects holding an array of results under their results key,
+// or sequences of results. The results wrapped in an object under its result key are unwrapped.
+type ExportDecoder struct {
+	row  map[string]interface{}
+	err  error
+	next func() (map[string]interface{}, error)
+
+	csv    *csv.Reader
+	header []string
+
+	json *json.Decoder
+	// inArray is whether the decoder is within an array of results
+	inArray bool
+	// inObject is whether the array of results is held by an object
+	inObject bool
+}
+
+/*
+NewExportDecoder - Returns a decoder reading the rows of an export from r.
+Parameters:
+
+	r: the export, e.g. the reader of a pipe ExportResultsTo writes to
+	mode: the output mode of the export, json if empty
+*/
+func NewExportDecoder(r io.Reader, mode ExportResultsoutputMode) *ExportDecoder {
+	d := &ExportDecoder{}
+	switch mode {
+	case ExportResultsoutputModeCsv:
+		d.csv = csv.NewReader(r)
+		d.csv.FieldsPerRecord = -1
+		d.csv.ReuseRecord = true
+		d.next = d.nextCSV
+	case ExportResultsoutputModeJson, "":
+		d.json = json.NewDecoder(r)
+		d.next = d.nextJSON
+	default:
+		d.err = fmt.Errorf("search: unsupported export output mode %q", mode)
+	}
+	return d
+}
+
+// Next decodes the next row. It returns false at the end of the export or if an error occurred, see Err.
+func (d *ExportDecoder) Next() bool {
+	d.row = nil
+	if d.err != nil {
+		return false
+	}
+	row, err := d.next()
+	if err != nil {
+		if err != io.EOF {
+			d.err = fmt.Errorf("search: can't decode export: %w", err)
+		}
+		return false
+	}
+	d.row = row
+	return true
+}
+
+// Row returns the current row
+func (d *ExportDecoder) Row() map[string]interface{} {
+	return d.row
+}
+
+// Decode decodes the current row into v, a pointer to a struct, see DecodeRow
+func (d *ExportDecoder) Decode(v interface{}) error {
+	return DecodeRow(d.row, v)
+}
+
+// Err returns the error encountered while decoding
+func (d *ExportDecoder) Err() error {
+	return d.err
+}
+
+func (d *ExportDecoder) nextCSV() (map[string]interface{}, error) {
+	if d.header == nil {
+		header, err := d.csv.Read()
+		if err != nil {
+			return nil, err
+		}
+		d.header = append([]string(nil), header...)
+	}
+	record, err := d.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := make(map[string]interface{}, len(d.header))
+	for i, value := range record {
+		if i < len(d.header) && value != "" {
+			row[d.header[i]] = value
+		}
+	}
+	return row, nil
+}
+
+func (d *ExportDecoder) nextJSON() (map[string]interface{}, error) {
+	for {
+		if d.inArray {
+			if d.json.More() {
+				var result map[string]interface{}
+				if err := d.json.Decode(&result); err != nil {
+					return nil, unexpectedEOF(err)
+				}
+				return unwrapResult(result), nil
+			}
+			if err := d.closeDelim(']'); err != nil {
+				return nil, err
+			}
+			d.inArray = false
+			if d.inObject {
+				// Skip the keys following the results
+				for d.json.More() {
+					var skipped json.RawMessage
+					if _, err := d.json.Token(); err != nil {
+						return nil, unexpectedEOF(err)
+					}
+					if err := d.json.Decode(&skipped); err != nil {
+						return nil, unexpectedEOF(err)
+					}
+				}
+				if err := d.closeDelim('}'); err != nil {
+					return nil, err
+				}
+				d.inObject = false
+			}
+			continue
+		}
+		// The end of the export is only expected between two top-level values
+		token, err := d.json.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token {
+		case json.Delim('['):
+			d.inArray = true
+		case json.Delim('{'):
+			result, err := d.readObject(true)
+			if err != nil {
+				return nil, err
+			}
+			if result != nil {
+				return unwrapResult(result), nil
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %v instead of a result", token)
+		}
+	}
+}
+
+// readObject reads an object once its opening brace is read. It returns nil when a top-level object holds an
+// array of results under its results key, the decoder being then within that array.
+func (d *ExportDecoder) readObject(top bool) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	for d.json.More() {
+		token, err := d.json.Token()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		key, _ := token.(string)
+		if top && key == "results" {
+			token, err := d.json.Token()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if token == json.Delim('[') {
+				d.inArray, d.inObject = true, true
+				return nil, nil
+			}
+			if object[key], err = d.readValue(token); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var value interface{}
+		if err := d.json.Decode(&value); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		object[key] = value
+	}
+	return object, d.closeDelim('}')
+}
+
+// readValue reads the value starting with token
+func (d *ExportDecoder) readValue(token json.Token) (interface{}, error) {
+	switch token {
+	case json.Delim('['):
+		values := []interface{}{}
+		for d.json.More() {
+			var value interface{}
+			if err := d.json.Decode(&value); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			values = append(values, value)
+		}
+		return values, d.closeDelim(']')
+	case json.Delim('{'):
+		return d.readObject(false)
+	}
+	return token, nil
+}
+
+func (d *ExportDecoder) closeDelim(delim json.Delim) error {
+	token, err := d.json.Token()
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if token != delim {
+		return fmt.Errorf("unexpected %v instead of %v", token, delim)
+	}
+	return nil
+}
+
+// unwrapResult returns the result wrapped in result under its result key, if any
+func unwrapResult(result map[string]interface{}) map[string]interface{} {
+	if wrapped, ok := result["result"].(map[string]interface{}); ok {
+		return wrapped
+	}
+	return result
+}
+
+// unexpectedEOF reports the end of the export within a value as an error
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
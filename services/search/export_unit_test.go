@@ -0,0 +1,147 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportRT serves an export of rows results in the requested output mode
+type exportRT struct {
+	rows  int
+	query string
+}
+
+func (rt *exportRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.query = req.URL.RawQuery
+	if !strings.Contains(req.URL.Path, "/jobs/sid/") {
+		return &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{"Content-Type": {"application/json"}},
+			Body: io.NopCloser(strings.NewReader(`{"text":"job not found"}`)), Request: req}, nil
+	}
+	pr, pw := io.Pipe()
+	csvMode := req.URL.Query().Get("outputMode") == "csv"
+	go func() {
+		if csvMode {
+			fmt.Fprintln(pw, "host,status")
+		}
+		for i := 0; i < rt.rows; i++ {
+			if csvMode {
+				fmt.Fprintf(pw, "web-%d,%d\n", i, 200+i%3)
+			} else {
+				fmt.Fprintf(pw, `{"preview":false,"result":{"host":"web-%d","status":"%d"}}`+"\n", i, 200+i%3)
+			}
+		}
+		pw.Close()
+	}()
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: pr, Request: req}, nil
+}
+
+func TestExportResultsTo(t *testing.T) {
+	rt := &exportRT{rows: 10000}
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt, TypedErrors: true})
+	require.NoError(t, err)
+	service := NewService(client)
+
+	var buf bytes.Buffer
+	query := ExportResultsQueryParams{}.SetOutputMode(ExportResultsoutputModeCsv)
+	n, err := service.ExportResultsTo(context.Background(), "sid", &buf, &query)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Contains(t, rt.query, "outputMode=csv")
+	assert.True(t, strings.HasPrefix(buf.String(), "host,status\nweb-0,200\n"), "CSV exports are copied as is")
+
+	// The export is decoded while it's received
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := service.ExportResultsTo(context.Background(), "sid", pw, nil)
+		pw.CloseWithError(err)
+	}()
+	rows := NewExportDecoder(pr, ExportResultsoutputModeJson)
+	count := 0
+	for rows.Next() {
+		var r request
+		require.NoError(t, rows.Decode(&r))
+		assert.Equal(t, fmt.Sprintf("web-%d", count), r.Host)
+		assert.Equal(t, 200+count%3, r.Status)
+		count++
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, rt.rows, count)
+
+	_, err = service.ExportResultsTo(context.Background(), "missing", &buf, nil)
+	assert.True(t, errors.Is(err, services.ErrNotFound))
+}
+
+func decodeExport(t *testing.T, export string, mode ExportResultsoutputMode) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+	d := NewExportDecoder(strings.NewReader(export), mode)
+	for d.Next() {
+		rows = append(rows, d.Row())
+	}
+	assert.Nil(t, d.Row())
+	return rows, d.Err()
+}
+
+func TestExportDecoder(t *testing.T) {
+	rows, err := decodeExport(t, "host,status,tag\nweb-1,200,\"a\nb\"\nweb-2,,\n", ExportResultsoutputModeCsv)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"host": "web-1", "status": "200", "tag": "a\nb"},
+		{"host": "web-2"},
+	}, rows, "empty CSV values are left out")
+
+	expected := []map[string]interface{}{{"host": "web-1", "status": float64(200)}, {"host": "web-2", "status": float64(500)}}
+	for _, export := range []string{
+		`[{"host":"web-1","status":200},{"host":"web-2","status":500}]`,
+		`{"results":[{"host":"web-1","status":200},{"host":"web-2","status":500}],"fields":[{"name":"host"}]}`,
+		`{"fields":[{"name":"host"}],"results":[{"host":"web-1","status":200},{"host":"web-2","status":500}]}`,
+		"{\"result\":{\"host\":\"web-1\",\"status\":200}}\n{\"host\":\"web-2\",\"status\":500}\n",
+	} {
+		rows, err := decodeExport(t, export, "")
+		require.NoError(t, err, export)
+		assert.Equal(t, expected, rows, export)
+	}
+	rows, err = decodeExport(t, `{"host":"web-1","results":"many","nested":{"results":[1]}}`, ExportResultsoutputModeJson)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"host": "web-1", "results": "many", "nested": map[string]interface{}{"results": []interface{}{float64(1)}}}}, rows)
+
+	rows, err = decodeExport(t, `[{"host":"web-1"},{"host":`, ExportResultsoutputModeJson)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), "truncated exports are errors")
+	assert.Len(t, rows, 1)
+	_, err = decodeExport(t, `{"results":[]`, ExportResultsoutputModeJson)
+	assert.Error(t, err)
+	_, err = decodeExport(t, `[1]`, ExportResultsoutputModeJson)
+	assert.Error(t, err)
+	_, err = decodeExport(t, `"text"`, ExportResultsoutputModeJson)
+	assert.Error(t, err)
+	_, err = decodeExport(t, "a,b", "xml")
+	assert.Error(t, err)
+	rows, err = decodeExport(t, "", ExportResultsoutputModeCsv)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+}
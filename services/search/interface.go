@@ -19,6 +19,9 @@
 package search
 
 import (
+	"context"
+	"io"
+	"net/http"
 	"time"
 )
 
@@ -27,7 +30,41 @@ type Servicer interface {
 	//interfaces that cannot be auto-generated from codegen
 	// WaitForJob polls the job until it's completed or errors out
 	WaitForJob(jobID string, pollInterval time.Duration) (interface{}, error)
+	/*
+		WaitForJobCtx - Polls the job until it's done, finalized, canceled or failed and returns it. The wait between two polls
+		adapts to the progress of the job, see WaitOptions.
+		The job is returned along with a *JobFailedError if it failed. If ctx is done first, the job is updated to
+		opts.OnTimeout if set and returned along with an error wrapping the error of ctx.
+		Parameters:
+			ctx: the context of the wait, its deadline bounds how long to wait for
+			sid: The search ID.
+			opts: the options of the wait, the defaults are used if nil
+	*/
+	WaitForJobCtx(ctx context.Context, sid string, opts *WaitOptions) (*SearchJob, error)
+	/*
+		ExportResultsTo - Exports the search results for the job with the specified search ID (SID) to w, as a CSV file or JSON file.
+		The body of the response is copied to w as it's received, so that exports of any size are written with constant memory.
+		The number of bytes written is returned, along with the error of the request or of the copy.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			w: the writer the export is copied to, e.g. a file
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ExportResultsTo(ctx context.Context, sid string, w io.Writer, query *ExportResultsQueryParams, resp ...*http.Response) (int64, error)
+	/*
+		NewResultsIterator - Returns an iterator over the results of a job, fetched with ListResults or with ListPreviewResults
+		for its preview results. The iterator must be closed once done with, to cancel any request prefetching results.
+		Parameters:
+			ctx: the context of the requests fetching the results
+			sid: The search ID.
+			opts: the options of the iterator, the defaults are used if nil
+	*/
+	NewResultsIterator(ctx context.Context, sid string, opts *IteratorOptions) *Iterator
 
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
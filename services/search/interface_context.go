@@ -0,0 +1,239 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		CreateDatasetWithContext - search service endpoint
+		Creates a dataset.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetPost
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateDatasetWithContext(ctx context.Context, datasetPost DatasetPost, resp ...*http.Response) (*Dataset, error)
+	/*
+		CreateFederatedConnectionWithContext - search service endpoint
+		Creates a new federated connection with information about how to connect to a remote index.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			federatedConnectionInput
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateFederatedConnectionWithContext(ctx context.Context, federatedConnectionInput FederatedConnectionInput, resp ...*http.Response) (*FederatedConnection, error)
+	/*
+		CreateJobWithContext - search service endpoint
+		Creates a search job.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			searchJob
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateJobWithContext(ctx context.Context, searchJob SearchJob, resp ...*http.Response) (*SearchJob, error)
+	/*
+		DeleteDatasetByIdWithContext - search service endpoint
+		Deletes a dataset with a specified dataset ID (datasetid).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetid: The dataset ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteDatasetByIdWithContext(ctx context.Context, datasetid string, resp ...*http.Response) error
+	/*
+		DeleteFederatedConnectionWithContext - search service endpoint
+		Deletes a federated connection with the specified name (connectionName)
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionName: The name of the federated connection.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteFederatedConnectionWithContext(ctx context.Context, connectionName string, resp ...*http.Response) error
+	/*
+		DeleteJobWithContext - search service endpoint
+		Creates a search job that deletes events from an index. The events are deleted from the index in the specified module, based on the search criteria as specified by the predicate.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			deleteSearchJob
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteJobWithContext(ctx context.Context, deleteSearchJob DeleteSearchJob, resp ...*http.Response) (*DeleteSearchJob, error)
+	/*
+		ExportResultsWithContext - search service endpoint
+		Exports the search results for the job with the specified search ID (SID). Export the results as a CSV file or JSON file.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ExportResultsWithContext(ctx context.Context, sid string, query *ExportResultsQueryParams, resp ...*http.Response) (*map[string]interface{}, error)
+	/*
+		GetAllFederatedConnectionsWithContext - search service endpoint
+		Returns all federated connections.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetAllFederatedConnectionsWithContext(ctx context.Context, resp ...*http.Response) (*ListFederatedConnections, error)
+	/*
+		GetDatasetByIdWithContext - search service endpoint
+		Returns a dataset with a specified dataset ID (datasetid).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetid: The dataset ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetDatasetByIdWithContext(ctx context.Context, datasetid string, resp ...*http.Response) (*Dataset, error)
+	/*
+		GetFederatedConnectionByNameWithContext - search service endpoint
+		Returns the federated connection with the specified name (connectionName).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionName: The name of the federated connection.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetFederatedConnectionByNameWithContext(ctx context.Context, connectionName string, resp ...*http.Response) (*FederatedConnection, error)
+	/*
+		GetJobWithContext - search service endpoint
+		Returns the search job with the specified search ID (SID).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetJobWithContext(ctx context.Context, sid string, resp ...*http.Response) (*SearchJob, error)
+	/*
+		ListDatasetsWithContext - search service endpoint
+		Returns a list of all datasets.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListDatasetsWithContext(ctx context.Context, resp ...*http.Response) (*ListDatasets, error)
+	/*
+		ListEventsSummaryWithContext - search service endpoint
+		Returns an events summary for search ID (SID) search.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListEventsSummaryWithContext(ctx context.Context, sid string, query *ListEventsSummaryQueryParams, resp ...*http.Response) (*ListSearchResultsResponse, error)
+	/*
+		ListFieldsSummaryWithContext - search service endpoint
+		Returns a fields stats summary of the events to-date, for search ID (SID) search.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListFieldsSummaryWithContext(ctx context.Context, sid string, query *ListFieldsSummaryQueryParams, resp ...*http.Response) (*FieldsSummary, error)
+	/*
+		ListJobsWithContext - search service endpoint
+		Returns the matching list of search jobs.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListJobsWithContext(ctx context.Context, query *ListJobsQueryParams, resp ...*http.Response) ([]SearchJob, error)
+	/*
+		ListPreviewResultsWithContext - search service endpoint
+		Returns the preview search results for the job with the specified search ID (SID). Can be used when a job is running to return interim results.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListPreviewResultsWithContext(ctx context.Context, sid string, query *ListPreviewResultsQueryParams, resp ...*http.Response) (*ListPreviewResultsResponse, error)
+	/*
+		ListResultsWithContext - search service endpoint
+		Returns the search results for the job with the specified search ID (SID).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListResultsWithContext(ctx context.Context, sid string, query *ListResultsQueryParams, resp ...*http.Response) (*ListSearchResultsResponse, error)
+	/*
+		ListTimeBucketsWithContext - search service endpoint
+		Returns the event distribution over time of the untransformed events read to-date, for search ID(SID) search.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListTimeBucketsWithContext(ctx context.Context, sid string, resp ...*http.Response) (*TimeBucketsSummary, error)
+	/*
+		PutFederatedConnectionByNameWithContext - search service endpoint
+		Creates or updates a federated connection with a specified name (connectionName).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionName: The name of the federated connection.
+			federatedConnectionInput
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PutFederatedConnectionByNameWithContext(ctx context.Context, connectionName string, federatedConnectionInput FederatedConnectionInput, resp ...*http.Response) (*FederatedConnection, error)
+	/*
+		RefreshFederatedConnectionWithContext - search service endpoint
+		Refresh a federated connection to fetch new remote indexes and add/delete corresponding federated datasets.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionName: The name of the federated connection.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	RefreshFederatedConnectionWithContext(ctx context.Context, connectionName string, resp ...*http.Response) error
+	/*
+		TestFederatedConnectionWithContext - search service endpoint
+		Test connection with remote EC instance using federated connection parameters.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionName: The name of the federated connection.
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	TestFederatedConnectionWithContext(ctx context.Context, connectionName string, resp ...*http.Response) error
+	/*
+		UpdateDatasetByIdWithContext - search service endpoint
+		Modifies a dataset with a specified dataset ID (datasetid).
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			datasetid: The dataset ID.
+			datasetPatch
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateDatasetByIdWithContext(ctx context.Context, datasetid string, datasetPatch DatasetPatch, resp ...*http.Response) (*Dataset, error)
+	/*
+		UpdateJobWithContext - search service endpoint
+		Updates the search job with the specified search ID (SID) with an action.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			sid: The search ID.
+			updateJob
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateJobWithContext(ctx context.Context, sid string, updateJob UpdateJob, resp ...*http.Response) (*SearchJob, error)
+}
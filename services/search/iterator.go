@@ -17,40 +17,73 @@
 package search
 
 import (
+	"context"
 	"errors"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
 )
 
-// QueryFunc is the function to be executed in each Next call of the iterator
-type QueryFunc func(step, start int) (*ListSearchResultsResponse, error)
+// QueryFunc is the function to be executed in each Next call of the iterator, it returns count results starting
+// at the start index of the result set
+type QueryFunc func(count, start int) (*ListSearchResultsResponse, error)
 
 // Iterator is the result of a search query. Its cursor starts at 0 index
-// of the result set. Use Next() to advance through the rows:
+// of the result set. Use Next() to advance through the pages of results:
+//
+//	iterator := client.SearchService.NewResultsIterator(ctx, sid, &search.IteratorOptions{BatchSize: 100})
+//	defer iterator.Close()
+//	for iterator.Next() {
+//		page, err := iterator.Value()
+//		...
+//	}
+//	err := iterator.Err() // get any error encountered during iteration
 //
-//	 search, _ := client.SearchService.SubmitSearch(&PostJobsRequest{Search: "search index=main | head 5"})
-//		pages, _ := search.QueryResults(2, 0, &FetchResultsRequest{Count: 5})
-//		defer pages.Close()
-//		for pages.Next() {
-//			values, err := pages.Value()
-//	     ...
+// or range over its results with All:
 //
-//		}
-//		err := pages.Err() // get any error encountered during iteration
-//	 ...
+//	for result, err := range iterator.All() {
+//		...
+//	}
 type Iterator struct {
 	value    *ListSearchResultsResponse // stores current value
-	max      int                        // max number of results
-	start    int                        // index to start with fetching results, same concept as "offset"
+	max      int                        // max number of results, no limit if 0
 	offset   int                        // offset value to start iterator with. e.g. offset=5 means iterator will skip the first 5 results
 	batch    int                        // batch size of results in each Next call
 	err      error                      // error encountered during iteration
 	fn       QueryFunc                  // function to be executed in each Next call
 	isClosed bool                       // signal indicating status of the iterator
+	prefetch bool                       // whether the next page is fetched while the current one is processed
+	// next pulls the next page from the pager of the iterator, stop stops it. They're set by the first Next call.
+	next   func() ([]*ListSearchResultsResponse, error, bool)
+	stop   func()
+	cancel context.CancelFunc // cancels the requests of the iterator, if any
+}
+
+// IteratorOptions configures Service.NewResultsIterator
+type IteratorOptions struct {
+	// BatchSize is the number of results fetched per request, DefaultRowsPageSize if 0
+	BatchSize int
+	// Offset is the index of the first result
+	Offset int
+	// Max is the maximum number of results, all the results if 0
+	Max int
+	// Preview iterates over the preview results of the job, available while it's running, instead of its final results
+	Preview bool
+	// Prefetch fetches the next page of results while the current one is processed
+	Prefetch bool
 }
 
-// NewIterator creates a new reference to the iterator object
+// NewIterator creates a new reference to the iterator object, fetching batch results per Next call
+// (DefaultRowsPageSize if 0) starting at offset, up to max results after offset (no limit if 0). The iteration
+// stops at the first page holding fewer results than requested.
+//
+// Note that max 0 used to stop the iteration after the first page, it now fetches all the results. Pass the
+// number of results wanted as max to keep fetching a single page.
 func NewIterator(batch, offset, max int, fn QueryFunc) *Iterator {
+	if batch <= 0 {
+		batch = DefaultRowsPageSize
+	}
 	return &Iterator{
-		start:    offset,
 		batch:    batch,
 		max:      max,
 		fn:       fn,
@@ -59,6 +92,40 @@ func NewIterator(batch, offset, max int, fn QueryFunc) *Iterator {
 	}
 }
 
+/*
+NewResultsIterator - Returns an iterator over the results of a job, fetched with ListResults or with ListPreviewResults
+for its preview results. The iterator must be closed once done with, to cancel any request prefetching results.
+Parameters:
+
+	ctx: the context of the requests fetching the results
+	sid: The search ID.
+	opts: the options of the iterator, the defaults are used if nil
+*/
+func (s *Service) NewResultsIterator(ctx context.Context, sid string, opts *IteratorOptions) *Iterator {
+	var o IteratorOptions
+	if opts != nil {
+		o = *opts
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	fn := func(count, start int) (*ListSearchResultsResponse, error) {
+		if !o.Preview {
+			query := ListResultsQueryParams{}.SetCount(int32(count)).SetOffset(int32(start))
+			return s.ListResultsWithContext(ctx, sid, &query)
+		}
+		query := ListPreviewResultsQueryParams{}.SetCount(int32(count)).SetOffset(int32(start))
+		preview, err := s.ListPreviewResultsWithContext(ctx, sid, &query)
+		if err != nil {
+			return nil, err
+		}
+		return &ListSearchResultsResponse{Results: preview.Results, Fields: preview.Fields, Messages: preview.Messages,
+			NextLink: preview.NextLink, Wait: preview.Wait}, nil
+	}
+	iterator := NewIterator(o.BatchSize, o.Offset, o.Max, fn)
+	iterator.prefetch = o.Prefetch
+	iterator.cancel = cancel
+	return iterator
+}
+
 // Value returns value in current iteration or error out if iterator is closed
 func (i *Iterator) Value() (*ListSearchResultsResponse, error) {
 	if i.isClosed == true {
@@ -68,36 +135,101 @@ func (i *Iterator) Value() (*ListSearchResultsResponse, error) {
 }
 
 // Next prepares the next result set for reading with the Value method. It
-// returns true on success, or false if there is no next result row or an error
-// occurred while preparing it.
+// returns true on success, or false if there is no next result row, the max
+// number of results was reached or an error occurred while preparing it.
 //
 // Every call to Value, even the first one, must be preceded by a call to Next.
 func (i *Iterator) Next() bool {
-	if i.start > i.max || i.isClosed == true || i.err != nil {
+	if i.isClosed == true || i.err != nil {
 		return false
 	}
-	results, err := i.fn(i.batch, i.start)
-	if err != nil {
-		i.err = err
-		return false
+	if i.next == nil {
+		i.next, i.stop = iter.Pull2(i.pager().Pages(context.Background()))
 	}
-	// No more results
-	if len(results.Results) == 0 {
+	page, err, ok := i.next()
+	if !ok {
 		return false
 	}
-	i.value = results
-	// Return all results, therefore no longer need to iterate
-	if i.batch == 0 {
+	if err != nil {
+		i.err = err
 		return false
 	}
-	i.start += i.batch
+	i.value = page[0]
 	return true
 }
 
+// pager returns the pager fetching the pages of results of the iterator. Every result of a page is represented
+// by the page itself, so that the pager counts the results while the iterator returns the whole pages.
+func (i *Iterator) pager() *sdkservices.Pager[*ListSearchResultsResponse] {
+	fn, offset, max := i.fn, i.offset, i.max
+	concurrency := 1
+	if i.prefetch {
+		concurrency = 2
+	}
+	return sdkservices.NewOffsetPager(func(_ context.Context, start, size int) ([]*ListSearchResultsResponse, int64, error) {
+		total := int64(-1)
+		if max > 0 {
+			total = int64(max)
+			if remaining := max - start; remaining < size {
+				size = remaining
+			}
+		}
+		results, err := fn(size, offset+start)
+		if err != nil || results == nil {
+			return nil, total, err
+		}
+		page := make([]*ListSearchResultsResponse, len(results.Results))
+		for n := range page {
+			page[n] = results
+		}
+		return page, total, nil
+	}, sdkservices.WithPageSize(i.batch), sdkservices.WithConcurrency(concurrency))
+}
+
+// All returns the results one at a time, to range over them. The iterator is closed once they're all returned
+// or the loop is exited, an error encountered during iteration is returned last with a nil result.
+func (i *Iterator) All() iter.Seq2[map[string]interface{}, error] {
+	return func(yield func(map[string]interface{}, error) bool) {
+		defer i.Close()
+		for i.Next() {
+			for _, result := range i.value.Results {
+				if !yield(result, nil) {
+					return
+				}
+			}
+		}
+		if i.err != nil {
+			yield(nil, i.err)
+		}
+	}
+}
+
+// Pages returns the pages of results one at a time, to range over them. The iterator is closed once they're all
+// returned or the loop is exited, an error encountered during iteration is returned last with a nil page.
+func (i *Iterator) Pages() iter.Seq2[*ListSearchResultsResponse, error] {
+	return func(yield func(*ListSearchResultsResponse, error) bool) {
+		defer i.Close()
+		for i.Next() {
+			if !yield(i.value, nil) {
+				return
+			}
+		}
+		if i.err != nil {
+			yield(nil, i.err)
+		}
+	}
+}
+
 // Close checks the status and closes iterator if it's not already. After Close, no results can be retrieved
 func (i *Iterator) Close() {
 	if i.isClosed != true {
 		i.isClosed = true
+		if i.stop != nil {
+			i.stop()
+		}
+		if i.cancel != nil {
+			i.cancel()
+		}
 	}
 }
 
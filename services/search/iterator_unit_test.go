@@ -17,8 +17,10 @@
 package search
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,7 +35,6 @@ func TestNewIterator(t *testing.T) {
 	assert.Equal(t, iterator.batch, 10)
 	assert.Equal(t, iterator.offset, 0)
 	assert.Equal(t, iterator.max, 100)
-	assert.Equal(t, iterator.start, 0)
 	assert.Equal(t, iterator.isClosed, false)
 
 }
@@ -141,14 +142,45 @@ func TestNextOnZeroBatch(t *testing.T) {
 
 	iterator := NewIterator(batch, 0, max,
 		func(count, offset int) (*ListSearchResultsResponse, error) {
-			if count == 0 {
-				return &ListSearchResultsResponse{Results: results}, nil
-			}
-			return nil, nil
+			assert.Equal(t, max, count, "the default batch is capped by max")
+			return &ListSearchResultsResponse{Results: results[offset : offset+count]}, nil
 		})
-	assert.False(t, iterator.Next())
+	assert.Equal(t, DefaultRowsPageSize, iterator.batch)
+	assert.True(t, iterator.Next())
 	searchResults, _ = iterator.Value()
 	assert.Equal(t, &ListSearchResultsResponse{Results: results}, searchResults)
+	assert.False(t, iterator.Next(), "max results were returned")
+}
+
+func TestNextWithOffsetAndMax(t *testing.T) {
+	var calls [][2]int
+	iterator := NewIterator(2, 3, 3,
+		func(count, offset int) (*ListSearchResultsResponse, error) {
+			calls = append(calls, [2]int{count, offset})
+			results := make([]map[string]interface{}, count)
+			return &ListSearchResultsResponse{Results: results}, nil
+		})
+	for iterator.Next() {
+	}
+	require.NoError(t, iterator.Err())
+	assert.Equal(t, [][2]int{{2, 3}, {1, 5}}, calls, "max is the number of results after the offset")
+
+	// Without max, the iteration stops at the first page holding fewer results than requested
+	calls = nil
+	iterator = NewIterator(4, 0, 0,
+		func(count, offset int) (*ListSearchResultsResponse, error) {
+			calls = append(calls, [2]int{count, offset})
+			if offset >= 8 {
+				return &ListSearchResultsResponse{Results: make([]map[string]interface{}, 3)}, nil
+			}
+			return &ListSearchResultsResponse{Results: make([]map[string]interface{}, count)}, nil
+		})
+	pages := 0
+	for iterator.Next() {
+		pages++
+	}
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, [][2]int{{4, 0}, {4, 4}, {4, 8}}, calls)
 }
 
 func TestNextOnFnErr(t *testing.T) {
@@ -180,3 +212,52 @@ func TestNextNoMoreResults(t *testing.T) {
 	searchResultsActual, _ := iterator.Value()
 	assert.Equal(t, searchResults, searchResultsActual)
 }
+
+func TestNewResultsIterator(t *testing.T) {
+	var results []map[string]interface{}
+	for i := 0; i < 7; i++ {
+		results = append(results, map[string]interface{}{"n": float64(i)})
+	}
+	for _, preview := range []bool{false, true} {
+		rt := &resultsRT{results: results}
+		iterator := newTestSearchService(t, rt).NewResultsIterator(context.Background(), "sid", &IteratorOptions{BatchSize: 3, Offset: 1, Preview: preview, Prefetch: true})
+		var ns []float64
+		for result, err := range iterator.All() {
+			require.NoError(t, err)
+			ns = append(ns, result["n"].(float64))
+		}
+		assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, ns)
+		assert.True(t, iterator.isClosed)
+		rt.mux.Lock()
+		// The page after the last one may have been prefetched too
+		require.GreaterOrEqual(t, len(rt.paths), 3)
+		if preview {
+			assert.True(t, strings.HasSuffix(rt.paths[0], "/jobs/sid/results-preview"))
+		} else {
+			assert.True(t, strings.HasSuffix(rt.paths[0], "/jobs/sid/results"))
+		}
+		// Prefetched pages may be requested in any order
+		assert.Contains(t, strings.Join(rt.queries, " "), "offset=4")
+		rt.mux.Unlock()
+	}
+
+	// The loop can be exited early, the prefetched page being discarded
+	iterator := newTestSearchService(t, &resultsRT{results: results}).NewResultsIterator(context.Background(), "sid", &IteratorOptions{BatchSize: 2, Prefetch: true})
+	pages := 0
+	for page, err := range iterator.Pages() {
+		require.NoError(t, err)
+		assert.Len(t, page.Results, 2)
+		pages++
+		break
+	}
+	assert.Equal(t, 1, pages)
+	assert.False(t, iterator.Next(), "the iterator is closed")
+
+	iterator = NewIterator(2, 0, 0, func(count, start int) (*ListSearchResultsResponse, error) {
+		return nil, errors.New("test error")
+	})
+	for result, err := range iterator.All() {
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "test error")
+	}
+}
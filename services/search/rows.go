@@ -0,0 +1,384 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+// DefaultRowsPageSize is the number of results fetched per request by Rows, the default count of ListResults
+const DefaultRowsPageSize = 1000
+
+// Rows streams the results of a job decoded into T, a struct whose exported fields are mapped to the fields of
+// the results by their spl tag, or by their name if they have none. The fields tagged spl:"-" are skipped.
+// Its cursor starts before the first row, use Next() to advance through the rows:
+//
+//	type Request struct {
+//		Time   time.Time `spl:"_time"`
+//		Host   string    `spl:"host"`
+//		Status int       `spl:"status"`
+//		Tags   []string  `spl:"tag"`
+//	}
+//
+//	rows := search.NewRows[Request](ctx, client.SearchService, sid, 0)
+//	defer rows.Close()
+//	for rows.Next() {
+//		request := rows.Row()
+//		...
+//	}
+//	err := rows.Err() // get any error encountered during iteration
+//
+// or range over All():
+//
+//	for request, err := range search.NewRows[Request](ctx, client.SearchService, sid, 0).All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The job should be done, see WaitForJobCtx, the results of a running job may not be available yet.
+// See DecodeRow for how the fields are decoded.
+type Rows[T any] struct {
+	ctx   context.Context
+	pager *sdkservices.Pager[map[string]interface{}]
+	next  func() (T, error, bool)
+	stop  func()
+	row   T
+	done  bool
+	err   error
+}
+
+/*
+NewRows - Returns the rows of the results of a job, decoded into T.
+Parameters:
+
+	ctx: the context of the requests fetching the results
+	service: the search service
+	sid: The search ID.
+	pageSize: the number of results fetched per request, DefaultRowsPageSize if 0
+*/
+func NewRows[T any](ctx context.Context, service Servicer, sid string, pageSize int32) *Rows[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultRowsPageSize
+	}
+	rows := &Rows[T]{ctx: ctx}
+	t := reflect.TypeOf(rows.row)
+	if t == nil || t.Kind() != reflect.Struct {
+		rows.err = errors.New("search: rows are decoded into a struct")
+		return rows
+	}
+	// Only fetch the fields which are decoded
+	var names []string
+	for _, f := range cachedRowFields(t) {
+		names = append(names, f.name)
+	}
+	query := ListResultsQueryParams{Field: strings.Join(names, ",")}
+	rows.pager = sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]map[string]interface{}, int64, error) {
+		page := query.SetOffset(int32(offset)).SetCount(int32(size))
+		results, err := service.ListResultsWithContext(ctx, sid, &page)
+		if err != nil {
+			return nil, -1, err
+		}
+		return results.Results, -1, nil
+	}, sdkservices.WithPageSize(int(pageSize)))
+	return rows
+}
+
+// All returns an iterator over the rows, fetching the pages of results as they are needed. Iteration stops
+// after the first error, which is yielded along with the zero value of T. Every call of All iterates from
+// the first row, independently of Next.
+func (r *Rows[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if r.pager == nil {
+			yield(zero, r.err)
+			return
+		}
+		index := 0
+		for result, err := range r.pager.All(r.ctx) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			var row T
+			if err := decodeRow(result, reflect.ValueOf(&row).Elem()); err != nil {
+				yield(zero, fmt.Errorf("search: can't decode result %d: %w", index, err))
+				return
+			}
+			if !yield(row, nil) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+// Next advances to the next row, fetching the next page of results when needed. It returns false when there
+// are no more rows or an error occurred, see Err.
+func (r *Rows[T]) Next() bool {
+	if r.done || r.err != nil {
+		return false
+	}
+	if r.next == nil {
+		r.next, r.stop = iter.Pull2(r.All())
+	}
+	row, err, ok := r.next()
+	if !ok || err != nil {
+		r.err = err
+		r.Close()
+		return false
+	}
+	r.row = row
+	return true
+}
+
+// Row returns the current row
+func (r *Rows[T]) Row() T {
+	return r.row
+}
+
+// Err returns the error encountered during iteration
+func (r *Rows[T]) Err() error {
+	return r.err
+}
+
+// Close stops the iteration with Next, it only needs to be called when the rows are abandoned before Next
+// returned false
+func (r *Rows[T]) Close() {
+	if r.stop != nil {
+		r.stop()
+	}
+	r.done = true
+}
+
+/*
+DecodeRow - Decodes a result into v, a pointer to a struct whose exported fields are mapped to the fields of the result
+by their spl tag, or by their name if they have none. The fields tagged spl:"-" are skipped, as are the fields
+missing from the result. The values are decoded as follows:
+  - multivalue fields into slices, a single value being decoded into a slice of one value
+  - numeric and boolean strings into numbers and booleans, numbers and booleans into strings
+  - RFC 3339 timestamps and epoch seconds, like those of _time, into time.Time
+  - any value into interface{}, as is
+
+Parameters:
+
+	row: a result, as returned by ListResults
+	v: a pointer to the struct to decode row into
+*/
+func DecodeRow(row map[string]interface{}, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("search: rows are decoded into a pointer to a struct")
+	}
+	return decodeRow(row, rv.Elem())
+}
+
+// rowField is an exported field of a struct rows are decoded into
+type rowField struct {
+	name  string
+	index int
+}
+
+// rowFieldsCache holds the []rowField of the struct types rows were decoded into
+var rowFieldsCache sync.Map
+
+func cachedRowFields(t reflect.Type) []rowField {
+	if fields, ok := rowFieldsCache.Load(t); ok {
+		return fields.([]rowField)
+	}
+	var fields []rowField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Tag.Get("spl")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, rowField{name: name, index: i})
+	}
+	rowFieldsCache.Store(t, fields)
+	return fields
+}
+
+func decodeRow(row map[string]interface{}, v reflect.Value) error {
+	for _, f := range cachedRowFields(v.Type()) {
+		value, ok := row[f.name]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(value, v.Field(f.index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func decodeValue(value interface{}, v reflect.Value) error {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch {
+	case v.Type() == timeType:
+		t, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case v.Kind() == reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeValue(value, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case v.Kind() == reflect.Interface && v.NumMethod() == 0:
+		v.Set(reflect.ValueOf(value))
+		return nil
+	case v.Kind() == reflect.Slice:
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+		s := reflect.MakeSlice(v.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := decodeValue(value, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	}
+	if _, ok := value.([]interface{}); ok {
+		return fmt.Errorf("a multivalue field can't be decoded into %s", v.Type())
+	}
+	switch v.Kind() {
+	case reflect.String:
+		switch x := value.(type) {
+		case string:
+			v.SetString(x)
+		case float64:
+			v.SetString(strconv.FormatFloat(x, 'f', -1, 64))
+		default:
+			v.SetString(fmt.Sprint(x))
+		}
+	case reflect.Bool:
+		switch x := value.(type) {
+		case bool:
+			v.SetBool(x)
+		case float64:
+			v.SetBool(x != 0)
+		case string:
+			b, err := strconv.ParseBool(x)
+			if err != nil {
+				return err
+			}
+			v.SetBool(b)
+		default:
+			return fmt.Errorf("%T can't be decoded into %s", value, v.Type())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseInt(value)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseInt(value)
+		if err != nil {
+			return err
+		}
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := parseFloat(value)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func parseFloat(value interface{}) (float64, error) {
+	switch x := value.(type) {
+	case float64:
+		return x, nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(x), 64)
+	}
+	return 0, fmt.Errorf("%T isn't a number", value)
+}
+
+// parseInt parses integers, including those formatted as floats like 42.0
+func parseInt(value interface{}) (int64, error) {
+	if s, ok := value.(string); ok {
+		if n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+			return n, nil
+		}
+	}
+	f, err := parseFloat(value)
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("%v isn't an integer", value)
+	}
+	return int64(f), nil
+}
+
+// parseTime parses RFC 3339 timestamps and epoch seconds, with a fractional part or not
+func parseTime(value interface{}) (time.Time, error) {
+	if s, ok := value.(string); ok {
+		if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(s)); err == nil {
+			return t, nil
+		}
+	}
+	f, err := parseFloat(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%v isn't a time", value)
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), nil
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resultsRT serves the results of a job by page
+type resultsRT struct {
+	mux     sync.Mutex
+	results []map[string]interface{}
+	// queries are the query strings of the requests
+	queries []string
+	// paths are the paths of the requests
+	paths []string
+}
+
+func (rt *resultsRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	rt.queries = append(rt.queries, req.URL.RawQuery)
+	rt.paths = append(rt.paths, req.URL.Path)
+	offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+	count, _ := strconv.Atoi(req.URL.Query().Get("count"))
+	page := []map[string]interface{}{}
+	for i := offset; i < len(rt.results) && i < offset+count; i++ {
+		page = append(page, rt.results[i])
+	}
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(rec).Encode(ListSearchResultsResponse{Results: page})
+	return rec.Result(), nil
+}
+
+type request struct {
+	Time     time.Time `spl:"_time"`
+	Host     string    `spl:"host"`
+	Status   int       `spl:"status"`
+	Bytes    *uint32   `spl:"bytes"`
+	Duration float64   `spl:"duration"`
+	Tags     []string  `spl:"tag"`
+	Cached   bool      `spl:"cached"`
+	Raw      interface{}
+	Ignored  string `spl:"-"`
+	ignored  string
+}
+
+func TestRows(t *testing.T) {
+	var results []map[string]interface{}
+	for i := 0; i < 5; i++ {
+		results = append(results, map[string]interface{}{"host": "web-" + strconv.Itoa(i), "status": strconv.Itoa(200 + i)})
+	}
+	rt := &resultsRT{results: results}
+	rows := NewRows[request](context.Background(), newTestSearchService(t, rt), "sid", 2)
+	var hosts []string
+	var statuses []int
+	for rows.Next() {
+		hosts = append(hosts, rows.Row().Host)
+		statuses = append(statuses, rows.Row().Status)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"web-0", "web-1", "web-2", "web-3", "web-4"}, hosts)
+	assert.Equal(t, []int{200, 201, 202, 203, 204}, statuses)
+	require.Len(t, rt.queries, 3, "the last page is shorter than the page size")
+	assert.Contains(t, rt.queries[0], "field=_time%2Chost%2Cstatus%2Cbytes%2Cduration%2Ctag%2Ccached%2CRaw")
+	assert.Contains(t, rt.queries[2], "offset=4")
+
+	rt.results = append(rt.results, map[string]interface{}{"status": "not a number"})
+	rows = NewRows[request](context.Background(), newTestSearchService(t, rt), "sid", 0)
+	for rows.Next() {
+	}
+	require.Error(t, rows.Err())
+	assert.Contains(t, rows.Err().Error(), "result 5")
+	assert.Contains(t, rows.Err().Error(), "field status")
+
+	ints := NewRows[int](context.Background(), newTestSearchService(t, rt), "sid", 0)
+	assert.False(t, ints.Next())
+	assert.Error(t, ints.Err())
+}
+
+func TestRowsAll(t *testing.T) {
+	var results []map[string]interface{}
+	for i := 0; i < 5; i++ {
+		results = append(results, map[string]interface{}{"host": "web-" + strconv.Itoa(i), "status": strconv.Itoa(200 + i)})
+	}
+	rt := &resultsRT{results: results}
+	rows := NewRows[request](context.Background(), newTestSearchService(t, rt), "sid", 2)
+	var hosts []string
+	for row, err := range rows.All() {
+		require.NoError(t, err)
+		hosts = append(hosts, row.Host)
+		if len(hosts) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"web-0", "web-1", "web-2"}, hosts)
+	assert.Len(t, rt.queries, 2, "no page is fetched past the break")
+
+	// Closing the rows stops the iteration with Next
+	rt.queries = nil
+	require.True(t, rows.Next())
+	assert.Equal(t, "web-0", rows.Row().Host, "Next iterates from the first row")
+	rows.Close()
+	assert.False(t, rows.Next())
+	assert.NoError(t, rows.Err())
+	assert.Len(t, rt.queries, 1)
+
+	for _, err := range NewRows[int](context.Background(), newTestSearchService(t, rt), "sid", 0).All() {
+		assert.Error(t, err)
+	}
+}
+
+func TestDecodeRow(t *testing.T) {
+	var row map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"_time": "2024-03-01T10:20:30.123+01:00",
+		"host": "web-1",
+		"status": "404",
+		"bytes": 1024,
+		"duration": "0.25",
+		"tag": ["web", "prod"],
+		"cached": "true",
+		"Raw": {"a": 1},
+		"Ignored": "x",
+		"ignored": "x"
+	}`), &row))
+	var r request
+	require.NoError(t, DecodeRow(row, &r))
+	assert.True(t, time.Date(2024, 3, 1, 9, 20, 30, 123000000, time.UTC).Equal(r.Time))
+	assert.Equal(t, "web-1", r.Host)
+	assert.Equal(t, 404, r.Status)
+	require.NotNil(t, r.Bytes)
+	assert.Equal(t, uint32(1024), *r.Bytes)
+	assert.Equal(t, 0.25, r.Duration)
+	assert.Equal(t, []string{"web", "prod"}, r.Tags)
+	assert.True(t, r.Cached)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, r.Raw)
+	assert.Empty(t, r.Ignored)
+	assert.Empty(t, r.ignored)
+
+	r = request{}
+	require.NoError(t, DecodeRow(map[string]interface{}{"_time": "1709284830.5", "tag": "web", "host": float64(42), "bytes": nil}, &r))
+	assert.Equal(t, time.Unix(1709284830, 500000000).UTC(), r.Time)
+	assert.Equal(t, []string{"web"}, r.Tags, "a single value is decoded into a slice")
+	assert.Equal(t, "42", r.Host)
+	assert.Nil(t, r.Bytes)
+
+	assert.Error(t, DecodeRow(map[string]interface{}{"host": []interface{}{"a", "b"}}, &r))
+	assert.Error(t, DecodeRow(map[string]interface{}{"status": "4.5"}, &r))
+	assert.Error(t, DecodeRow(map[string]interface{}{"bytes": "-1"}, &r))
+	assert.Error(t, DecodeRow(map[string]interface{}{"_time": "yesterday"}, &r))
+	assert.Error(t, DecodeRow(map[string]interface{}{"cached": "maybe"}, &r))
+	assert.Error(t, DecodeRow(map[string]interface{}{}, r))
+}
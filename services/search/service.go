@@ -5,21 +5,185 @@
 
 package search
 
-import "time"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultWaitMinInterval is the default shortest wait between two polls of WaitForJobCtx
+	defaultWaitMinInterval = 250 * time.Millisecond
+	// defaultWaitMaxInterval is the default longest wait between two polls of WaitForJobCtx
+	defaultWaitMaxInterval = 5 * time.Second
+	// waitUpdateTimeout bounds the update of a job whose wait timed out, the context of the wait being done
+	waitUpdateTimeout = 30 * time.Second
+)
+
+// JobProgress is the progress of a job, reported after every poll while waiting for it
+type JobProgress struct {
+	Sid              string
+	Status           SearchStatus
+	PercentComplete  int32
+	ResultsAvailable int32
+}
+
+// WaitOptions configures WaitForJobCtx, the zero value polls the job every 250ms up to every 5s and leaves it
+// running when the wait times out
+type WaitOptions struct {
+	// MinInterval is the shortest wait between two polls, the wait doubles after every poll where the job
+	// didn't progress and halves after every poll where it did
+	MinInterval time.Duration
+	// MaxInterval is the longest wait between two polls
+	MaxInterval time.Duration
+	// Progress is called after every poll, if set
+	Progress func(progress JobProgress)
+	// OnTimeout is the status the job is updated to when ctx is done before the job completes:
+	// UpdateJobStatusFinalized to stop it and keep the results found so far, or UpdateJobStatusCanceled to
+	// discard it. The job keeps running if empty.
+	OnTimeout UpdateJobStatus
+}
+
+// JobFailedError is returned when waiting for a job which failed
+type JobFailedError struct {
+	Sid string
+	// Messages are the ERROR and FATAL messages of the job
+	Messages []Message
+}
+
+func (e *JobFailedError) Error() string {
+	var texts []string
+	for _, m := range e.Messages {
+		if m.Text != nil {
+			texts = append(texts, *m.Text)
+		}
+	}
+	if len(texts) == 0 {
+		return fmt.Sprintf("search: job %s failed", e.Sid)
+	}
+	return fmt.Sprintf("search: job %s failed: %s", e.Sid, strings.Join(texts, "; "))
+}
+
+func newJobFailedError(sid string, job *SearchJob) *JobFailedError {
+	err := &JobFailedError{Sid: sid}
+	for _, m := range job.Messages {
+		if m.Type != nil && (*m.Type == MessageTypeError || *m.Type == MessageTypeFatal) {
+			err.Messages = append(err.Messages, m)
+		}
+	}
+	return err
+}
 
 // WaitForJob polls the job until it's completed or errors out
 func (s *Service) WaitForJob(jobID string, pollInterval time.Duration) (interface{}, error) {
-	for {
-		job, err := s.GetJob(jobID)
+	job, err := s.WaitForJobCtx(context.Background(), jobID, &WaitOptions{MinInterval: pollInterval, MaxInterval: pollInterval})
+	var failed *JobFailedError
+	if err != nil && !errors.As(err, &failed) {
+		return nil, err
+	}
+	return *job.Status, nil
+}
+
+/*
+WaitForJobCtx - Polls the job until it's done, finalized, canceled or failed and returns it. The wait between two polls
+adapts to the progress of the job, see WaitOptions.
+The job is returned along with a *JobFailedError if it failed. If ctx is done first, the job is updated to
+opts.OnTimeout if set and returned along with an error wrapping the error of ctx.
+Parameters:
+
+	ctx: the context of the wait, its deadline bounds how long to wait for
+	sid: The search ID.
+	opts: the options of the wait, the defaults are used if nil
+*/
+func (s *Service) WaitForJobCtx(ctx context.Context, sid string, opts *WaitOptions) (*SearchJob, error) {
+	var o WaitOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MinInterval <= 0 {
+		o.MinInterval = defaultWaitMinInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultWaitMaxInterval
+	}
+	if o.MaxInterval < o.MinInterval {
+		o.MaxInterval = o.MinInterval
+	}
+	interval := o.MinInterval
+	var job *SearchJob
+	var last JobProgress
+	for poll := 0; ; poll++ {
+		current, err := s.GetJobWithContext(ctx, sid)
 		if err != nil {
-			return nil, err
+			if ctx.Err() != nil {
+				return s.abandonJob(ctx, sid, o.OnTimeout, job)
+			}
+			return job, err
+		}
+		job = current
+		progress := jobProgress(sid, job)
+		if o.Progress != nil {
+			o.Progress(progress)
+		}
+		if job.Status != nil {
+			switch *job.Status {
+			case SearchStatusDone, SearchStatusFinalized, SearchStatusCanceled:
+				return job, nil
+			case SearchStatusFailed:
+				return job, newJobFailedError(sid, job)
+			}
+		}
+		// Poll more often while the job progresses and back off while it doesn't
+		if poll > 0 {
+			if progress == last {
+				interval *= 2
+			} else {
+				interval /= 2
+			}
+			if interval > o.MaxInterval {
+				interval = o.MaxInterval
+			} else if interval < o.MinInterval {
+				interval = o.MinInterval
+			}
 		}
-		// wait for terminal state
-		switch *job.Status {
-		case SearchStatusDone, SearchStatusFailed, SearchStatusCanceled:
-			return *job.Status, nil
-		default:
-			time.Sleep(pollInterval)
+		last = progress
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return s.abandonJob(ctx, sid, o.OnTimeout, job)
 		}
 	}
 }
+
+// abandonJob updates the job to status, if set, once ctx is done before the job completed
+func (s *Service) abandonJob(ctx context.Context, sid string, status UpdateJobStatus, job *SearchJob) (*SearchJob, error) {
+	if status == "" {
+		return job, fmt.Errorf("search: job %s did not complete: %w", sid, ctx.Err())
+	}
+	// The update keeps the values of ctx, e.g. for tracing, but not its cancellation
+	updateCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), waitUpdateTimeout)
+	defer cancel()
+	updated, err := s.UpdateJobWithContext(updateCtx, sid, UpdateJob{Status: status})
+	if err != nil {
+		return job, fmt.Errorf("search: job %s did not complete and can't be %s: %w", sid, status, errors.Join(ctx.Err(), err))
+	}
+	return updated, fmt.Errorf("search: job %s did not complete and was %s: %w", sid, status, ctx.Err())
+}
+
+func jobProgress(sid string, job *SearchJob) JobProgress {
+	progress := JobProgress{Sid: sid}
+	if job.Status != nil {
+		progress.Status = *job.Status
+	}
+	if job.PercentComplete != nil {
+		progress.PercentComplete = *job.PercentComplete
+	}
+	if job.ResultsAvailable != nil {
+		progress.ResultsAvailable = *job.ResultsAvailable
+	}
+	return progress
+}
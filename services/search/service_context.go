@@ -0,0 +1,837 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package search
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+CreateDatasetWithContext - search service endpoint
+Creates a dataset.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetPost
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateDatasetWithContext(ctx context.Context, datasetPost DatasetPost, resp ...*http.Response) (*Dataset, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "CreateDataset")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/datasets`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: datasetPost})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dataset
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateFederatedConnectionWithContext - search service endpoint
+Creates a new federated connection with information about how to connect to a remote index.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	federatedConnectionInput
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateFederatedConnectionWithContext(ctx context.Context, federatedConnectionInput FederatedConnectionInput, resp ...*http.Response) (*FederatedConnection, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "CreateFederatedConnection")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: federatedConnectionInput})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb FederatedConnection
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateJobWithContext - search service endpoint
+Creates a search job.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	searchJob
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateJobWithContext(ctx context.Context, searchJob SearchJob, resp ...*http.Response) (*SearchJob, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "CreateJob")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/jobs`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: searchJob})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SearchJob
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteDatasetByIdWithContext - search service endpoint
+Deletes a dataset with a specified dataset ID (datasetid).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetid: The dataset ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteDatasetByIdWithContext(ctx context.Context, datasetid string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "search", "DeleteDatasetById")
+	pp := struct {
+		Datasetid string
+	}{
+		Datasetid: datasetid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/datasets/{{.Datasetid}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteFederatedConnectionWithContext - search service endpoint
+Deletes a federated connection with the specified name (connectionName)
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionName: The name of the federated connection.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteFederatedConnectionWithContext(ctx context.Context, connectionName string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "search", "DeleteFederatedConnection")
+	pp := struct {
+		ConnectionName string
+	}{
+		ConnectionName: connectionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections/{{.ConnectionName}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteJobWithContext - search service endpoint
+Creates a search job that deletes events from an index. The events are deleted from the index in the specified module, based on the search criteria as specified by the predicate.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	deleteSearchJob
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteJobWithContext(ctx context.Context, deleteSearchJob DeleteSearchJob, resp ...*http.Response) (*DeleteSearchJob, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "DeleteJob")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/jobs/delete`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: deleteSearchJob})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb DeleteSearchJob
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ExportResultsWithContext - search service endpoint
+Exports the search results for the job with the specified search ID (SID). Export the results as a CSV file or JSON file.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ExportResultsWithContext(ctx context.Context, sid string, query *ExportResultsQueryParams, resp ...*http.Response) (*map[string]interface{}, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ExportResults")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs/{{.Sid}}/export`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb map[string]interface{}
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetAllFederatedConnectionsWithContext - search service endpoint
+Returns all federated connections.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetAllFederatedConnectionsWithContext(ctx context.Context, resp ...*http.Response) (*ListFederatedConnections, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "GetAllFederatedConnections")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ListFederatedConnections
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetDatasetByIdWithContext - search service endpoint
+Returns a dataset with a specified dataset ID (datasetid).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetid: The dataset ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetDatasetByIdWithContext(ctx context.Context, datasetid string, resp ...*http.Response) (*Dataset, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "GetDatasetById")
+	pp := struct {
+		Datasetid string
+	}{
+		Datasetid: datasetid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/datasets/{{.Datasetid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dataset
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetFederatedConnectionByNameWithContext - search service endpoint
+Returns the federated connection with the specified name (connectionName).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionName: The name of the federated connection.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetFederatedConnectionByNameWithContext(ctx context.Context, connectionName string, resp ...*http.Response) (*FederatedConnection, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "GetFederatedConnectionByName")
+	pp := struct {
+		ConnectionName string
+	}{
+		ConnectionName: connectionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections/{{.ConnectionName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb FederatedConnection
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetJobWithContext - search service endpoint
+Returns the search job with the specified search ID (SID).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetJobWithContext(ctx context.Context, sid string, resp ...*http.Response) (*SearchJob, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "GetJob")
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/jobs/{{.Sid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SearchJob
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListDatasetsWithContext - search service endpoint
+Returns a list of all datasets.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListDatasetsWithContext(ctx context.Context, resp ...*http.Response) (*ListDatasets, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListDatasets")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/datasets`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ListDatasets
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListEventsSummaryWithContext - search service endpoint
+Returns an events summary for search ID (SID) search.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListEventsSummaryWithContext(ctx context.Context, sid string, query *ListEventsSummaryQueryParams, resp ...*http.Response) (*ListSearchResultsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListEventsSummary")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs/{{.Sid}}/timeline-metadata/auto/events-summary`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ListSearchResultsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListFieldsSummaryWithContext - search service endpoint
+Returns a fields stats summary of the events to-date, for search ID (SID) search.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListFieldsSummaryWithContext(ctx context.Context, sid string, query *ListFieldsSummaryQueryParams, resp ...*http.Response) (*FieldsSummary, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListFieldsSummary")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs/{{.Sid}}/timeline-metadata/auto/fields-summary`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb FieldsSummary
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListJobsWithContext - search service endpoint
+Returns the matching list of search jobs.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListJobsWithContext(ctx context.Context, query *ListJobsQueryParams, resp ...*http.Response) ([]SearchJob, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListJobs")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb []SearchJob
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+ListPreviewResultsWithContext - search service endpoint
+Returns the preview search results for the job with the specified search ID (SID). Can be used when a job is running to return interim results.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListPreviewResultsWithContext(ctx context.Context, sid string, query *ListPreviewResultsQueryParams, resp ...*http.Response) (*ListPreviewResultsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListPreviewResults")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs/{{.Sid}}/results-preview`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ListPreviewResultsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListResultsWithContext - search service endpoint
+Returns the search results for the job with the specified search ID (SID).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListResultsWithContext(ctx context.Context, sid string, query *ListResultsQueryParams, resp ...*http.Response) (*ListSearchResultsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListResults")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/search/v2/jobs/{{.Sid}}/results`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ListSearchResultsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListTimeBucketsWithContext - search service endpoint
+Returns the event distribution over time of the untransformed events read to-date, for search ID(SID) search.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListTimeBucketsWithContext(ctx context.Context, sid string, resp ...*http.Response) (*TimeBucketsSummary, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "ListTimeBuckets")
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/jobs/{{.Sid}}/timeline-metadata/auto/time-buckets`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb TimeBucketsSummary
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PutFederatedConnectionByNameWithContext - search service endpoint
+Creates or updates a federated connection with a specified name (connectionName).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionName: The name of the federated connection.
+	federatedConnectionInput
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PutFederatedConnectionByNameWithContext(ctx context.Context, connectionName string, federatedConnectionInput FederatedConnectionInput, resp ...*http.Response) (*FederatedConnection, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "PutFederatedConnectionByName")
+	pp := struct {
+		ConnectionName string
+	}{
+		ConnectionName: connectionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections/{{.ConnectionName}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: federatedConnectionInput})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb FederatedConnection
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+RefreshFederatedConnectionWithContext - search service endpoint
+Refresh a federated connection to fetch new remote indexes and add/delete corresponding federated datasets.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionName: The name of the federated connection.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) RefreshFederatedConnectionWithContext(ctx context.Context, connectionName string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "search", "RefreshFederatedConnection")
+	pp := struct {
+		ConnectionName string
+	}{
+		ConnectionName: connectionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections/{{.ConnectionName}}/refresh`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+TestFederatedConnectionWithContext - search service endpoint
+Test connection with remote EC instance using federated connection parameters.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionName: The name of the federated connection.
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) TestFederatedConnectionWithContext(ctx context.Context, connectionName string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "search", "TestFederatedConnection")
+	pp := struct {
+		ConnectionName string
+	}{
+		ConnectionName: connectionName,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/connections/{{.ConnectionName}}/test`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+UpdateDatasetByIdWithContext - search service endpoint
+Modifies a dataset with a specified dataset ID (datasetid).
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	datasetid: The dataset ID.
+	datasetPatch
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateDatasetByIdWithContext(ctx context.Context, datasetid string, datasetPatch DatasetPatch, resp ...*http.Response) (*Dataset, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "UpdateDatasetById")
+	pp := struct {
+		Datasetid string
+	}{
+		Datasetid: datasetid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/datasets/{{.Datasetid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: datasetPatch})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Dataset
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateJobWithContext - search service endpoint
+Updates the search job with the specified search ID (SID) with an action.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	sid: The search ID.
+	updateJob
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateJobWithContext(ctx context.Context, sid string, updateJob UpdateJob, resp ...*http.Response) (*SearchJob, error) {
+	ctx = sdkservices.WithOperation(ctx, "search", "UpdateJob")
+	pp := struct {
+		Sid string
+	}{
+		Sid: sid,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/search/v2/jobs/{{.Sid}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: updateJob})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb SearchJob
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
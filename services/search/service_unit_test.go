@@ -0,0 +1,165 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobRT replies to the polls of a job with the states in order, the last one being repeated
+type jobRT struct {
+	mux     sync.Mutex
+	states  []SearchJob
+	polls   int
+	updates []UpdateJobStatus
+	// updateCtxs are the contexts of the updates
+	updateCtxs []context.Context
+	// updateErr, if set, fails the updates
+	updateErr error
+}
+
+// traceKey is the key of a context value the updates of a job are expected to keep
+type traceKey struct{}
+
+func (rt *jobRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Type", "application/json")
+	job := rt.states[len(rt.states)-1]
+	switch req.Method {
+	case http.MethodGet:
+		if rt.polls < len(rt.states) {
+			job = rt.states[rt.polls]
+		}
+		rt.polls++
+	case http.MethodPatch:
+		if rt.updateErr != nil {
+			return nil, rt.updateErr
+		}
+		var update UpdateJob
+		json.NewDecoder(req.Body).Decode(&update)
+		rt.updates = append(rt.updates, update.Status)
+		rt.updateCtxs = append(rt.updateCtxs, req.Context())
+		status := SearchStatus(update.Status)
+		job.Status = &status
+	}
+	json.NewEncoder(rec).Encode(job)
+	return rec.Result(), nil
+}
+
+// newTestSearchService returns a service sending its requests to rt
+func newTestSearchService(t *testing.T, rt http.RoundTripper) *Service {
+	client, err := services.NewClient(&services.Config{Token: "EXAMPLE_AUTHENTICATION_TOKEN", RoundTripper: rt})
+	require.NoError(t, err)
+	return NewService(client)
+}
+
+func testJob(status SearchStatus, percent, results int32) SearchJob {
+	return SearchJob{Status: &status, PercentComplete: &percent, ResultsAvailable: &results}
+}
+
+func TestWaitForJobCtx(t *testing.T) {
+	rt := &jobRT{states: []SearchJob{
+		{},
+		testJob(SearchStatusRunning, 10, 0),
+		testJob(SearchStatusRunning, 50, 5),
+		testJob(SearchStatusDone, 100, 12),
+	}}
+	var progress []JobProgress
+	job, err := newTestSearchService(t, rt).WaitForJobCtx(context.Background(), "sid", &WaitOptions{
+		MinInterval: time.Millisecond,
+		Progress:    func(p JobProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, SearchStatusDone, *job.Status)
+	assert.Equal(t, []JobProgress{
+		{Sid: "sid"},
+		{Sid: "sid", Status: SearchStatusRunning, PercentComplete: 10},
+		{Sid: "sid", Status: SearchStatusRunning, PercentComplete: 50, ResultsAvailable: 5},
+		{Sid: "sid", Status: SearchStatusDone, PercentComplete: 100, ResultsAvailable: 12},
+	}, progress, "a job without a status is still polled")
+
+	status, err := newTestSearchService(t, &jobRT{states: rt.states}).WaitForJob("sid", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, SearchStatusDone, status)
+}
+
+func TestWaitForJobCtxFailed(t *testing.T) {
+	failed := testJob(SearchStatusFailed, 100, 0)
+	info, fatal := MessageTypeInfo, MessageTypeFatal
+	parsing, unknown := "parsing the query", "unknown command foo"
+	failed.Messages = []Message{{Type: &info, Text: &parsing}, {Type: &fatal, Text: &unknown}}
+	rt := &jobRT{states: []SearchJob{failed}}
+	job, err := newTestSearchService(t, rt).WaitForJobCtx(context.Background(), "sid", nil)
+	require.Error(t, err)
+	assert.Equal(t, SearchStatusFailed, *job.Status)
+	var failedErr *JobFailedError
+	require.True(t, errors.As(err, &failedErr))
+	assert.Equal(t, "sid", failedErr.Sid)
+	require.Len(t, failedErr.Messages, 1)
+	assert.Equal(t, "search: job sid failed: unknown command foo", err.Error())
+
+	status, err := newTestSearchService(t, rt).WaitForJob("sid", time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, SearchStatusFailed, status)
+}
+
+func TestWaitForJobCtxTimeout(t *testing.T) {
+	running := testJob(SearchStatusRunning, 10, 0)
+	for _, onTimeout := range []UpdateJobStatus{"", UpdateJobStatusFinalized, UpdateJobStatusCanceled} {
+		rt := &jobRT{states: []SearchJob{running}}
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), traceKey{}, "trace"), 20*time.Millisecond)
+		job, err := newTestSearchService(t, rt).WaitForJobCtx(ctx, "sid", &WaitOptions{MinInterval: time.Millisecond, MaxInterval: 4 * time.Millisecond, OnTimeout: onTimeout})
+		cancel()
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Greater(t, rt.polls, 2)
+		if onTimeout == "" {
+			assert.Empty(t, rt.updates)
+			assert.Equal(t, SearchStatusRunning, *job.Status, "the job is left running")
+			continue
+		}
+		assert.Equal(t, []UpdateJobStatus{onTimeout}, rt.updates)
+		assert.Equal(t, SearchStatus(onTimeout), *job.Status)
+		assert.Equal(t, "trace", rt.updateCtxs[0].Value(traceKey{}), "the update keeps the values of the context")
+		_, hasDeadline := rt.updateCtxs[0].Deadline()
+		assert.True(t, hasDeadline, "the update has its own timeout")
+	}
+
+	// The error of the update is returned along with the one of ctx
+	updateErr := errors.New("update failed")
+	rt := &jobRT{states: []SearchJob{running}, updateErr: updateErr}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	job, err := newTestSearchService(t, rt).WaitForJobCtx(ctx, "sid", &WaitOptions{MinInterval: time.Millisecond, OnTimeout: UpdateJobStatusCanceled})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.Is(err, updateErr))
+	assert.Equal(t, SearchStatusRunning, *job.Status)
+}
@@ -0,0 +1,297 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package spl2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The precedences of the expressions, an operand whose precedence is lower than its operator is parenthesized
+const (
+	precAlias = iota
+	precOr
+	precAnd
+	precNot
+	precCompare
+	precAtom
+)
+
+// Expr is an SPL2 expression. The expressions are built with the functions of this package, which quote the
+// identifiers and values, or with Raw for trusted SPL2.
+type Expr struct {
+	text string
+	prec int
+	err  error
+}
+
+// String returns the SPL2 text of the expression
+func (e Expr) String() string {
+	return e.text
+}
+
+// Err returns the error of the expression if it's invalid, e.g. a value of an unsupported type
+func (e Expr) Err() error {
+	return e.err
+}
+
+// Raw is trusted SPL2 text used as is, it must not contain values coming from users
+func Raw(spl string) Expr {
+	return Expr{text: spl, prec: precAlias}
+}
+
+// Field is the field named name
+func Field(name string) Expr {
+	return Expr{text: QuoteIdentifier(name), prec: precAtom}
+}
+
+// Value is a literal: a string, a boolean, a number or nil. A value which is already an Expr is returned as is.
+func Value(v interface{}) Expr {
+	if e, ok := v.(Expr); ok {
+		return e
+	}
+	if v == nil {
+		return Expr{text: "null", prec: precAtom}
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return Expr{text: QuoteString(rv.String()), prec: precAtom}
+	case reflect.Bool:
+		return Expr{text: strconv.FormatBool(rv.Bool()), prec: precAtom}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Expr{text: strconv.FormatInt(rv.Int(), 10), prec: precAtom}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Expr{text: strconv.FormatUint(rv.Uint(), 10), prec: precAtom}
+	case reflect.Float32, reflect.Float64:
+		return floatValue(rv.Float(), rv.Type().Bits())
+	}
+	return Expr{text: "null", prec: precAtom, err: fmt.Errorf("spl2: unsupported value type %T", v)}
+}
+
+func floatValue(f float64, bitSize int) Expr {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Expr{text: "null", prec: precAtom, err: fmt.Errorf("spl2: unsupported value %v", f)}
+	}
+	return Expr{text: strconv.FormatFloat(f, 'f', -1, bitSize), prec: precAtom}
+}
+
+func compare(field, operator string, value interface{}) Expr {
+	v := Value(value)
+	return Expr{text: QuoteIdentifier(field) + operator + operand(v, precCompare+1), prec: precCompare, err: v.err}
+}
+
+// Eq is true when field equals value
+func Eq(field string, value interface{}) Expr {
+	return compare(field, "=", value)
+}
+
+// Ne is true when field differs from value
+func Ne(field string, value interface{}) Expr {
+	return compare(field, "!=", value)
+}
+
+// Gt is true when field is greater than value
+func Gt(field string, value interface{}) Expr {
+	return compare(field, ">", value)
+}
+
+// Ge is true when field is greater than or equal to value
+func Ge(field string, value interface{}) Expr {
+	return compare(field, ">=", value)
+}
+
+// Lt is true when field is less than value
+func Lt(field string, value interface{}) Expr {
+	return compare(field, "<", value)
+}
+
+// Le is true when field is less than or equal to value
+func Le(field string, value interface{}) Expr {
+	return compare(field, "<=", value)
+}
+
+// In is true when field equals one of values
+func In(field string, values ...interface{}) Expr {
+	exprs := make([]Expr, len(values))
+	for i, v := range values {
+		exprs[i] = Value(v)
+	}
+	err := firstErr(exprs)
+	if len(values) == 0 {
+		err = errors.New("spl2: IN needs a value")
+	}
+	return Expr{text: QuoteIdentifier(field) + " IN (" + joinExprs(exprs) + ")", prec: precCompare, err: err}
+}
+
+// Like is true when field matches pattern, where % matches any characters and _ one character
+func Like(field, pattern string) Expr {
+	return Call("like", Field(field), Value(pattern))
+}
+
+func logical(operator string, prec int, exprs []Expr) Expr {
+	if len(exprs) == 0 {
+		return Expr{prec: prec, err: fmt.Errorf("spl2: %s needs an operand", operator)}
+	}
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	operands := make([]string, len(exprs))
+	for i, e := range exprs {
+		operands[i] = operand(e, prec)
+	}
+	return Expr{text: strings.Join(operands, " "+operator+" "), prec: prec, err: firstErr(exprs)}
+}
+
+// And is true when all of exprs are
+func And(exprs ...Expr) Expr {
+	return logical("AND", precAnd, exprs)
+}
+
+// Or is true when any of exprs is
+func Or(exprs ...Expr) Expr {
+	return logical("OR", precOr, exprs)
+}
+
+// Not is true when e isn't
+func Not(e Expr) Expr {
+	return Expr{text: "NOT " + operand(e, precNot), prec: precNot, err: e.err}
+}
+
+// Call calls function with args, e.g. Call("count") or Call("sum", Field("bytes"))
+func Call(function string, args ...Expr) Expr {
+	err := firstErr(args)
+	if !plainIdentifier.MatchString(function) {
+		err = fmt.Errorf("spl2: invalid function name %q", function)
+	}
+	return Expr{text: function + "(" + joinExprs(args) + ")", prec: precAtom, err: err}
+}
+
+// As names the result of e alias, e.g. for the aggregations of Stats
+func As(e Expr, alias string) Expr {
+	return Expr{text: operand(e, precAlias+1) + " AS " + QuoteIdentifier(alias), prec: precAlias, err: e.err}
+}
+
+// operand returns the text of e as the operand of an operator of precedence prec
+func operand(e Expr, prec int) string {
+	if e.prec < prec {
+		return "(" + e.text + ")"
+	}
+	return e.text
+}
+
+func joinExprs(exprs []Expr) string {
+	texts := make([]string, len(exprs))
+	for i, e := range exprs {
+		texts[i] = e.text
+	}
+	return strings.Join(texts, ", ")
+}
+
+func joinIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func firstErr(exprs []Expr) error {
+	for _, e := range exprs {
+		if e.err != nil {
+			return e.err
+		}
+	}
+	return nil
+}
+
+// plainIdentifier matches the identifiers which don't need to be quoted
+var plainIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// keywords are the words which are quoted to be used as identifiers
+var keywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true, "like": true, "as": true, "by": true,
+	"true": true, "false": true, "null": true, "where": true, "from": true, "group": true, "select": true,
+}
+
+// QuoteIdentifier returns name as an SPL2 identifier, the names which aren't plain words are single quoted with
+// their single quotes and backslashes escaped by a backslash
+func QuoteIdentifier(name string) string {
+	if plainIdentifier.MatchString(name) && !keywords[strings.ToLower(name)] {
+		return name
+	}
+	return quote(name, '\'')
+}
+
+// QuoteString returns s as an SPL2 string literal, double quoted with its double quotes and backslashes escaped
+// by a backslash
+func QuoteString(s string) string {
+	return quote(s, '"')
+}
+
+func quote(s string, q byte) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte(q)
+	for i := 0; i < len(s); i++ {
+		if s[i] == q || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte(q)
+	return b.String()
+}
+
+// UnquoteIdentifier returns the name of an identifier returned by QuoteIdentifier
+func UnquoteIdentifier(identifier string) (string, error) {
+	if plainIdentifier.MatchString(identifier) {
+		return identifier, nil
+	}
+	return unquote(identifier, '\'')
+}
+
+// UnquoteString returns the value of a string literal returned by QuoteString
+func UnquoteString(literal string) (string, error) {
+	return unquote(literal, '"')
+}
+
+func unquote(s string, q byte) (string, error) {
+	if len(s) < 2 || s[0] != q || s[len(s)-1] != q {
+		return "", fmt.Errorf("spl2: %s isn't quoted with %c", s, q)
+	}
+	var b strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		c := s[i]
+		if c == '\\' {
+			i++
+			if i == len(s)-1 {
+				return "", fmt.Errorf("spl2: %s ends with an escaping backslash", s)
+			}
+			c = s[i]
+		} else if c == q {
+			return "", fmt.Errorf("spl2: %s has an unescaped quote", s)
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package spl2
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// commands are the commands of the queries Parse accepts
+var commands = map[string]bool{
+	"from": true, "where": true, "search": true, "stats": true, "eval": true, "head": true, "sort": true, "fields": true,
+}
+
+// fromClauses are the clauses which may follow the dataset of a from command
+var fromClauses = map[string]bool{"where": true, "group": true, "select": true}
+
+// commandName matches the name of a command
+var commandName = regexp.MustCompile(`^[A-Za-z]+`)
+
+// dataset matches the dataset of a from command, a plain or quoted identifier optionally prefixed by index:
+var dataset = regexp.MustCompile(`^(?:index:)?(?:[A-Za-z_][A-Za-z0-9_]*|'(?:[^'\\]|\\.)*')`)
+
+/*
+Parse - Returns the query of an SPL2 text, such as the one returned by Query.String, so that
+Parse(q.String()).String() == q.String(). The commands are split on the pipes outside of quotes and parentheses
+and checked: the query must start with a from command reading a dataset, its other commands must be supported
+by Query, its quotes must be closed and its parentheses balanced. The expressions of the commands are kept as is.
+The earliest and latest times, which aren't part of the text, are left unset.
+Parameters:
+
+	spl: the SPL2 text of the query, its leading pipe being optional
+*/
+func Parse(spl string) (Query, error) {
+	texts, err := splitCommands(spl)
+	if err != nil {
+		return Query{}, err
+	}
+	var q Query
+	for i, text := range texts {
+		name := strings.ToLower(commandName.FindString(text))
+		if !commands[name] {
+			return Query{}, fmt.Errorf("spl2: unsupported command %q", text)
+		}
+		if (name == "from") != (i == 0) {
+			return Query{}, fmt.Errorf("spl2: the query must start with a single from command, not %q", text)
+		}
+		args := strings.TrimSpace(text[len(name):])
+		switch name {
+		case "from":
+			err = checkFrom(args)
+		case "head":
+			if n, convErr := strconv.Atoi(args); convErr != nil || n < 0 {
+				err = fmt.Errorf("spl2: head needs a positive count, not %q", args)
+			}
+		default:
+			if args == "" {
+				err = fmt.Errorf("spl2: %s needs arguments", name)
+			}
+		}
+		if err != nil {
+			return Query{}, err
+		}
+		q.commands = append(q.commands, name+" "+args)
+	}
+	return q, nil
+}
+
+// checkFrom checks the arguments of a from command: a dataset and optionally clauses
+func checkFrom(args string) error {
+	name := dataset.FindString(args)
+	if name == "" {
+		return fmt.Errorf("spl2: from needs a dataset, not %q", args)
+	}
+	rest := args[len(name):]
+	if rest == "" {
+		return nil
+	}
+	clause := strings.ToLower(commandName.FindString(strings.TrimSpace(rest)))
+	if strings.TrimSpace(rest) == rest || !fromClauses[clause] {
+		return fmt.Errorf("spl2: unsupported from clause %q", strings.TrimSpace(rest))
+	}
+	return nil
+}
+
+// splitCommands returns the trimmed commands of spl, split on the pipes outside of quotes and parentheses
+func splitCommands(spl string) ([]string, error) {
+	var texts []string
+	depth, start := 0, 0
+	for i := 0; i < len(spl); i++ {
+		switch spl[i] {
+		case '"', '\'':
+			end := closingQuote(spl, i)
+			if end < 0 {
+				return nil, fmt.Errorf("spl2: unclosed quote at %d in %q", i, spl)
+			}
+			i = end
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("spl2: unbalanced parenthesis at %d in %q", i, spl)
+			}
+		case '|':
+			if depth == 0 {
+				texts = append(texts, strings.TrimSpace(spl[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("spl2: unclosed parenthesis in %q", spl)
+	}
+	texts = append(texts, strings.TrimSpace(spl[start:]))
+	// The leading pipe is optional
+	if len(texts) > 1 && texts[0] == "" {
+		texts = texts[1:]
+	}
+	for _, text := range texts {
+		if text == "" {
+			return nil, fmt.Errorf("spl2: empty command in %q", spl)
+		}
+	}
+	return texts, nil
+}
+
+// closingQuote returns the index of the quote closing the one at start, -1 if it isn't closed
+func closingQuote(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case s[start]:
+			return i
+		}
+	}
+	return -1
+}
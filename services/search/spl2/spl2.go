@@ -0,0 +1,271 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package spl2 builds SPL2 queries for search jobs, quoting and escaping the identifiers and values so that the
+// values coming from users can't change the structure of the query:
+//
+//	query := spl2.FromIndex("main").
+//		Where(spl2.And(spl2.Eq("host", host), spl2.Gt("status", 399))).
+//		Stats([]spl2.Expr{spl2.As(spl2.Call("count"), "errors")}, "source").
+//		Sort(spl2.Desc("errors")).
+//		Head(10).
+//		EarliestRelative("-24h@h")
+//	job, err := query.SearchJob()
+//	if err != nil {
+//		...
+//	}
+//	job, err = client.SearchService.CreateJob(job)
+//
+// Queries written as text, e.g. read from a configuration, are checked with Parse.
+// Queries are values, every clause returns a new query so that a query can be shared and extended.
+package spl2
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/search"
+)
+
+// AbsoluteTimeFormat is the ISO-8601 format of the absolute earliest and latest times, in UTC
+const AbsoluteTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// Query is an SPL2 query, built from a dataset with From or FromIndex and extended with clauses. The first
+// error of an invalid clause is kept and returned by Err and SearchJob.
+type Query struct {
+	commands []string
+	earliest *string
+	latest   *string
+	err      error
+}
+
+// From starts a query reading dataset
+func From(dataset string) Query {
+	return Query{commands: []string{"from " + QuoteIdentifier(dataset)}}
+}
+
+// FromIndex starts a query reading the events of index
+func FromIndex(index string) Query {
+	return Query{commands: []string{"from index:" + QuoteIdentifier(index)}}
+}
+
+// then returns a copy of q with command appended, the commands of q being shared with other queries
+func (q Query) then(command string, err error) Query {
+	commands := make([]string, len(q.commands), len(q.commands)+1)
+	copy(commands, q.commands)
+	q.commands = append(commands, command)
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
+
+// clause returns a copy of q with clause appended to its from command, which must be its only command
+func (q Query) clause(name, clause string, err error) Query {
+	if len(q.commands) != 1 {
+		if q.err == nil {
+			q.err = fmt.Errorf("spl2: %s must directly follow From or FromIndex", name)
+		}
+		return q
+	}
+	q.commands = []string{q.commands[0] + " " + clause}
+	if q.err == nil {
+		q.err = err
+	}
+	return q
+}
+
+// GroupBy groups the events read by the from command by the fields names, it must directly follow From or
+// FromIndex and be followed by Select
+func (q Query) GroupBy(names ...string) Query {
+	var err error
+	if len(names) == 0 {
+		err = errors.New("spl2: group by needs a field")
+	}
+	return q.clause("group by", "GROUP BY "+joinIdentifiers(names), err)
+}
+
+// Select picks the fields and aggregations exprs of the events read by the from command, e.g.
+// As(Call("sum", Field("CPU")), "cpu") for the groups of GroupBy. It must directly follow From, FromIndex or GroupBy.
+func (q Query) Select(exprs ...Expr) Query {
+	err := firstErr(exprs)
+	if len(exprs) == 0 {
+		err = errors.New("spl2: select needs a field")
+	}
+	return q.clause("select", "SELECT "+joinExprs(exprs), err)
+}
+
+// Where filters the results with condition
+func (q Query) Where(condition Expr) Query {
+	return q.then("where "+condition.text, condition.err)
+}
+
+// Search filters the results with condition, like Where
+func (q Query) Search(condition Expr) Query {
+	return q.then("search "+condition.text, condition.err)
+}
+
+// Stats aggregates the results with aggregations, e.g. Call("count") or As(Call("sum", Field("bytes")), "total"),
+// grouped by the fields by
+func (q Query) Stats(aggregations []Expr, by ...string) Query {
+	if len(aggregations) == 0 {
+		return q.then("stats", errors.New("spl2: stats needs an aggregation"))
+	}
+	command, err := "stats "+joinExprs(aggregations), firstErr(aggregations)
+	if len(by) > 0 {
+		command += " BY " + joinIdentifiers(by)
+	}
+	return q.then(command, err)
+}
+
+// Eval sets field to the value of expression for every result
+func (q Query) Eval(field string, expression Expr) Query {
+	return q.then("eval "+QuoteIdentifier(field)+"="+expression.text, expression.err)
+}
+
+// Head keeps the first n results
+func (q Query) Head(n int) Query {
+	var err error
+	if n < 0 {
+		err = fmt.Errorf("spl2: head needs a positive count, not %d", n)
+	}
+	return q.then("head "+strconv.Itoa(n), err)
+}
+
+// SortKey is a field results are sorted by, see Asc and Desc
+type SortKey struct {
+	field      string
+	descending bool
+}
+
+// Asc sorts the results by field in ascending order
+func Asc(field string) SortKey {
+	return SortKey{field: field}
+}
+
+// Desc sorts the results by field in descending order
+func Desc(field string) SortKey {
+	return SortKey{field: field, descending: true}
+}
+
+// Sort sorts the results by keys
+func (q Query) Sort(keys ...SortKey) Query {
+	if len(keys) == 0 {
+		return q.then("sort", errors.New("spl2: sort needs a field"))
+	}
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = QuoteIdentifier(key.field)
+		if key.descending {
+			fields[i] = "-" + fields[i]
+		}
+	}
+	return q.then("sort "+strings.Join(fields, ", "), nil)
+}
+
+// Fields keeps the fields of the results named names
+func (q Query) Fields(names ...string) Query {
+	if len(names) == 0 {
+		return q.then("fields", errors.New("spl2: fields needs a field"))
+	}
+	return q.then("fields "+joinIdentifiers(names), nil)
+}
+
+// FieldsExcept removes the fields of the results named names
+func (q Query) FieldsExcept(names ...string) Query {
+	if len(names) == 0 {
+		return q.then("fields -", errors.New("spl2: fields needs a field"))
+	}
+	return q.then("fields - "+joinIdentifiers(names), nil)
+}
+
+// Earliest sets the earliest time of the events to t
+func (q Query) Earliest(t time.Time) Query {
+	earliest := t.UTC().Format(AbsoluteTimeFormat)
+	q.earliest = &earliest
+	return q
+}
+
+// EarliestRelative sets the earliest time of the events to a relative time modifier such as -24h or -7d@d
+func (q Query) EarliestRelative(modifier string) Query {
+	q.earliest = &modifier
+	if q.err == nil {
+		q.err = validateRelativeTime(modifier)
+	}
+	return q
+}
+
+// Latest sets the latest time of the events to t
+func (q Query) Latest(t time.Time) Query {
+	latest := t.UTC().Format(AbsoluteTimeFormat)
+	q.latest = &latest
+	return q
+}
+
+// LatestRelative sets the latest time of the events to a relative time modifier such as now or @d
+func (q Query) LatestRelative(modifier string) Query {
+	q.latest = &modifier
+	if q.err == nil {
+		q.err = validateRelativeTime(modifier)
+	}
+	return q
+}
+
+// String returns the SPL2 text of the query, which Parse parses back into the query
+func (q Query) String() string {
+	return "| " + strings.Join(q.commands, " | ")
+}
+
+// Err returns the error of the first invalid clause of the query, if any
+func (q Query) Err() error {
+	if q.err == nil && len(q.commands) == 0 {
+		return errors.New("spl2: the query has no dataset, start it with From or FromIndex")
+	}
+	return q.err
+}
+
+// QueryParameters returns the earliest and latest times of the query, nil if none is set
+func (q Query) QueryParameters() *search.QueryParameters {
+	if q.earliest == nil && q.latest == nil {
+		return nil
+	}
+	return &search.QueryParameters{Earliest: q.earliest, Latest: q.latest}
+}
+
+// SearchJob returns a search job running the query, or the error of the query if it's invalid
+func (q Query) SearchJob() (search.SearchJob, error) {
+	if err := q.Err(); err != nil {
+		return search.SearchJob{}, err
+	}
+	return search.SearchJob{Query: q.String(), QueryParameters: q.QueryParameters()}, nil
+}
+
+// relativeTimeUnit matches the time units of relative time modifiers, the longest first
+const relativeTimeUnit = `(?:seconds|second|secs|sec|s|minutes|minute|mins|min|m|hours|hour|hrs|hr|h|days|day|d|weeks|week|w[0-7]?|months|month|mon|quarters|quarter|qtrs|qtr|q|years|year|yrs|yr|y)`
+
+// relativeTime matches the relative time modifiers, offsets from now optionally snapped to a unit, e.g. -7d@w1+8h
+var relativeTime = regexp.MustCompile(`^(?:now|(?:[+-]\d*` + relativeTimeUnit + `)*(?:@` + relativeTimeUnit + `(?:[+-]\d*` + relativeTimeUnit + `)*)?)$`)
+
+func validateRelativeTime(modifier string) error {
+	if modifier == "" || !relativeTime.MatchString(modifier) {
+		return fmt.Errorf("spl2: invalid relative time modifier %q", modifier)
+	}
+	return nil
+}
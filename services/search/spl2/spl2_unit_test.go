@@ -0,0 +1,195 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package spl2
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/services/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	host := `web" OR 1=1 | delete`
+	query := FromIndex("main").
+		Where(And(Eq("host", host), Or(Gt("status", 399), In("source", "a", "b")), Not(Like("uri", "/health%")))).
+		Eval("kb", Raw("bytes / 1024")).
+		Stats([]Expr{As(Call("count"), "errors"), As(Call("sum", Field("kb")), "total kb")}, "source", "by").
+		Sort(Desc("errors"), Asc("source")).
+		Head(10).
+		Fields("source", "errors")
+	require.NoError(t, query.Err())
+	assert.Equal(t, `| from index:main`+
+		` | where host="web\" OR 1=1 | delete" AND (status>399 OR source IN ("a", "b")) AND NOT like(uri, "/health%")`+
+		` | eval kb=bytes / 1024`+
+		` | stats count() AS errors, sum(kb) AS 'total kb' BY source, 'by'`+
+		` | sort -errors, source`+
+		` | head 10`+
+		` | fields source, errors`, query.String())
+	assert.Equal(t, query.String(), query.String())
+
+	// Queries are values, extending a query doesn't change it
+	base := From("my-dataset").Where(Eq("level", "ERROR"))
+	a, b := base.Head(1), base.FieldsExcept("_raw")
+	assert.Equal(t, `| from 'my-dataset' | where level="ERROR"`, base.String())
+	assert.Equal(t, `| from 'my-dataset' | where level="ERROR" | head 1`, a.String())
+	assert.Equal(t, `| from 'my-dataset' | where level="ERROR" | fields - _raw`, b.String())
+
+	assert.Equal(t, `| from main | where NOT (a=1 OR b=2.5) AND c=true AND d=null AND e=-3`,
+		From("main").Where(And(Not(Or(Eq("a", 1), Eq("b", 2.5))), Eq("c", true), Eq("d", nil), Eq("e", int8(-3)))).String())
+	type level string
+	assert.Equal(t, `| from main | where level="warn"`, From("main").Where(Eq("level", level("warn"))).String())
+}
+
+func TestQueryErrors(t *testing.T) {
+	for _, query := range []Query{
+		From("main").Where(Eq("a", struct{}{})),
+		From("main").Where(Eq("a", math.NaN())),
+		From("main").Where(And()),
+		From("main").Where(In("a")),
+		From("main").Stats(nil),
+		From("main").Stats([]Expr{Call("count) | delete (")}),
+		From("main").Sort(),
+		From("main").Fields(),
+		From("main").Head(-1),
+		From("main").EarliestRelative("yesterday"),
+		From("main").LatestRelative(""),
+		{},
+	} {
+		assert.Error(t, query.Err(), query.String())
+		_, err := query.SearchJob()
+		assert.Error(t, err)
+	}
+}
+
+func TestQueryTimes(t *testing.T) {
+	job, err := From("main").SearchJob()
+	require.NoError(t, err)
+	assert.Nil(t, job.QueryParameters)
+
+	earliest := time.Date(2021, 1, 25, 14, 15, 30, 500000000, time.FixedZone("CET", 3600))
+	job, err = From("main").Earliest(earliest).LatestRelative("now").SearchJob()
+	require.NoError(t, err)
+	assert.Equal(t, "| from main", job.Query)
+	assert.Equal(t, &search.QueryParameters{Earliest: strPtr("2021-01-25T13:15:30.500Z"), Latest: strPtr("now")}, job.QueryParameters)
+
+	job, err = From("main").Latest(earliest).EarliestRelative("-7d@w1+8h").SearchJob()
+	require.NoError(t, err)
+	assert.Equal(t, "-7d@w1+8h", *job.QueryParameters.Earliest)
+	assert.Equal(t, "2021-01-25T13:15:30.500Z", *job.QueryParameters.Latest)
+
+	for _, modifier := range []string{"now", "-24h", "-15m", "+1d", "@d", "-1mon@mon", "-2y@q", "-30seconds", "-h"} {
+		assert.NoError(t, validateRelativeTime(modifier), modifier)
+	}
+	for _, modifier := range []string{"", "24h", "-1x", "-1h now", "@", "-1h\"", "2021-01-25"} {
+		assert.Error(t, validateRelativeTime(modifier), modifier)
+	}
+}
+
+func TestFromClauses(t *testing.T) {
+	host := `web"1`
+	query := From("metrics").GroupBy("host").Select(As(Call("sum", Field("CPU")), "cpu"), Field("host")).
+		Search(And(Eq("host", host), Gt("cpu", 0)))
+	require.NoError(t, query.Err())
+	assert.Equal(t, `| from metrics GROUP BY host SELECT sum(CPU) AS cpu, host | search host="web\"1" AND cpu>0`, query.String())
+
+	for _, query := range []Query{
+		From("metrics").Head(1).GroupBy("host"),
+		From("metrics").Where(Eq("a", 1)).Select(Field("host")),
+		From("metrics").GroupBy(),
+		From("metrics").Select(),
+		From("metrics").Select(Value(struct{}{})),
+		Query{}.GroupBy("host"),
+	} {
+		assert.Error(t, query.Err(), query.String())
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, query := range []Query{
+		FromIndex("main").
+			Where(And(Eq("host", `web" OR 1=1 | delete`), Or(Gt("status", 399), In("source", "a|b", "b")), Not(Like("uri", "/health%")))).
+			Eval("kb", Raw("bytes / 1024")).
+			Stats([]Expr{As(Call("count"), "errors"), As(Call("sum", Field("kb")), "total | kb")}, "source", "by").
+			Sort(Desc("errors"), Asc("source")).
+			Head(10).
+			FieldsExcept("_raw"),
+		From("my-dataset"),
+		From("metrics").GroupBy("host").Select(As(Call("sum", Field("CPU")), "cpu"), Field("host")).Search(Gt("cpu", 0)),
+	} {
+		parsed, err := Parse(query.String())
+		require.NoError(t, err, query.String())
+		assert.Equal(t, query.String(), parsed.String())
+		assert.NoError(t, parsed.Err())
+	}
+
+	parsed, err := Parse(`from main |WHERE host="a" |  head 5`)
+	require.NoError(t, err)
+	assert.Equal(t, `| from main | where host="a" | head 5`, parsed.String())
+	job, err := parsed.EarliestRelative("-1h").SearchJob()
+	require.NoError(t, err)
+	assert.Equal(t, "-1h", *job.QueryParameters.Earliest)
+
+	for _, spl := range []string{
+		"",
+		"|",
+		"| where a=1",
+		"| from main | from other",
+		"| from main | delete",
+		"| from main || head 1",
+		"| from main | head",
+		"| from main | head -1",
+		"| from main | where",
+		`| from main | where a="b`,
+		`| from main | where a='b | head 1`,
+		"| from main | where (a=1",
+		"| from main | where a=1)",
+		"| from",
+		"| from main delete",
+		"| from 'main'x",
+	} {
+		_, err := Parse(spl)
+		assert.Error(t, err, spl)
+	}
+}
+
+func TestQuoteRoundTrip(t *testing.T) {
+	for _, s := range []string{"", "plain", "in", "with space", `quo"te`, "sin'gle", `back\slash`, `\`, `'"\'`, "new\nline", "ünïcode"} {
+		unquoted, err := UnquoteString(QuoteString(s))
+		require.NoError(t, err)
+		assert.Equal(t, s, unquoted)
+		unquoted, err = UnquoteIdentifier(QuoteIdentifier(s))
+		require.NoError(t, err)
+		assert.Equal(t, s, unquoted)
+	}
+	assert.Equal(t, "host", QuoteIdentifier("host"))
+	assert.Equal(t, "'AND'", QuoteIdentifier("AND"))
+	assert.Equal(t, `'it\'s'`, QuoteIdentifier("it's"))
+	assert.Equal(t, `"a\\\"b"`, QuoteString(`a\"b`))
+
+	for _, s := range []string{``, `"`, `"a`, `"a"b"`, `"a\"`, `'a'`} {
+		_, err := UnquoteString(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
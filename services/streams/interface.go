@@ -18,8 +18,25 @@
 
 package streams
 
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
 // Servicer represents the interface for implementing all endpoints for this service
 type Servicer interface {
+	//interfaces that cannot be auto-generated from codegen
+	// AllPipelines returns an iterator over every pipeline matching query, requesting pages as they are needed
+	AllPipelines(ctx context.Context, query *ListPipelinesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[PipelineResponse, error]
+	// AllConnections returns an iterator over every connection matching query, requesting pages as they are needed
+	AllConnections(ctx context.Context, query *ListConnectionsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[ConnectionResponse, error]
+	// AllTemplates returns an iterator over every template matching query, requesting pages as they are needed
+	AllTemplates(ctx context.Context, query *ListTemplatesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[TemplateResponse, error]
+
 	//interfaces that are auto-generated in interface_generated.go
 	ServicerGenerated
+	//context variants of the auto-generated interfaces in interface_context.go
+	ServicerWithContext
 }
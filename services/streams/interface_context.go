@@ -0,0 +1,414 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package streams
+
+import (
+	"context"
+	"net/http"
+)
+
+// ServicerWithContext represents the interface for the context variants of the endpoints of ServicerGenerated
+type ServicerWithContext interface {
+	/*
+		ActivatePipelineWithContext - Activates an existing pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			activatePipelineRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ActivatePipelineWithContext(ctx context.Context, id string, activatePipelineRequest ActivatePipelineRequest, resp ...*http.Response) (*Response, error)
+	/*
+		CompileWithContext - Compiles SPL2 and returns streams JSON.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			splCompileRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CompileWithContext(ctx context.Context, splCompileRequest SplCompileRequest, resp ...*http.Response) (*Pipeline, error)
+	/*
+		CreateConnectionWithContext - Create a new DSP connection.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionRequest: Request JSON
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateConnectionWithContext(ctx context.Context, connectionRequest ConnectionRequest, query *CreateConnectionQueryParams, resp ...*http.Response) (*ConnectionSaveResponse, error)
+	/*
+		CreatePipelineWithContext - Creates a pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			pipelineRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreatePipelineWithContext(ctx context.Context, pipelineRequest PipelineRequest, resp ...*http.Response) (*PipelineResponse, error)
+	/*
+		CreateTemplateWithContext - Creates a template for a tenant.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			templateRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	CreateTemplateWithContext(ctx context.Context, templateRequest TemplateRequest, resp ...*http.Response) (*TemplateResponse, error)
+	/*
+		DeactivatePipelineWithContext - Deactivates an existing pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			deactivatePipelineRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeactivatePipelineWithContext(ctx context.Context, id string, deactivatePipelineRequest DeactivatePipelineRequest, resp ...*http.Response) (*Response, error)
+	/*
+		DecompileWithContext - Decompiles UPL and returns SPL.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			decompileRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DecompileWithContext(ctx context.Context, decompileRequest DecompileRequest, resp ...*http.Response) (*DecompileResponse, error)
+	/*
+		DeleteConnectionWithContext - Delete all versions of a connection by its id.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionId: Connection ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteConnectionWithContext(ctx context.Context, connectionId string, resp ...*http.Response) error
+	/*
+		DeleteFileWithContext - Delete file.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			fileId: File ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteFileWithContext(ctx context.Context, fileId string, resp ...*http.Response) error
+	/*
+		DeleteLookupFileWithContext - Delete lookup file.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			fileId: File ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteLookupFileWithContext(ctx context.Context, fileId string, resp ...*http.Response) error
+	/*
+		DeletePipelineWithContext - Removes a pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeletePipelineWithContext(ctx context.Context, id string, resp ...*http.Response) error
+	/*
+		DeleteSourceWithContext - Delete a source.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Source ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteSourceWithContext(ctx context.Context, id string, resp ...*http.Response) error
+	/*
+		DeleteTemplateWithContext - Removes a template with a specific ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			templateId: Template ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	DeleteTemplateWithContext(ctx context.Context, templateId string, resp ...*http.Response) error
+	/*
+		GetFileMetadataWithContext - Get file metadata.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			fileId: File ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetFileMetadataWithContext(ctx context.Context, fileId string, resp ...*http.Response) (*UploadFileResponse, error)
+	/*
+		GetFilesMetadataWithContext - Returns files metadata.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetFilesMetadataWithContext(ctx context.Context, resp ...*http.Response) (*FilesMetaDataResponse, error)
+	/*
+		GetInputSchemaWithContext - Returns the input schema for a function in a pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			getInputSchemaRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetInputSchemaWithContext(ctx context.Context, getInputSchemaRequest GetInputSchemaRequest, resp ...*http.Response) (*UplType, error)
+	/*
+		GetLookupFileMetadataWithContext - Get lookup file metadata.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			fileId: File ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetLookupFileMetadataWithContext(ctx context.Context, fileId string, resp ...*http.Response) (*UploadFileResponse, error)
+	/*
+		GetLookupFilesMetadataWithContext - Returns lookup files metadata.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetLookupFilesMetadataWithContext(ctx context.Context, resp ...*http.Response) (*FilesMetaDataResponse, error)
+	/*
+		GetLookupTableWithContext - Returns lookup table results.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionId: Connection ID
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetLookupTableWithContext(ctx context.Context, connectionId string, query *GetLookupTableQueryParams, resp ...*http.Response) (*LookupTableResponse, error)
+	/*
+		GetOutputSchemaWithContext - Returns the output schema for a specified function in a pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			getOutputSchemaRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetOutputSchemaWithContext(ctx context.Context, getOutputSchemaRequest GetOutputSchemaRequest, resp ...*http.Response) (map[string]UplType, error)
+	/*
+		GetPipelineWithContext - Returns an individual pipeline by version.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPipelineWithContext(ctx context.Context, id string, query *GetPipelineQueryParams, resp ...*http.Response) (*PipelineResponse, error)
+	/*
+		GetPipelineLatestMetricsWithContext - Returns the latest metrics for a single pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPipelineLatestMetricsWithContext(ctx context.Context, id string, resp ...*http.Response) (*MetricsResponse, error)
+	/*
+		GetPipelinesStatusWithContext - Returns the status of pipelines from the underlying streaming system.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPipelinesStatusWithContext(ctx context.Context, query *GetPipelinesStatusQueryParams, resp ...*http.Response) (*PaginatedResponseOfPipelineJobStatus, error)
+	/*
+		GetPreviewDataWithContext - Returns the preview data for a session.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			previewSessionId: Preview Session ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPreviewDataWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) (*PreviewData, error)
+	/*
+		GetPreviewSessionWithContext - Returns information from a preview session.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			previewSessionId: Preview Session ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPreviewSessionWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) (*PreviewState, error)
+	/*
+		GetPreviewSessionLatestMetricsWithContext - Returns the latest metrics for a preview session.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			previewSessionId: Preview Session ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetPreviewSessionLatestMetricsWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) (*MetricsResponse, error)
+	/*
+		GetRegistryWithContext - Returns all functions in JSON format.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetRegistryWithContext(ctx context.Context, query *GetRegistryQueryParams, resp ...*http.Response) (*RegistryModel, error)
+	/*
+		GetTemplateWithContext - Returns an individual template by version.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			templateId: Template ID
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	GetTemplateWithContext(ctx context.Context, templateId string, query *GetTemplateQueryParams, resp ...*http.Response) (*TemplateResponse, error)
+	/*
+		ListConnectionsWithContext - Returns a list of connections (latest versions only) by tenant ID.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListConnectionsWithContext(ctx context.Context, query *ListConnectionsQueryParams, resp ...*http.Response) (*PaginatedResponseOfConnectionResponse, error)
+	/*
+		ListConnectorsWithContext - Returns a list of the available connectors.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListConnectorsWithContext(ctx context.Context, resp ...*http.Response) (*PaginatedResponseOfConnectorResponse, error)
+	/*
+		ListPipelinesWithContext - Returns all pipelines.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListPipelinesWithContext(ctx context.Context, query *ListPipelinesQueryParams, resp ...*http.Response) (*PaginatedResponseOfPipelineResponse, error)
+	/*
+		ListTemplatesWithContext - Returns a list of all templates.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ListTemplatesWithContext(ctx context.Context, query *ListTemplatesQueryParams, resp ...*http.Response) (*PaginatedResponseOfTemplateResponse, error)
+	/*
+		PatchPipelineWithContext - Patches an existing pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			pipelinePatchRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PatchPipelineWithContext(ctx context.Context, id string, pipelinePatchRequest PipelinePatchRequest, resp ...*http.Response) (*PipelineResponse, error)
+	/*
+		PutConnectionWithContext - Updates an existing DSP connection.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionId: Connection ID
+			connectionPutRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PutConnectionWithContext(ctx context.Context, connectionId string, connectionPutRequest ConnectionPutRequest, resp ...*http.Response) (*ConnectionSaveResponse, error)
+	/*
+		PutTemplateWithContext - Updates an existing template.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			templateId: Template ID
+			templatePutRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	PutTemplateWithContext(ctx context.Context, templateId string, templatePutRequest TemplatePutRequest, resp ...*http.Response) (*TemplateResponse, error)
+	/*
+		ReactivatePipelineWithContext - Reactivate a pipeline
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			reactivatePipelineRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ReactivatePipelineWithContext(ctx context.Context, id string, reactivatePipelineRequest ReactivatePipelineRequest, resp ...*http.Response) (*PipelineReactivateResponse, error)
+	/*
+		ReactivationStatusWithContext - Get pipeline reactivation status
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			upgradeId: Pipeline Upgrade ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ReactivationStatusWithContext(ctx context.Context, id string, upgradeId string, resp ...*http.Response) (*PipelineReactivationStatus, error)
+	/*
+		StartPreviewWithContext - Creates a preview session for a pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			previewSessionStartRequest: Parameters to start a new Preview session
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	StartPreviewWithContext(ctx context.Context, previewSessionStartRequest PreviewSessionStartRequest, resp ...*http.Response) (*PreviewStartResponse, error)
+	/*
+		StopPreviewWithContext - Stops a preview session.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			previewSessionId: Preview Session ID
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	StopPreviewWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) error
+	/*
+		UpdateConnectionWithContext - Patches an existing DSP connection.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			connectionId: Connection ID
+			connectionPatchRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateConnectionWithContext(ctx context.Context, connectionId string, connectionPatchRequest ConnectionPatchRequest, resp ...*http.Response) (*ConnectionSaveResponse, error)
+	/*
+		UpdatePipelineWithContext - Updates an existing pipeline.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			pipelineRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdatePipelineWithContext(ctx context.Context, id string, pipelineRequest PipelineRequest, resp ...*http.Response) (*PipelineResponse, error)
+	/*
+		UpdateTemplateWithContext - Patches an existing template.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			templateId: Template ID
+			templatePatchRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpdateTemplateWithContext(ctx context.Context, templateId string, templatePatchRequest TemplatePatchRequest, resp ...*http.Response) (*TemplateResponse, error)
+	/*
+		UpgradePipelineWithContext - Upgrades a pipeline async
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			id: Pipeline ID
+			upgradePipelineRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UpgradePipelineWithContext(ctx context.Context, id string, upgradePipelineRequest UpgradePipelineRequest, resp ...*http.Response) (*PipelineReactivateResponseAsync, error)
+	/*
+	   UploadFileWithContext - Upload new file.
+	   Parameters:
+	       ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	       filename
+	       resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UploadFileWithContext(ctx context.Context, filename string, resp ...*http.Response) error
+	/*
+	   UploadLookupFileWithContext - Upload new lookup file.
+	   Parameters:
+	       ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	       filename
+	       resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	UploadLookupFileWithContext(ctx context.Context, filename string, resp ...*http.Response) error
+	/*
+		ValidateConnectionWithContext - Validates the configuration of a DSP connection.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			validateConnectionRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ValidateConnectionWithContext(ctx context.Context, validateConnectionRequest ValidateConnectionRequest, resp ...*http.Response) error
+	/*
+		ValidatePipelineWithContext - Verifies whether the Streams JSON is valid.
+		Parameters:
+			ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+			validateRequest: Request JSON
+			resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+	*/
+	ValidatePipelineWithContext(ctx context.Context, validateRequest ValidateRequest, resp ...*http.Response) (*ValidateResponse, error)
+}
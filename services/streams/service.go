@@ -0,0 +1,92 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package streams
+
+import (
+	"context"
+	"iter"
+
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+// AllPipelines returns an iterator over every pipeline matching query, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.Offset as the offset of the first pipeline, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllPipelines(ctx context.Context, query *ListPipelinesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[PipelineResponse, error] {
+	var q ListPipelinesQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]PipelineResponse, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetPageSize(int32(size))
+		resp, err := s.ListPipelinesWithContext(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Items, remaining(resp.Total, start), nil
+	}, opts...).All(ctx)
+}
+
+// AllConnections returns an iterator over every connection matching query, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.Offset as the offset of the first connection, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllConnections(ctx context.Context, query *ListConnectionsQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[ConnectionResponse, error] {
+	var q ListConnectionsQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]ConnectionResponse, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetPageSize(int32(size))
+		resp, err := s.ListConnectionsWithContext(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Items, remaining(resp.Total, start), nil
+	}, opts...).All(ctx)
+}
+
+// AllTemplates returns an iterator over every template matching query, requesting pages as they are needed.
+// query.PageSize is used as the page size and query.Offset as the offset of the first template, the page size
+// and concurrency can be overridden by opts.
+func (s *Service) AllTemplates(ctx context.Context, query *ListTemplatesQueryParams, opts ...sdkservices.PagerOption) iter.Seq2[TemplateResponse, error] {
+	var q ListTemplatesQueryParams
+	if query != nil {
+		q = *query
+	}
+	start := sdkservices.IntValue(q.Offset)
+	opts = append([]sdkservices.PagerOption{sdkservices.WithPageSize(sdkservices.IntValue(q.PageSize))}, opts...)
+	return sdkservices.NewOffsetPager(func(ctx context.Context, offset, size int) ([]TemplateResponse, int64, error) {
+		page := q.SetOffset(int32(start + offset)).SetPageSize(int32(size))
+		resp, err := s.ListTemplatesWithContext(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return resp.Items, remaining(resp.Total, start), nil
+	}, opts...).All(ctx)
+}
+
+// remaining returns the number of items from start given the total number of items, -1 if total is unknown
+func remaining(total *int64, start int) int64 {
+	if total == nil {
+		return -1
+	}
+	return *total - int64(start)
+}
@@ -0,0 +1,1638 @@
+/*
+ * Copyright © 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+// This file contains the context variants of the endpoints of service_generated.go, which can't be auto-generated
+// from codegen. They set the operation of the requests, see services.WithOperation.
+
+package streams
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft-lab/go-dependencies/util"
+	sdkservices "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+)
+
+/*
+ActivatePipelineWithContext - Activates an existing pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	activatePipelineRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ActivatePipelineWithContext(ctx context.Context, id string, activatePipelineRequest ActivatePipelineRequest, resp ...*http.Response) (*Response, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ActivatePipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}/activate`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: activatePipelineRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Response
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CompileWithContext - Compiles SPL2 and returns streams JSON.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	splCompileRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CompileWithContext(ctx context.Context, splCompileRequest SplCompileRequest, resp ...*http.Response) (*Pipeline, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "Compile")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/compile`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: splCompileRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Pipeline
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateConnectionWithContext - Create a new DSP connection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionRequest: Request JSON
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateConnectionWithContext(ctx context.Context, connectionRequest ConnectionRequest, query *CreateConnectionQueryParams, resp ...*http.Response) (*ConnectionSaveResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "CreateConnection")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/connections`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: connectionRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ConnectionSaveResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreatePipelineWithContext - Creates a pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	pipelineRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreatePipelineWithContext(ctx context.Context, pipelineRequest PipelineRequest, resp ...*http.Response) (*PipelineResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "CreatePipeline")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: pipelineRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+CreateTemplateWithContext - Creates a template for a tenant.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	templateRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) CreateTemplateWithContext(ctx context.Context, templateRequest TemplateRequest, resp ...*http.Response) (*TemplateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "CreateTemplate")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/templates`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: templateRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb TemplateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeactivatePipelineWithContext - Deactivates an existing pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	deactivatePipelineRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeactivatePipelineWithContext(ctx context.Context, id string, deactivatePipelineRequest DeactivatePipelineRequest, resp ...*http.Response) (*Response, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeactivatePipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}/deactivate`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: deactivatePipelineRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb Response
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DecompileWithContext - Decompiles UPL and returns SPL.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	decompileRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DecompileWithContext(ctx context.Context, decompileRequest DecompileRequest, resp ...*http.Response) (*DecompileResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "Decompile")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/decompile`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: decompileRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb DecompileResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+DeleteConnectionWithContext - Delete all versions of a connection by its id.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionId: Connection ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteConnectionWithContext(ctx context.Context, connectionId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeleteConnection")
+	pp := struct {
+		ConnectionId string
+	}{
+		ConnectionId: connectionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/connections/{{.ConnectionId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteFileWithContext - Delete file.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	fileId: File ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteFileWithContext(ctx context.Context, fileId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeleteFile")
+	pp := struct {
+		FileId string
+	}{
+		FileId: fileId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/files/{{.FileId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteLookupFileWithContext - Delete lookup file.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	fileId: File ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteLookupFileWithContext(ctx context.Context, fileId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeleteLookupFile")
+	pp := struct {
+		FileId string
+	}{
+		FileId: fileId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/lookups/files/{{.FileId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeletePipelineWithContext - Removes a pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeletePipelineWithContext(ctx context.Context, id string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeletePipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteSourceWithContext - Delete a source.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Source ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteSourceWithContext(ctx context.Context, id string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeleteSource")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/sources/{{.Id}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+DeleteTemplateWithContext - Removes a template with a specific ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	templateId: Template ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) DeleteTemplateWithContext(ctx context.Context, templateId string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "DeleteTemplate")
+	pp := struct {
+		TemplateId string
+	}{
+		TemplateId: templateId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/templates/{{.TemplateId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+GetFileMetadataWithContext - Get file metadata.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	fileId: File ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetFileMetadataWithContext(ctx context.Context, fileId string, resp ...*http.Response) (*UploadFileResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetFileMetadata")
+	pp := struct {
+		FileId string
+	}{
+		FileId: fileId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/files/{{.FileId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb UploadFileResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetFilesMetadataWithContext - Returns files metadata.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetFilesMetadataWithContext(ctx context.Context, resp ...*http.Response) (*FilesMetaDataResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetFilesMetadata")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/files`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb FilesMetaDataResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetInputSchemaWithContext - Returns the input schema for a function in a pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	getInputSchemaRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetInputSchemaWithContext(ctx context.Context, getInputSchemaRequest GetInputSchemaRequest, resp ...*http.Response) (*UplType, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetInputSchema")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/input-schema`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: getInputSchemaRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb UplType
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetLookupFileMetadataWithContext - Get lookup file metadata.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	fileId: File ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetLookupFileMetadataWithContext(ctx context.Context, fileId string, resp ...*http.Response) (*UploadFileResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetLookupFileMetadata")
+	pp := struct {
+		FileId string
+	}{
+		FileId: fileId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/lookups/files/{{.FileId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb UploadFileResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetLookupFilesMetadataWithContext - Returns lookup files metadata.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetLookupFilesMetadataWithContext(ctx context.Context, resp ...*http.Response) (*FilesMetaDataResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetLookupFilesMetadata")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/lookups/files`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb FilesMetaDataResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetLookupTableWithContext - Returns lookup table results.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionId: Connection ID
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetLookupTableWithContext(ctx context.Context, connectionId string, query *GetLookupTableQueryParams, resp ...*http.Response) (*LookupTableResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetLookupTable")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		ConnectionId string
+	}{
+		ConnectionId: connectionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/lookups/{{.ConnectionId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb LookupTableResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetOutputSchemaWithContext - Returns the output schema for a specified function in a pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	getOutputSchemaRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetOutputSchemaWithContext(ctx context.Context, getOutputSchemaRequest GetOutputSchemaRequest, resp ...*http.Response) (map[string]UplType, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetOutputSchema")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/output-schema`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: getOutputSchemaRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb map[string]UplType
+	err = util.ParseResponse(&rb, response)
+	return rb, err
+}
+
+/*
+GetPipelineWithContext - Returns an individual pipeline by version.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPipelineWithContext(ctx context.Context, id string, query *GetPipelineQueryParams, resp ...*http.Response) (*PipelineResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetPipeline")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPipelineLatestMetricsWithContext - Returns the latest metrics for a single pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPipelineLatestMetricsWithContext(ctx context.Context, id string, resp ...*http.Response) (*MetricsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetPipelineLatestMetrics")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}/metrics/latest`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb MetricsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPipelinesStatusWithContext - Returns the status of pipelines from the underlying streaming system.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPipelinesStatusWithContext(ctx context.Context, query *GetPipelinesStatusQueryParams, resp ...*http.Response) (*PaginatedResponseOfPipelineJobStatus, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetPipelinesStatus")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/pipelines/status`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PaginatedResponseOfPipelineJobStatus
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPreviewDataWithContext - Returns the preview data for a session.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	previewSessionId: Preview Session ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPreviewDataWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) (*PreviewData, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetPreviewData")
+	pp := struct {
+		PreviewSessionId int64
+	}{
+		PreviewSessionId: previewSessionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/preview-data/{{.PreviewSessionId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PreviewData
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPreviewSessionWithContext - Returns information from a preview session.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	previewSessionId: Preview Session ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPreviewSessionWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) (*PreviewState, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetPreviewSession")
+	pp := struct {
+		PreviewSessionId int64
+	}{
+		PreviewSessionId: previewSessionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/preview-session/{{.PreviewSessionId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PreviewState
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetPreviewSessionLatestMetricsWithContext - Returns the latest metrics for a preview session.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	previewSessionId: Preview Session ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetPreviewSessionLatestMetricsWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) (*MetricsResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetPreviewSessionLatestMetrics")
+	pp := struct {
+		PreviewSessionId int64
+	}{
+		PreviewSessionId: previewSessionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/preview-session/{{.PreviewSessionId}}/metrics/latest`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb MetricsResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetRegistryWithContext - Returns all functions in JSON format.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetRegistryWithContext(ctx context.Context, query *GetRegistryQueryParams, resp ...*http.Response) (*RegistryModel, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetRegistry")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/pipelines/registry`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb RegistryModel
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+GetTemplateWithContext - Returns an individual template by version.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	templateId: Template ID
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) GetTemplateWithContext(ctx context.Context, templateId string, query *GetTemplateQueryParams, resp ...*http.Response) (*TemplateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "GetTemplate")
+	values := util.ParseURLParams(query)
+	pp := struct {
+		TemplateId string
+	}{
+		TemplateId: templateId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/templates/{{.TemplateId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb TemplateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListConnectionsWithContext - Returns a list of connections (latest versions only) by tenant ID.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListConnectionsWithContext(ctx context.Context, query *ListConnectionsQueryParams, resp ...*http.Response) (*PaginatedResponseOfConnectionResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ListConnections")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/connections`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PaginatedResponseOfConnectionResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListConnectorsWithContext - Returns a list of the available connectors.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListConnectorsWithContext(ctx context.Context, resp ...*http.Response) (*PaginatedResponseOfConnectorResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ListConnectors")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/connectors`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PaginatedResponseOfConnectorResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListPipelinesWithContext - Returns all pipelines.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListPipelinesWithContext(ctx context.Context, query *ListPipelinesQueryParams, resp ...*http.Response) (*PaginatedResponseOfPipelineResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ListPipelines")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/pipelines`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PaginatedResponseOfPipelineResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ListTemplatesWithContext - Returns a list of all templates.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	query: a struct pointer of valid query parameters for the endpoint, nil to send no query parameters
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ListTemplatesWithContext(ctx context.Context, query *ListTemplatesQueryParams, resp ...*http.Response) (*PaginatedResponseOfTemplateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ListTemplates")
+	values := util.ParseURLParams(query)
+	u, err := s.Client.BuildURLFromPathParams(values, serviceCluster, `/streams/v3beta1/templates`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PaginatedResponseOfTemplateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PatchPipelineWithContext - Patches an existing pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	pipelinePatchRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PatchPipelineWithContext(ctx context.Context, id string, pipelinePatchRequest PipelinePatchRequest, resp ...*http.Response) (*PipelineResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "PatchPipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: pipelinePatchRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PutConnectionWithContext - Updates an existing DSP connection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionId: Connection ID
+	connectionPutRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PutConnectionWithContext(ctx context.Context, connectionId string, connectionPutRequest ConnectionPutRequest, resp ...*http.Response) (*ConnectionSaveResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "PutConnection")
+	pp := struct {
+		ConnectionId string
+	}{
+		ConnectionId: connectionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/connections/{{.ConnectionId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: connectionPutRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ConnectionSaveResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+PutTemplateWithContext - Updates an existing template.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	templateId: Template ID
+	templatePutRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) PutTemplateWithContext(ctx context.Context, templateId string, templatePutRequest TemplatePutRequest, resp ...*http.Response) (*TemplateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "PutTemplate")
+	pp := struct {
+		TemplateId string
+	}{
+		TemplateId: templateId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/templates/{{.TemplateId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: templatePutRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb TemplateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ReactivatePipelineWithContext - Reactivate a pipeline
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	reactivatePipelineRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ReactivatePipelineWithContext(ctx context.Context, id string, reactivatePipelineRequest ReactivatePipelineRequest, resp ...*http.Response) (*PipelineReactivateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ReactivatePipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}/reactivate`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: reactivatePipelineRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineReactivateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+ReactivationStatusWithContext - Get pipeline reactivation status
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	upgradeId: Pipeline Upgrade ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ReactivationStatusWithContext(ctx context.Context, id string, upgradeId string, resp ...*http.Response) (*PipelineReactivationStatus, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ReactivationStatus")
+	pp := struct {
+		Id        string
+		UpgradeId string
+	}{
+		Id:        id,
+		UpgradeId: upgradeId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}/upgrade/{{.UpgradeId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.GetWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineReactivationStatus
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+StartPreviewWithContext - Creates a preview session for a pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	previewSessionStartRequest: Parameters to start a new Preview session
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) StartPreviewWithContext(ctx context.Context, previewSessionStartRequest PreviewSessionStartRequest, resp ...*http.Response) (*PreviewStartResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "StartPreview")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/preview-session`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: previewSessionStartRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PreviewStartResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+StopPreviewWithContext - Stops a preview session.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	previewSessionId: Preview Session ID
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) StopPreviewWithContext(ctx context.Context, previewSessionId int64, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "StopPreview")
+	pp := struct {
+		PreviewSessionId int64
+	}{
+		PreviewSessionId: previewSessionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/preview-session/{{.PreviewSessionId}}`, pp)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.DeleteWithContext(ctx, s.Client, services.RequestParams{URL: u})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+UpdateConnectionWithContext - Patches an existing DSP connection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	connectionId: Connection ID
+	connectionPatchRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateConnectionWithContext(ctx context.Context, connectionId string, connectionPatchRequest ConnectionPatchRequest, resp ...*http.Response) (*ConnectionSaveResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "UpdateConnection")
+	pp := struct {
+		ConnectionId string
+	}{
+		ConnectionId: connectionId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/connections/{{.ConnectionId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: connectionPatchRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ConnectionSaveResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdatePipelineWithContext - Updates an existing pipeline.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	pipelineRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdatePipelineWithContext(ctx context.Context, id string, pipelineRequest PipelineRequest, resp ...*http.Response) (*PipelineResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "UpdatePipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PutWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: pipelineRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpdateTemplateWithContext - Patches an existing template.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	templateId: Template ID
+	templatePatchRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpdateTemplateWithContext(ctx context.Context, templateId string, templatePatchRequest TemplatePatchRequest, resp ...*http.Response) (*TemplateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "UpdateTemplate")
+	pp := struct {
+		TemplateId string
+	}{
+		TemplateId: templateId,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/templates/{{.TemplateId}}`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PatchWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: templatePatchRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb TemplateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UpgradePipelineWithContext - Upgrades a pipeline async
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	id: Pipeline ID
+	upgradePipelineRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UpgradePipelineWithContext(ctx context.Context, id string, upgradePipelineRequest UpgradePipelineRequest, resp ...*http.Response) (*PipelineReactivateResponseAsync, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "UpgradePipeline")
+	pp := struct {
+		Id string
+	}{
+		Id: id,
+	}
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/{{.Id}}/upgrade`, pp)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: upgradePipelineRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb PipelineReactivateResponseAsync
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
+
+/*
+UploadFileWithContext - Upload new file.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	filename
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UploadFileWithContext(ctx context.Context, filename string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "UploadFile")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/files`, nil)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var response *http.Response
+	if len(resp) > 0 && resp[0] != nil {
+		response = resp[0]
+	}
+
+	form := services.FormData{Filename: filepath.Base(filename), Stream: file, Key: "file"}
+
+	multipartResp, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: form, Headers: map[string]string{"Content-Type": "multipart/form-data"}})
+
+	if multipartResp != nil {
+		defer multipartResp.Body.Close()
+
+		// populate input *http.Response if provided
+		if response != nil {
+			*response = *multipartResp
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/*
+UploadLookupFileWithContext - Upload new lookup file.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	filename
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) UploadLookupFileWithContext(ctx context.Context, filename string, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "UploadLookupFile")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/lookups/files`, nil)
+
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var response *http.Response
+	if len(resp) > 0 && resp[0] != nil {
+		response = resp[0]
+	}
+
+	form := services.FormData{Filename: filepath.Base(filename), Stream: file, Key: "file"}
+
+	multipartResp, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: form, Headers: map[string]string{"Content-Type": "multipart/form-data"}})
+
+	if multipartResp != nil {
+		defer multipartResp.Body.Close()
+
+		// populate input *http.Response if provided
+		if response != nil {
+			*response = *multipartResp
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/*
+ValidateConnectionWithContext - Validates the configuration of a DSP connection.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	validateConnectionRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ValidateConnectionWithContext(ctx context.Context, validateConnectionRequest ValidateConnectionRequest, resp ...*http.Response) error {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ValidateConnection")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/connections/validate`, nil)
+	if err != nil {
+		return err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: validateConnectionRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	return err
+}
+
+/*
+ValidatePipelineWithContext - Verifies whether the Streams JSON is valid.
+Parameters:
+
+	ctx: the context of the request, used for cancellation, deadlines and request-scoped values
+	validateRequest: Request JSON
+	resp: an optional pointer to a http.Response to be populated by this method. NOTE: only the first resp pointer will be used if multiple are provided
+*/
+func (s *Service) ValidatePipelineWithContext(ctx context.Context, validateRequest ValidateRequest, resp ...*http.Response) (*ValidateResponse, error) {
+	ctx = sdkservices.WithOperation(ctx, "streams", "ValidatePipeline")
+	u, err := s.Client.BuildURLFromPathParams(nil, serviceCluster, `/streams/v3beta1/pipelines/validate`, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := sdkservices.PostWithContext(ctx, s.Client, services.RequestParams{URL: u, Body: validateRequest})
+	if response != nil {
+		defer response.Body.Close()
+
+		// populate input *http.Response if provided
+		if len(resp) > 0 && resp[0] != nil {
+			*resp[0] = *response
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rb ValidateResponse
+	err = util.ParseResponse(&rb, response)
+	return &rb, err
+}
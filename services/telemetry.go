@@ -0,0 +1,226 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer and meter used by BaseClient
+const InstrumentationName = "github.com/khulnasoft/khulnasoft-cloud-sdk-go/services"
+
+// Names of the metrics recorded by BaseClient when a MeterProvider is configured
+const (
+	// MetricCallDuration is a histogram of the duration of SDK calls in seconds, including retries
+	MetricCallDuration = "khulnasoft.sdk.call.duration"
+	// MetricCallRetries is a histogram of the number of retries made by SDK calls
+	MetricCallRetries = "khulnasoft.sdk.call.retries"
+	// MetricAttempts is a counter of request attempts by response status code, or error type when no response was received
+	MetricAttempts = "khulnasoft.sdk.attempts"
+)
+
+// Attribute keys set on spans and metrics recorded by BaseClient
+const (
+	// AttributeService is the name of the service package of the SDK call e.g. "ingest"
+	AttributeService = attribute.Key("khulnasoft.service")
+	// AttributeOperation is the name of the service method of the SDK call e.g. "PostEvents"
+	AttributeOperation = attribute.Key("khulnasoft.operation")
+	// AttributeTenant is the default tenant of the client making the call
+	AttributeTenant = attribute.Key("khulnasoft.tenant")
+)
+
+const (
+	attrMethod      = attribute.Key("http.request.method")
+	attrStatusCode  = attribute.Key("http.response.status_code")
+	attrErrorType   = attribute.Key("error.type")
+	attrServer      = attribute.Key("server.address")
+	attrURLPath     = attribute.Key("url.path")
+	attrResendCount = attribute.Key("http.request.resend_count")
+	attrRetryWait   = attribute.Key("khulnasoft.retry.wait_ms")
+	attrRetryClass  = attribute.Key("khulnasoft.retry.class")
+)
+
+// telemetry holds the OpenTelemetry instruments of a BaseClient, a nil *telemetry records nothing
+type telemetry struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	retries  metric.Int64Histogram
+	attempts metric.Int64Counter
+}
+
+// newTelemetry creates the instruments from the providers, returning nil if neither is set
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) (*telemetry, error) {
+	if tp == nil && mp == nil {
+		return nil, nil
+	}
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+	t := &telemetry{tracer: tp.Tracer(InstrumentationName, trace.WithInstrumentationVersion(Version))}
+	meter := mp.Meter(InstrumentationName, metric.WithInstrumentationVersion(Version))
+	var err error
+	if t.duration, err = meter.Float64Histogram(MetricCallDuration, metric.WithUnit("s"),
+		metric.WithDescription("Duration of SDK calls, including retries")); err != nil {
+		return nil, err
+	}
+	if t.retries, err = meter.Int64Histogram(MetricCallRetries, metric.WithUnit("{retry}"),
+		metric.WithDescription("Number of retries made by SDK calls")); err != nil {
+		return nil, err
+	}
+	if t.attempts, err = meter.Int64Counter(MetricAttempts, metric.WithUnit("{attempt}"),
+		metric.WithDescription("Request attempts by response status code")); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// callTelemetry records a single logical SDK call
+type callTelemetry struct {
+	t     *telemetry
+	ctx   context.Context
+	span  trace.Span
+	start time.Time
+	attrs []attribute.KeyValue
+}
+
+// operationAttributes returns the service and operation attributes of the call made with ctx
+func operationAttributes(ctx context.Context, method string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attrMethod.String(method)}
+	if op, ok := OperationFromContext(ctx); ok {
+		attrs = append(attrs, AttributeService.String(op.Service), AttributeOperation.String(op.Name))
+	}
+	return attrs
+}
+
+// startCall starts the span of an SDK call, the returned context must be used for every attempt of the call
+func (t *telemetry) startCall(ctx context.Context, tenant string, method string) (context.Context, *callTelemetry) {
+	if t == nil {
+		return ctx, nil
+	}
+	name := "HTTP " + method
+	if op, ok := OperationFromContext(ctx); ok {
+		name = op.String()
+	}
+	attrs := operationAttributes(ctx, method)
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, AttributeTenant.String(tenant))...))
+	return ctx, &callTelemetry{t: t, ctx: ctx, span: span, start: time.Now(), attrs: attrs}
+}
+
+// statusAttribute returns the status code of the response, or the type of err if there is no response
+func statusAttribute(response *http.Response, err error) attribute.KeyValue {
+	if response != nil {
+		return attrStatusCode.Int(response.StatusCode)
+	}
+	if err != nil {
+		return attrErrorType.String(fmt.Sprintf("%T", err))
+	}
+	return attrErrorType.String("unknown")
+}
+
+// end ends the span of the call and records its metrics, request is nil if it could not be created
+func (c *callTelemetry) end(request *Request, response *http.Response, err error) {
+	if c == nil {
+		return
+	}
+	attrs := append(c.attrs, statusAttribute(response, err))
+	c.span.SetAttributes(attrs[len(attrs)-1])
+	if err != nil {
+		c.span.RecordError(err)
+		c.span.SetStatus(codes.Error, err.Error())
+	} else if response != nil && response.StatusCode >= 400 {
+		c.span.SetStatus(codes.Error, response.Status)
+	}
+	c.span.End()
+
+	set := metric.WithAttributes(attrs...)
+	c.t.duration.Record(c.ctx, time.Since(c.start).Seconds(), set)
+	if request != nil && request.NumAttempts > 0 {
+		c.t.retries.Record(c.ctx, int64(request.NumAttempts-1), metric.WithAttributes(c.attrs...))
+	}
+}
+
+// attemptTelemetry records a single attempt of an SDK call
+type attemptTelemetry struct {
+	t      *telemetry
+	parent context.Context
+	span   trace.Span
+	req    *Request
+}
+
+// startAttempt starts a child span of the call for the attempt and binds it to the request's context
+func (t *telemetry) startAttempt(req *Request) *attemptTelemetry {
+	if t == nil {
+		return nil
+	}
+	parent := req.Context()
+	attrs := []attribute.KeyValue{
+		attrMethod.String(req.Method),
+		attrServer.String(req.URL.Host),
+		attrURLPath.String(req.URL.Path),
+	}
+	if req.NumAttempts > 1 {
+		attrs = append(attrs, attrResendCount.Int(int(req.NumAttempts-1)))
+	}
+	ctx, span := t.tracer.Start(parent, "HTTP "+req.Method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	req.Request = req.Request.WithContext(ctx)
+	return &attemptTelemetry{t: t, parent: parent, span: span, req: req}
+}
+
+// end ends the span of the attempt, counts it and restores the request's context to the call's context
+func (a *attemptTelemetry) end(response *http.Response, err error) {
+	if a == nil {
+		return
+	}
+	status := statusAttribute(response, err)
+	a.span.SetAttributes(status)
+	if err != nil {
+		a.span.RecordError(err)
+		a.span.SetStatus(codes.Error, err.Error())
+	} else if response.StatusCode >= 400 {
+		a.span.SetStatus(codes.Error, response.Status)
+	}
+	a.span.End()
+	a.t.attempts.Add(a.parent, 1, metric.WithAttributes(append(operationAttributes(a.parent, a.req.Method), status)...))
+	a.req.Request = a.req.Request.WithContext(a.parent)
+}
+
+// addRetryEvent records on the span of the call that the request is about to be retried after wait
+func addRetryEvent(request *Request, wait time.Duration, reqErr error, response *http.Response) {
+	span := trace.SpanFromContext(request.Context())
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent("retry", trace.WithAttributes(
+		attrRetryWait.Int64(wait.Milliseconds()),
+		attrRetryClass.String(ClassifyRetry(reqErr, response).String()),
+		statusAttribute(response, reqErr),
+	))
+}
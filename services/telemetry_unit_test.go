@@ -0,0 +1,162 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newInstrumentedClient(t *testing.T, rt http.RoundTripper) (*BaseClient, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	retryConfig := ConfigurableRetryConfig{RetryNum: 3, Interval: 10, clock: &fakeClock{now: time.Unix(1000, 0)}}
+	client, err := NewClient(&Config{
+		Token:          "testtoken",
+		Tenant:         "mytenant",
+		RoundTripper:   rt,
+		RetryRequests:  true,
+		RetryConfig:    RetryStrategyConfig{ConfigurableRetryConfig: &retryConfig},
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	require.NoError(t, err)
+	return client, exporter, reader
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func collectMetrics(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		assert.Equal(t, InstrumentationName, sm.Scope.Name)
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestTelemetrySpansAndMetrics(t *testing.T) {
+	rt := &retryAfterRT{responses: []*http.Response{throttled(429, ""), throttled(429, "")}}
+	client, exporter, reader := newInstrumentedClient(t, rt)
+
+	ctx := WithOperation(context.Background(), "ingest", "PostEvents")
+	u := url.URL{Scheme: "https", Host: "api.scp.splunk.com", Path: "/mytenant/ingest/v1beta2/events"}
+	resp, err := client.PostWithContext(ctx, services.RequestParams{URL: u, Body: []byte("[]")})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+	call := spans[len(spans)-1]
+	assert.Equal(t, "ingest.PostEvents", call.Name)
+	assert.Equal(t, "ingest", spanAttr(call, AttributeService).AsString())
+	assert.Equal(t, "PostEvents", spanAttr(call, AttributeOperation).AsString())
+	assert.Equal(t, "mytenant", spanAttr(call, AttributeTenant).AsString())
+	assert.Equal(t, int64(200), spanAttr(call, attrStatusCode).AsInt64())
+	require.Len(t, call.Events, 2)
+	assert.Equal(t, "retry", call.Events[0].Name)
+
+	// Each attempt is a child span of the call
+	for i, attempt := range spans[:3] {
+		assert.Equal(t, "HTTP POST", attempt.Name)
+		assert.Equal(t, call.SpanContext.SpanID(), attempt.Parent.SpanID())
+		assert.Equal(t, "api.scp.splunk.com", spanAttr(attempt, attrServer).AsString())
+		if i < 2 {
+			assert.Equal(t, int64(429), spanAttr(attempt, attrStatusCode).AsInt64())
+			assert.Equal(t, codes.Error, attempt.Status.Code)
+		}
+		if i > 0 {
+			assert.Equal(t, int64(i), spanAttr(attempt, attrResendCount).AsInt64())
+		}
+	}
+
+	metrics := collectMetrics(t, reader)
+	duration := metrics[MetricCallDuration].(metricdata.Histogram[float64])
+	require.Len(t, duration.DataPoints, 1)
+	assert.Equal(t, uint64(1), duration.DataPoints[0].Count)
+	op, _ := duration.DataPoints[0].Attributes.Value(AttributeOperation)
+	assert.Equal(t, "PostEvents", op.AsString())
+
+	retries := metrics[MetricCallRetries].(metricdata.Histogram[int64])
+	require.Len(t, retries.DataPoints, 1)
+	assert.Equal(t, int64(2), retries.DataPoints[0].Sum)
+
+	attempts := metrics[MetricAttempts].(metricdata.Sum[int64])
+	byStatus := map[int64]int64{}
+	for _, dp := range attempts.DataPoints {
+		status, _ := dp.Attributes.Value(attrStatusCode)
+		byStatus[status.AsInt64()] = dp.Value
+	}
+	assert.Equal(t, map[int64]int64{429: 2, 200: 1}, byStatus)
+}
+
+// errRT fails every request
+type errRT struct{}
+
+func (errRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errors.New("boom")
+}
+
+func TestTelemetryRequestError(t *testing.T) {
+	client, exporter, reader := newInstrumentedClient(t, errRT{})
+
+	_, err := client.GetWithContext(context.Background(), services.RequestParams{URL: url.URL{Scheme: "https", Host: "api.scp.splunk.com", Path: "/mytenant/widgets"}})
+	require.Error(t, err)
+
+	spans := exporter.GetSpans()
+	require.NotEmpty(t, spans)
+	call := spans[len(spans)-1]
+	assert.Equal(t, "HTTP GET", call.Name)
+	assert.Equal(t, codes.Error, call.Status.Code)
+	assert.NotEmpty(t, spanAttr(call, attrErrorType).AsString())
+
+	metrics := collectMetrics(t, reader)
+	assert.Contains(t, metrics, MetricCallDuration)
+}
+
+func TestTelemetryDisabled(t *testing.T) {
+	client, err := NewClient(&Config{Token: "testtoken", RoundTripper: &retryAfterRT{}})
+	require.NoError(t, err)
+	assert.Nil(t, client.telemetry)
+	_, err = client.Get(services.RequestParams{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/idp"
+)
+
+// Reasons passed to the refresh callback of a TokenSource
+const (
+	tokenRefreshInitial      = "initial"
+	tokenRefreshExpiring     = "expiring"
+	tokenRefreshAhead        = "refresh_ahead"
+	tokenRefreshUnauthorized = "unauthorized"
+)
+
+// TokenSource provides access tokens from a TokenRetriever to any number of concurrent callers. At most one
+// retrieval is in flight at any time and every caller needing a new token waits for that retrieval.
+//
+// A token is considered expired once less than the expire window remains before it expires, callers then
+// block until a new token is retrieved. Once less than twice the expire window remains, callers are handed
+// the current token and a new one is retrieved in the background, such that under steady traffic callers
+// never wait for a token. A timer also starts that retrieval when the token enters this window if it was handed
+// to a caller, so that a token in use is renewed even when no caller arrives in time, while a source no longer
+// used stops retrieving tokens. Close stops the timer. Tokens with no expiry information (ExpiresIn and StartTime
+// both zero) are always considered expired.
+type TokenSource struct {
+	retriever    idp.TokenRetriever
+	expireWindow time.Duration
+	// static sources never expire their token, they are created for clients configured with a Token
+	static bool
+
+	mux     sync.Mutex
+	current *idp.Context
+	fetch   *tokenFetch
+	// used is set once the current token was handed to a caller
+	used bool
+	// timer retrieves a new token ahead of the expiry of the current one
+	timer  *time.Timer
+	closed bool
+
+	// now returns the current time, time.Now is used if nil
+	now func() time.Time
+	// onRefresh is (optionally) called after every retrieval with the reason for it
+	onRefresh func(ctx context.Context, reason string, err error)
+}
+
+// tokenFetch is a retrieval in flight, done is closed once tokenCtx and err are set
+type tokenFetch struct {
+	done     chan struct{}
+	tokenCtx *idp.Context
+	err      error
+}
+
+// NewTokenSource creates a TokenSource retrieving tokens from retriever, expireWindow is the window within which
+// a token is considered expired, 1 minute if zero. No token is retrieved until the first call to Token.
+func NewTokenSource(retriever idp.TokenRetriever, expireWindow time.Duration) *TokenSource {
+	if expireWindow == 0 {
+		expireWindow = time.Minute
+	}
+	return &TokenSource{retriever: retriever, expireWindow: expireWindow}
+}
+
+// newStaticTokenSource creates a TokenSource always returning token
+func newStaticTokenSource(token string) *TokenSource {
+	tokenCtx := &idp.Context{AccessToken: token}
+	return &TokenSource{retriever: &idp.NoOpTokenRetriever{Context: tokenCtx}, current: tokenCtx, static: true}
+}
+
+func (s *TokenSource) getNow() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// Current returns the current token without retrieving a new one, nil if no token was retrieved yet
+func (s *TokenSource) Current() *idp.Context {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.current
+}
+
+// Set replaces the current token
+func (s *TokenSource) Set(tokenCtx *idp.Context) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.current, s.used = tokenCtx, false
+	s.schedule()
+}
+
+// Close stops retrieving tokens ahead of their expiry, Token keeps retrieving them when needed
+func (s *TokenSource) Close() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.closed = true
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+}
+
+// Token returns a token which isn't about to expire, retrieving a new one if needed. It returns ctx.Err()
+// if ctx is done before the retrieval completes, the retrieval itself carries on for other callers.
+func (s *TokenSource) Token(ctx context.Context) (*idp.Context, error) {
+	s.mux.Lock()
+	if s.current != nil {
+		if s.static {
+			defer s.mux.Unlock()
+			return s.current, nil
+		}
+		expiresAt := tokenExpiry(s.current)
+		now := s.getNow()
+		if now.Add(s.expireWindow).Before(expiresAt) {
+			if !now.Add(2 * s.expireWindow).Before(expiresAt) {
+				s.startFetch(tokenRefreshAhead, s.retriever)
+			}
+			s.used = true
+			defer s.mux.Unlock()
+			return s.current, nil
+		}
+	}
+	reason := tokenRefreshExpiring
+	if s.current == nil {
+		reason = tokenRefreshInitial
+	}
+	f := s.startFetch(reason, s.retriever)
+	s.mux.Unlock()
+	return f.wait(ctx)
+}
+
+// Renew retrieves a new token to replace rejected, the access token the server responded 401 Unauthorized to.
+// If the current token is no longer rejected, because another caller renewed it already, it is returned as is.
+func (s *TokenSource) Renew(ctx context.Context, rejected string) (*idp.Context, error) {
+	return s.renewWith(ctx, rejected, s.retriever)
+}
+
+// renewWith implements Renew using retriever to retrieve the new token
+func (s *TokenSource) renewWith(ctx context.Context, rejected string, retriever idp.TokenRetriever) (*idp.Context, error) {
+	s.mux.Lock()
+	if s.fetch == nil && s.current != nil && s.current.AccessToken != rejected {
+		defer s.mux.Unlock()
+		return s.current, nil
+	}
+	f := s.startFetch(tokenRefreshUnauthorized, retriever)
+	s.mux.Unlock()
+	return f.wait(ctx)
+}
+
+// startFetch returns the retrieval in flight or starts a new one, it must be called with s.mux held
+func (s *TokenSource) startFetch(reason string, retriever idp.TokenRetriever) *tokenFetch {
+	if s.fetch != nil {
+		return s.fetch
+	}
+	f := &tokenFetch{done: make(chan struct{})}
+	s.fetch = f
+	go func() {
+		tokenCtx, err := retriever.GetTokenContext()
+		if err == nil && tokenCtx == nil {
+			err = errors.New("services: token retriever returned no token")
+		}
+		s.mux.Lock()
+		if err == nil {
+			// The callers waiting for the token use it, unlike the ones handed the previous token
+			s.current, s.used = tokenCtx, reason != tokenRefreshAhead
+			s.schedule()
+		}
+		s.fetch = nil
+		f.tokenCtx, f.err = tokenCtx, err
+		s.mux.Unlock()
+		close(f.done)
+		if s.onRefresh != nil {
+			s.onRefresh(context.Background(), reason, err)
+		}
+	}()
+	return f
+}
+
+// schedule arms the timer retrieving a new token once the current one is less than twice the expire window from
+// its expiry, it must be called with s.mux held
+func (s *TokenSource) schedule() {
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	tokenCtx := s.current
+	if s.closed || s.static || tokenCtx == nil || (tokenCtx.StartTime == 0 && tokenCtx.ExpiresIn == 0) {
+		return
+	}
+	delay := tokenExpiry(tokenCtx).Add(-2 * s.expireWindow).Sub(s.getNow())
+	if delay <= 0 {
+		// The token is already within the window, callers retrieve the next one
+		return
+	}
+	s.timer = time.AfterFunc(delay, func() {
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		if !s.closed && s.current == tokenCtx && s.used {
+			s.startFetch(tokenRefreshAhead, s.retriever)
+		}
+	})
+}
+
+// tokenExpiry returns the time tokenCtx expires at
+func tokenExpiry(tokenCtx *idp.Context) time.Time {
+	return time.Unix(tokenCtx.StartTime+int64(tokenCtx.ExpiresIn), 0)
+}
+
+// wait waits for the retrieval to complete or ctx to be done
+func (f *tokenFetch) wait(ctx context.Context) (*idp.Context, error) {
+	select {
+	case <-f.done:
+		return f.tokenCtx, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
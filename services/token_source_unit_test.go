@@ -0,0 +1,255 @@
+/*
+ * Copyright 2024 KhulnaSoft, Ltd.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"): you may
+ * not use this file except in compliance with the License. You may obtain
+ * a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ */
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/khulnasoft-lab/go-dependencies/services"
+	"github.com/khulnasoft/khulnasoft-cloud-sdk-go/idp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numConcurrentCallers = 100
+
+// countingRetriever returns a new token valid for an hour from now() on every call, after delay
+type countingRetriever struct {
+	n     int32
+	delay time.Duration
+	now   func() time.Time
+	err   error
+}
+
+func (tr *countingRetriever) GetTokenContext() (*idp.Context, error) {
+	n := atomic.AddInt32(&tr.n, 1)
+	time.Sleep(tr.delay)
+	if tr.err != nil {
+		return nil, tr.err
+	}
+	return &idp.Context{AccessToken: fmt.Sprintf("token-%d", n), StartTime: tr.now().Unix(), ExpiresIn: 3600}, nil
+}
+
+func (tr *countingRetriever) count() int {
+	return int(atomic.LoadInt32(&tr.n))
+}
+
+// testNow is a clock which can be moved forward concurrently with its use
+type testNow struct {
+	mux sync.Mutex
+	t   time.Time
+}
+
+func (n *testNow) now() time.Time {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	return n.t
+}
+
+func (n *testNow) advance(d time.Duration) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	n.t = n.t.Add(d)
+}
+
+func newTestTokenSource(delay time.Duration) (*TokenSource, *countingRetriever, *testNow) {
+	clk := &testNow{t: time.Unix(1000000, 0)}
+	tr := &countingRetriever{delay: delay, now: clk.now}
+	source := NewTokenSource(tr, time.Minute)
+	source.now = clk.now
+	return source, tr, clk
+}
+
+// concurrently calls fn from numConcurrentCallers goroutines and returns the tokens they got
+func concurrently(t *testing.T, fn func() (*idp.Context, error)) []string {
+	tokens := make([]string, numConcurrentCallers)
+	var wg sync.WaitGroup
+	for i := 0; i < numConcurrentCallers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokenCtx, err := fn()
+			if assert.NoError(t, err) {
+				tokens[i] = tokenCtx.AccessToken
+			}
+		}(i)
+	}
+	wg.Wait()
+	return tokens
+}
+
+func TestTokenSourceSingleFlight(t *testing.T) {
+	source, tr, _ := newTestTokenSource(20 * time.Millisecond)
+	tokens := concurrently(t, func() (*idp.Context, error) { return source.Token(context.Background()) })
+	assert.Equal(t, 1, tr.count())
+	for _, token := range tokens {
+		assert.Equal(t, "token-1", token)
+	}
+
+	// The token is cached until it is about to expire
+	tokenCtx, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", tokenCtx.AccessToken)
+	assert.Equal(t, 1, tr.count())
+}
+
+func TestTokenSourceExpiring(t *testing.T) {
+	source, tr, clk := newTestTokenSource(0)
+	_, err := source.Token(context.Background())
+	require.NoError(t, err)
+
+	// Within the expire window, every caller waits for the same new token
+	clk.advance(time.Hour - 30*time.Second)
+	tokens := concurrently(t, func() (*idp.Context, error) { return source.Token(context.Background()) })
+	assert.Equal(t, 2, tr.count())
+	for _, token := range tokens {
+		assert.Equal(t, "token-2", token)
+	}
+}
+
+func TestTokenSourceRefreshAhead(t *testing.T) {
+	source, tr, clk := newTestTokenSource(20 * time.Millisecond)
+	_, err := source.Token(context.Background())
+	require.NoError(t, err)
+
+	// Between one and two expire windows before expiry, callers get the current token right away while a
+	// single new token is retrieved in the background
+	clk.advance(time.Hour - 90*time.Second)
+	tokens := concurrently(t, func() (*idp.Context, error) { return source.Token(context.Background()) })
+	for _, token := range tokens {
+		assert.Equal(t, "token-1", token)
+	}
+	assert.Eventually(t, func() bool {
+		current := source.Current()
+		return current != nil && current.AccessToken == "token-2"
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 2, tr.count())
+}
+
+func TestTokenSourceRefreshAheadTimer(t *testing.T) {
+	// The token, valid for an hour, enters the refresh-ahead window 50ms after it's retrieved
+	clk := &testNow{t: time.Unix(1000000, 0)}
+	tr := &countingRetriever{now: clk.now}
+	source := NewTokenSource(tr, 30*time.Minute-25*time.Millisecond)
+	source.now = clk.now
+	defer source.Close()
+	_, err := source.Token(context.Background())
+	require.NoError(t, err)
+
+	// Without any caller, the token in use is renewed once, the new token isn't since nobody used it
+	assert.Eventually(t, func() bool { return source.Current().AccessToken == "token-2" }, time.Second, 5*time.Millisecond)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 2, tr.count())
+
+	// Once closed, the source doesn't renew the token in use ahead of its expiry
+	source.Set(&idp.Context{AccessToken: "token-set", StartTime: clk.now().Unix(), ExpiresIn: 3600})
+	_, err = source.Token(context.Background())
+	require.NoError(t, err)
+	source.Close()
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 2, tr.count())
+	assert.Equal(t, "token-set", source.Current().AccessToken)
+}
+
+func TestTokenSourceRenew(t *testing.T) {
+	source, tr, _ := newTestTokenSource(20 * time.Millisecond)
+	_, err := source.Token(context.Background())
+	require.NoError(t, err)
+
+	// Many requests rejected with the same token result in a single retrieval
+	tokens := concurrently(t, func() (*idp.Context, error) { return source.Renew(context.Background(), "token-1") })
+	assert.Equal(t, 2, tr.count())
+	for _, token := range tokens {
+		assert.Equal(t, "token-2", token)
+	}
+
+	// A late rejection of an already renewed token doesn't retrieve a new one
+	tokenCtx, err := source.Renew(context.Background(), "token-1")
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", tokenCtx.AccessToken)
+	assert.Equal(t, 2, tr.count())
+}
+
+func TestTokenSourceErrorsAndContext(t *testing.T) {
+	source, tr, _ := newTestTokenSource(50 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := source.Token(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// The retrieval carried on and is reused by the next caller
+	tokenCtx, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", tokenCtx.AccessToken)
+	assert.Equal(t, 1, tr.count())
+
+	failing := NewTokenSource(&countingRetriever{err: errors.New("idp unavailable")}, 0)
+	_, err = failing.Token(context.Background())
+	assert.EqualError(t, err, "idp unavailable")
+	assert.Nil(t, failing.Current())
+}
+
+// authRT rejects every request which isn't authorized with the latest token of the retriever
+type authRT struct {
+	tr *countingRetriever
+}
+
+func (rt *authRT) RoundTrip(req *http.Request) (*http.Response, error) {
+	b := ioutil.NopCloser(bytes.NewReader([]byte("")))
+	if req.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", rt.tr.count()) {
+		return &http.Response{Status: "401 Unauthorized", StatusCode: 401, Body: b}, nil
+	}
+	return &http.Response{Status: "200 OK", StatusCode: 200, Body: b}, nil
+}
+
+func TestClientConcurrentTokenRenewal(t *testing.T) {
+	clk := &testNow{t: time.Now()}
+	tr := &countingRetriever{delay: 10 * time.Millisecond, now: clk.now}
+	client, err := NewClient(&Config{
+		TokenRetriever:   tr,
+		RoundTripper:     &authRT{tr: tr},
+		RetryRequests:    true,
+		ResponseHandlers: []ResponseHandler{AuthnResponseHandler{TokenRetriever: tr}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, tr.count())
+
+	// Revoke the token server-side, every concurrent request is rejected once and shares a single renewal
+	atomic.AddInt32(&tr.n, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < numConcurrentCallers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Post(services.RequestParams{Body: []byte("{}")})
+			if assert.NoError(t, err) {
+				assert.Equal(t, 200, resp.StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, tr.count())
+}
@@ -17,6 +17,7 @@
 package integration
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -55,15 +56,15 @@ func TestBatchEventsSenderTickerFlush(t *testing.T) {
 
 	collector.Run()
 	go blocking(done, 2)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
-	err = collector.AddEvent(event2)
+	err = collector.AddEvent(context.Background(), event2)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event2): %s", err)
-	err = collector.AddEvent(event3)
+	err = collector.AddEvent(context.Background(), event3)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event3): %s", err)
 	<-done
-	collector.Stop()
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	collector.Close(context.Background())
+	assert.Empty(t, collector.Errors())
 }
 
 // Should flush when queue is full and ticker has not ticked
@@ -90,15 +91,15 @@ func TestBatchEventsSenderQueueFlush(t *testing.T) {
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 	collector.Run()
 	go blocking(done, 2)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
-	err = collector.AddEvent(event2)
+	err = collector.AddEvent(context.Background(), event2)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event2): %s", err)
-	err = collector.AddEvent(event3)
+	err = collector.AddEvent(context.Background(), event3)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event3): %s", err)
 	<-done
-	collector.Stop()
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	collector.Close(context.Background())
+	assert.Empty(t, collector.Errors())
 }
 
 // Should flush when queue is full, payLoadSize limitation hit before batch size
@@ -139,22 +140,22 @@ func TestBatchEventsSenderPayloadSizeQueueFlush(t *testing.T) {
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 	collector.Run()
 	go blocking(done, 10)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
-	err = collector.AddEvent(event2)
+	err = collector.AddEvent(context.Background(), event2)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event2): %s", err)
-	err = collector.AddEvent(event3)
+	err = collector.AddEvent(context.Background(), event3)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event3): %s", err)
-	err = collector.AddEvent(event4)
+	err = collector.AddEvent(context.Background(), event4)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event4): %s", err)
-	err = collector.AddEvent(event5)
+	err = collector.AddEvent(context.Background(), event5)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event5): %s", err)
-	err = collector.AddEvent(event6)
+	err = collector.AddEvent(context.Background(), event6)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event6): %s", err)
 
 	<-done
-	collector.Stop()
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	collector.Close(context.Background())
+	assert.Empty(t, collector.Errors())
 }
 
 // Should flush when queue is full, only payloadSize is hit, batchsize has no impact
@@ -189,22 +190,22 @@ func TestBatchEventsSenderBatchSizeNoImpact(t *testing.T) {
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 	collector.Run()
 	go blocking(done, 3)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
-	err = collector.AddEvent(event2)
+	err = collector.AddEvent(context.Background(), event2)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event2): %s", err)
-	err = collector.AddEvent(event3)
+	err = collector.AddEvent(context.Background(), event3)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event3): %s", err)
-	err = collector.AddEvent(event4)
+	err = collector.AddEvent(context.Background(), event4)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event4): %s", err)
-	err = collector.AddEvent(event5)
+	err = collector.AddEvent(context.Background(), event5)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event5): %s", err)
 
 	//this sleep should not exceed the interval, otherwise interval will force the flush
 	time.Sleep(5 * time.Millisecond)
-	collector.Stop()
+	collector.Close(context.Background())
 	<-done
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	assert.Empty(t, collector.Errors())
 }
 
 // Should flush when queue is full, batchSize is hit before payLoadSize
@@ -240,20 +241,20 @@ func TestBatchEventsSenderPayloadSizeNoImpact(t *testing.T) {
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 	collector.Run()
 	go blocking(done, 5)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
-	err = collector.AddEvent(event2)
+	err = collector.AddEvent(context.Background(), event2)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event2): %s", err)
-	err = collector.AddEvent(event3)
+	err = collector.AddEvent(context.Background(), event3)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event3): %s", err)
-	err = collector.AddEvent(event4)
+	err = collector.AddEvent(context.Background(), event4)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event4): %s", err)
-	err = collector.AddEvent(event5)
+	err = collector.AddEvent(context.Background(), event5)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event5): %s", err)
 
-	collector.Stop()
+	collector.Close(context.Background())
 	<-done
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	assert.Empty(t, collector.Errors())
 }
 
 // Should flush when Queue is full, both batchSize and payLoadSize are hit while processing the Events Queue
@@ -285,23 +286,23 @@ func TestBatchEventsSenderFlushBothBatchSizePayloadSize(t *testing.T) {
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 	collector.Run()
 	go blocking(done, 3)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
-	err = collector.AddEvent(event2)
+	err = collector.AddEvent(context.Background(), event2)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event2): %s", err)
-	err = collector.AddEvent(event3)
+	err = collector.AddEvent(context.Background(), event3)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event3): %s", err)
-	err = collector.AddEvent(event4)
+	err = collector.AddEvent(context.Background(), event4)
 	time.Sleep(1 * time.Millisecond)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event4): %s", err)
-	err = collector.AddEvent(event5)
+	err = collector.AddEvent(context.Background(), event5)
 	time.Sleep(1 * time.Millisecond)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event5): %s", err)
 
-	collector.Stop()
+	collector.Close(context.Background())
 	<-done
 	time.Sleep(3 * time.Millisecond)
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	assert.Empty(t, collector.Errors())
 }
 
 // Should flush when quit signal is sent
@@ -319,12 +320,12 @@ func TestBatchEventsSenderQuitFlush(t *testing.T) {
 
 	collector.Run()
 	go blocking(done, 3)
-	err = collector.AddEvent(event1)
+	err = collector.AddEvent(context.Background(), event1)
 	assert.Emptyf(t, err, "Error collector.AddEvent(event1): %s", err)
 
-	collector.Stop()
+	collector.Close(context.Background())
 	time.Sleep(3 * time.Millisecond)
-	assert.Equal(t, 0, len(collector.EventsQueue))
+	assert.Empty(t, collector.Errors())
 	<-done
 }
 
@@ -338,7 +339,7 @@ func addEventBatch(collector *ingest.BatchEventsSender, event1 ingest.Event) {
 	defer wg.Done()
 	for i := 0; i < 3; i++ {
 		time.Sleep(time.Duration(rand.Intn(3)) * time.Second)
-		if err := collector.AddEvent(event1); err != nil {
+		if err := collector.AddEvent(context.Background(), event1); err != nil {
 			fmt.Println(err)
 			return
 		}
@@ -372,17 +373,18 @@ func TestBatchEventsSenderErrorHandle(t *testing.T) {
 	// it is possible that the stop signal is set by the maxAllowedErr constraint,
 	// but while there are some events are pushed to the queue by some threads before we do last flush
 	// therefore the last flush that flush all content in queue will add more errors than maxAllowedErr
-	assert.True(t, len(collector.Errors) >= maxAllowedErr)
+	errs := collector.Errors()
+	assert.True(t, len(errs) >= maxAllowedErr)
 
-	httpError, ok := collector.Errors[0].Error.(*util.HTTPError)
+	httpError, ok := errs[0].Err.(*util.HTTPError)
 	require.True(t, ok)
 	assert.Equal(t, httpError.HTTPStatusCode, 401)
 	assert.Equal(t, httpError.HTTPStatus, "401 Unauthorized")
 	errFound := httpError.Message == "Error validating request" || httpError.Message == "Invalid or Expired Bearer Token"
 	assert.True(t, errFound)
 
-	assert.Equal(t, &host, collector.Errors[0].Events[0].Host)
-	collector.Stop()
+	assert.Equal(t, &host, errs[0].Events[0].Host)
+	collector.Close(context.Background())
 }
 
 func TestBatchEventsSenderErrorHandleWithCallBack(t *testing.T) {
@@ -399,13 +401,16 @@ func TestBatchEventsSenderErrorHandleWithCallBack(t *testing.T) {
 	collector, err := client.IngestService.NewBatchEventsSenderWithMaxAllowedError(2, 2000, 0, maxAllowedErr)
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 
+	var mux sync.Mutex
 	callbackPrint := ""
-	callback := func(b *ingest.BatchEventsSender) {
-		assert.True(t, len(b.Errors) > 0)
+	callback := func(result ingest.DeliveryResult) {
+		assert.Error(t, result.Err)
+		mux.Lock()
+		defer mux.Unlock()
 		callbackPrint = "call from callback function"
 	}
 
-	collector.SetCallbackHandler(callback)
+	collector.SetDeliveryHandler(callback)
 
 	assert.Equal(t, "", callbackPrint)
 
@@ -420,11 +425,13 @@ func TestBatchEventsSenderErrorHandleWithCallBack(t *testing.T) {
 	go blocking(done, 2)
 	<-done
 
+	mux.Lock()
 	assert.Equal(t, "call from callback function", callbackPrint)
-	collector.Stop()
+	mux.Unlock()
+	collector.Close(context.Background())
 }
 
-func TestBatchEventsSenderRestart(t *testing.T) {
+func TestBatchEventsSenderStopsOnMaxErrors(t *testing.T) {
 	var client = getInvalidClient(t)
 
 	host := "host1"
@@ -438,7 +445,6 @@ func TestBatchEventsSenderRestart(t *testing.T) {
 	collector, err := client.IngestService.NewBatchEventsSenderWithMaxAllowedError(2, 2000, 0, maxAllowedErr)
 	require.Emptyf(t, err, "Error creating NewBatchEventsSender: %s", err)
 
-	// Initial run of the batchSender
 	collector.Run()
 	// start 15 threads to send data simultaneously
 	wg.Add(8)
@@ -448,19 +454,8 @@ func TestBatchEventsSenderRestart(t *testing.T) {
 	wg.Wait()
 
 	// batchSender should have stopped due to maxError hit
-	assert.False(t, collector.IsRunning)
-	assert.True(t, len(collector.Errors) >= 4)
-
-	// restart the batchSender and resend events, everything should work just like the initial run
-	collector.Restart()
-	assert.Nil(t, collector.Errors)
-	assert.True(t, collector.IsRunning)
-
-	// start 15 threads to send data simultaneously
-	wg.Add(8)
-	for i := 0; i < 8; i++ {
-		go addEventBatch(collector, event1)
-	}
-	wg.Wait()
-	assert.True(t, len(collector.Errors) >= 4)
+	err = collector.AddEvent(context.Background(), event1)
+	assert.True(t, errors.Is(err, ingest.ErrBatchEventsSenderClosed))
+	assert.True(t, len(collector.Errors()) >= 4)
+	assert.NoError(t, collector.Close(context.Background()))
 }